@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/app"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/workers"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+// newMemoryServer levanta la API y todos los workers en el proceso del test,
+// con todos los backends en memoria.
+func newMemoryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.Backend = config.BackendConfig{
+		Storage:   config.BackendMemory,
+		Messaging: config.BackendMemory,
+		Cache:     config.BackendMemory,
+	}
+	cfg.Log.Environment = config.EnvironmentDevelopment
+
+	appLogger, err := logger.New("error", "test")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	application, err := app.New(ctx, cfg, appLogger)
+	require.NoError(t, err)
+
+	go workers.Run(ctx, workers.Build(workers.Names(), application), appLogger)
+	timelineHub := application.TimelineHub()
+	go timelineHub.Run(ctx)
+	realtimeGateway, err := application.RealtimeGateway()
+	require.NoError(t, err)
+	go realtimeGateway.Run(ctx)
+
+	server := httptest.NewServer(setupRouter(newRouterDependencies(application, timelineHub, realtimeGateway)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func postJSON(t *testing.T, url string, body any) *http.Response {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(payload))
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// getJSON decodifica la respuesta en out y devuelve el status.
+func getJSON(t *testing.T, url string, out any) int {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestEndToEnd_TweetReachesFollowerTimelineAndNotifications(t *testing.T) {
+	server := newMemoryServer(t)
+	api := server.URL + "/api/v1"
+
+	resp := postJSON(t, api+"/follows/", map[string]string{"followerId": "alice", "followedId": "bob"})
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	resp = postJSON(t, api+"/tweets/", map[string]string{"userId": "bob", "content": "hola @alice #golang"})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created map[string]dmntweet.Tweet
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+	require.Len(t, created, 1)
+	var tweetID string
+	for id := range created {
+		tweetID = id
+	}
+
+	var hashtagPage dmntweet.TweetsPage
+	require.Equal(t, http.StatusOK, getJSON(t, api+"/hashtags/golang/tweets", &hashtagPage))
+	require.Len(t, hashtagPage.Tweets, 1)
+	assert.Equal(t, tweetID, hashtagPage.Tweets[0].ID)
+
+	// El fan-out pasa por el tópico de tweets y dos colas antes de llegar al
+	// timeline del seguidor.
+	var timeline dmntimeline.Timeline
+	require.Eventually(t, func() bool {
+		return getJSON(t, api+"/timeline/alice", &timeline) == http.StatusOK && len(timeline.Entries) > 0
+	}, 10*time.Second, 20*time.Millisecond)
+	assert.Equal(t, tweetID, timeline.Entries[0].TweetID)
+	assert.Equal(t, "bob", timeline.Entries[0].AuthorID)
+
+	var mentions dmnnotification.NotificationsPage
+	require.Eventually(t, func() bool {
+		return getJSON(t, api+"/notifications?user_id=alice", &mentions) == http.StatusOK && len(mentions.Notifications) > 0
+	}, 10*time.Second, 20*time.Millisecond)
+	assert.Equal(t, dmnnotification.TypeMention, mentions.Notifications[0].Type)
+	assert.Equal(t, tweetID, mentions.Notifications[0].TweetID)
+
+	var followers dmnnotification.NotificationsPage
+	require.Eventually(t, func() bool {
+		return getJSON(t, api+"/notifications?user_id=bob", &followers) == http.StatusOK && len(followers.Notifications) > 0
+	}, 10*time.Second, 20*time.Millisecond)
+	assert.Equal(t, dmnnotification.TypeFollower, followers.Notifications[0].Type)
+	assert.Equal(t, int64(1), followers.UnreadCount)
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/createfollow"
@@ -10,30 +12,63 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/juanmalvarez3/twit/pkg/config"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/health"
 	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/middleware"
+	"github.com/juanmalvarez3/twit/pkg/sse"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
-	"github.com/juanmalvarez3/twit/internal/adapters/queue"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/getnotifications"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/readnotifications"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/gateway"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/stream"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/gettimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/streamtimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/gettrends"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/createtweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/gethashtagtweets"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/gettweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/getusertweets"
+	"github.com/juanmalvarez3/twit/internal/workers"
 
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 
 	"go.uber.org/zap"
 )
 
+const serviceName = "twit-api"
+
 func main() {
 	cfg, err := config.New()
 	if err != nil {
 		log.Fatalf("Error cargando configuración: %v", err)
 	}
 
+	inProcess := flag.String("workers", cfg.Worker.InProcess,
+		"workers a correr dentro del proceso de la API separados por coma, o all ("+strings.Join(workers.Names(), ",")+")")
+	flag.Parse()
+
+	var workerNames []string
+	if *inProcess != "" {
+		workerNames, err = workers.Parse(*inProcess)
+		if err != nil {
+			log.Fatalf("Error en --workers: %v", err)
+		}
+	}
+
 	appLogger, err := logger.New(cfg.Log.Level, cfg.Log.Environment)
 	if err != nil {
 		log.Fatalf("Error inicializando logger: %v", err)
@@ -44,29 +79,54 @@ func main() {
 		zap.String("environment", cfg.Log.Environment),
 		zap.String("logLevel", cfg.Log.Level))
 
-	sqsAdapter, err := queue.NewAdapter(cfg)
+	shutdownTracing, err := tracing.New(context.Background(), cfg.Tracing, serviceName)
+	if err != nil {
+		appLogger.Fatal("Error inicializando tracing", zap.Error(err))
+	}
+
+	application, err := app.New(context.Background(), cfg, appLogger)
 	if err != nil {
-		appLogger.Fatal("Error inicializando adaptador SQS", zap.Error(err))
+		appLogger.Fatal("Error inicializando dependencias", zap.Error(err))
 	}
+	defer func() {
+		if err := application.Close(); err != nil {
+			appLogger.Error("Error cerrando dependencias", zap.Error(err))
+		}
+	}()
 
-	populateTimelineCachePublisher := queue.NewPopulateTimelineCachePublisher(sqsAdapter, cfg.SQS.PopulateCacheQueue, appLogger)
-	rebuildTimelinePublisher := queue.NewRebuildTimelinePublisher(sqsAdapter, cfg.SQS.RebuildTimelineQueue, appLogger)
+	// Una sola suscripción a Redis por instancia reparte los eventos nuevos
+	// entre todas las conexiones de streaming (SSE y WebSocket).
+	streamCtx, stopStreams := context.WithCancel(context.Background())
+	defer stopStreams()
+	timelineHub := application.TimelineHub()
+	go timelineHub.Run(streamCtx)
+	realtimeGateway, err := application.RealtimeGateway()
+	if err != nil {
+		appLogger.Fatal("Error inicializando el gateway WebSocket", zap.Error(err))
+	}
+	go realtimeGateway.Run(streamCtx)
 
-	createTweetUC := createtweet.Provide()
-	getTweetUC := gettweet.Provide()
-	getTimelineUC := gettimeline.Provide(
-		populateTimelineCachePublisher,
-		rebuildTimelinePublisher,
-		appLogger,
-	)
-	createFollowUC := createfollow.Provide(appLogger)
+	// Los workers avisan por Redis cuando cambia un timeline; cada instancia
+	// descarta su copia en memoria.
+	application.RunInvalidations()
+
+	deps := newRouterDependencies(application, timelineHub, realtimeGateway)
 
-	deps := &RouterDependencies{
-		CreateTweetUC:  createTweetUC,
-		GetTweetUC:     getTweetUC,
-		GetTimelineUC:  getTimelineUC,
-		CreateFollowUC: createFollowUC,
-		Logger:         appLogger,
+	// Con los backends en memoria la API y los workers tienen que compartir
+	// proceso: los mensajes no salen de él.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	workersDone := make(chan struct{})
+	if len(workerNames) > 0 {
+		selectedWorkers := workers.Build(workerNames, application)
+		deps.Health.Add(workers.Checks(selectedWorkers)...)
+		appLogger.Info("Iniciando workers en el proceso de la API", zap.Strings("workers", workerNames))
+		go func() {
+			workers.Run(workersCtx, selectedWorkers, appLogger)
+			close(workersDone)
+		}()
+	} else {
+		close(workersDone)
 	}
 
 	router := setupRouter(deps)
@@ -89,21 +149,86 @@ func main() {
 	<-quit
 	appLogger.Info("Apagando servidor...")
 
+	// Cerrar los streams primero: Shutdown espera a que terminen los handlers
+	// activos y las conexiones SSE no terminan por sí solas.
+	stopStreams()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
 		appLogger.Fatal("Error en el cierre del servidor", zap.Error(err))
 	}
 
+	stopWorkers()
+	select {
+	case <-workersDone:
+	case <-time.After(time.Duration(cfg.Worker.ShutdownTimeoutSeconds) * time.Second):
+		appLogger.Warn("Timeout esperando que terminen los workers")
+	}
+	if err := shutdownTracing(ctx); err != nil {
+		appLogger.Error("Error cerrando tracing", zap.Error(err))
+	}
+
 	appLogger.Info("Servidor detenido")
 }
 
 type RouterDependencies struct {
-	CreateTweetUC  createtweet.UseCase
-	GetTweetUC     gettweet.UseCase
-	GetTimelineUC  gettimeline.UseCase
-	CreateFollowUC createfollow.UseCase
-	Logger         logger.LoggerInterface
+	CreateTweetUC       createtweet.UseCase
+	GetTweetUC          gettweet.UseCase
+	HashtagUC           gethashtagtweets.UseCase
+	UserTweetsUC        getusertweets.UseCase
+	TrendsUC            gettrends.UseCase
+	NotificationsUC     getnotifications.UseCase
+	ReadNotificationsUC readnotifications.UseCase
+	GetTimelineUC       gettimeline.UseCase
+	StreamTimelineUC    streamtimeline.UseCase
+	StreamHeartbeat     time.Duration
+	Realtime            *gateway.Gateway
+	CreateFollowUC      createfollow.UseCase
+	Health              *health.Registry
+	Logger              logger.LoggerInterface
+}
+
+func newRouterDependencies(application *app.App, timelineHub *stream.Hub, realtimeGateway *gateway.Gateway) *RouterDependencies {
+	return &RouterDependencies{
+		CreateTweetUC:       application.CreateTweet(),
+		GetTweetUC:          application.GetTweet(),
+		HashtagUC:           application.GetHashtagTweets(),
+		UserTweetsUC:        application.GetUserTweets(),
+		TrendsUC:            application.GetTrends(),
+		NotificationsUC:     application.GetNotifications(),
+		ReadNotificationsUC: application.ReadNotifications(),
+		GetTimelineUC:       application.GetTimeline(),
+		StreamTimelineUC:    application.StreamTimeline(timelineHub),
+		StreamHeartbeat:     time.Duration(application.Config.Stream.HeartbeatSeconds) * time.Second,
+		Realtime:            realtimeGateway,
+		CreateFollowUC:      application.CreateFollow(),
+		Health:              newHealthRegistry(application),
+		Logger:              application.Logger,
+	}
+}
+
+func newHealthRegistry(application *app.App) *health.Registry {
+	cfg, infra := application.Config, application.Infra
+	registry := health.NewRegistry(
+		time.Duration(cfg.Health.CheckTimeoutMs)*time.Millisecond,
+		application.TableCheck(cfg.DynamoDB.TweetsTable),
+		application.TableCheck(cfg.DynamoDB.FollowsTable),
+		application.TableCheck(cfg.DynamoDB.TimelinesTable),
+		application.TableCheck(cfg.DynamoDB.HashtagsTable),
+		application.TableCheck(cfg.DynamoDB.NotificationsTable),
+		health.Redis(infra.Cache),
+		health.Broker(infra.Broker, broker.TopicTweets),
+		health.Broker(infra.Broker, broker.TopicFollows),
+		health.Broker(infra.Broker, broker.TopicPopulateCache),
+		health.Broker(infra.Broker, broker.TopicRebuildTimeline),
+	)
+	// Con MESSAGING_BACKEND=redis y la caché en otro backend, los streams
+	// tienen su propia conexión.
+	if infra.BrokerRedis != nil {
+		registry.Add(health.NewCheck("redis-broker", infra.BrokerRedis.Ping))
+	}
+	return registry
 }
 
 func setupRouter(deps *RouterDependencies) *gin.Engine {
@@ -113,18 +238,23 @@ func setupRouter(deps *RouterDependencies) *gin.Engine {
 
 	engine := gin.New()
 	engine.Use(gin.Recovery())
+	engine.Use(otelgin.Middleware(serviceName))
+	engine.Use(metrics.GinMiddleware())
+	engine.Use(middleware.RequestID())
 	engine.Use(cors.New(cors.Config{
 		AllowAllOrigins:  true,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID"},
+		ExposeHeaders:    []string{"Content-Length", middleware.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
+	engine.Use(middleware.ErrorHandler(deps.Logger))
 
-	engine.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-	})
+	engine.GET("/health", gin.WrapH(health.LivenessHandler()))
+	engine.GET("/livez", gin.WrapH(health.LivenessHandler()))
+	engine.GET("/readyz", gin.WrapH(health.ReadinessHandler(deps.Health)))
+	engine.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	v1 := engine.Group("/api/v1")
 	{
@@ -132,15 +262,13 @@ func setupRouter(deps *RouterDependencies) *gin.Engine {
 		{
 			t.POST("/", func(c *gin.Context) {
 				var tweetRequest dmntweet.Tweet
-				if err := c.BindJSON(&tweetRequest); err != nil {
-					deps.Logger.Error("Error deserializando request", zap.Error(err))
-					c.JSON(http.StatusBadRequest, gin.H{"error": "No se pudo deserializar el request"})
+				if err := c.ShouldBindJSON(&tweetRequest); err != nil {
+					_ = c.Error(invalidBodyError(err))
 					return
 				}
 				tweet, err := deps.CreateTweetUC.CreateTweet(c.Request.Context(), &tweetRequest)
 				if err != nil {
-					deps.Logger.Error("Error creando tweet", zap.Error(err))
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "No se pudo crear el tweet"})
+					_ = c.Error(err)
 					return
 				}
 				c.JSON(http.StatusCreated, gin.H{tweet.ID: tweet})
@@ -149,48 +277,264 @@ func setupRouter(deps *RouterDependencies) *gin.Engine {
 				id := c.Param("id")
 				tweet, err := deps.GetTweetUC.GetTweet(c.Request.Context(), id)
 				if err != nil {
-					deps.Logger.Error("Error obteniendo tweet", zap.Error(err))
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "No se pudo obtener el tweet"})
+					_ = c.Error(err)
 					return
 				}
 				c.JSON(http.StatusOK, tweet)
 			})
 		}
 
+		h := v1.Group("/hashtags")
+		{
+			h.GET("/:tag/tweets", func(c *gin.Context) {
+				tag := c.Param("tag")
+				limit, err := queryLimit(c)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+
+				page, err := deps.HashtagUC.Exec(c.Request.Context(), tag, limit, c.Query("cursor"))
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				c.JSON(http.StatusOK, page)
+			})
+		}
+
+		u := v1.Group("/users")
+		{
+			u.GET("/:id/tweets", func(c *gin.Context) {
+				limit, err := queryLimit(c)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				includeReplies, err := queryBool(c, "include_replies", false)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				includeRetweets, err := queryBool(c, "include_retweets", true)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+
+				page, err := deps.UserTweetsUC.Exec(c.Request.Context(), c.Param("id"), limit, c.Query("cursor"), includeReplies, includeRetweets)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				c.JSON(http.StatusOK, page)
+			})
+		}
+
+		v1.GET("/trends", func(c *gin.Context) {
+			limit, err := queryLimit(c)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+
+			trends, err := deps.TrendsUC.Exec(c.Request.Context(), c.Query("window"), limit)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			c.JSON(http.StatusOK, trends)
+		})
+
 		f := v1.Group("/follows")
 		{
 			f.POST("/", func(c *gin.Context) {
 				var followRequest dmnfollow.Follow
-				if err := c.BindJSON(&followRequest); err != nil {
-					deps.Logger.Error("Error deserializando request", zap.Error(err))
-					c.JSON(http.StatusBadRequest, gin.H{"error": "No se pudo deserializar el request"})
+				if err := c.ShouldBindJSON(&followRequest); err != nil {
+					_ = c.Error(invalidBodyError(err))
 					return
 				}
 
 				err := deps.CreateFollowUC.CreateFollow(c.Request.Context(), followRequest)
 				if err != nil {
-					deps.Logger.Error("Error creando follow", zap.Error(err))
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "No se pudo crear el follow"})
+					_ = c.Error(err)
 					return
 				}
 				c.JSON(http.StatusAccepted, gin.H{"message": "Follow creado!"})
 			})
 		}
 
+		n := v1.Group("/notifications")
+		{
+			n.GET("", func(c *gin.Context) {
+				limit, err := queryLimit(c)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+
+				page, err := deps.NotificationsUC.Exec(c.Request.Context(), c.Query("user_id"), limit, c.Query("cursor"))
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				c.JSON(http.StatusOK, page)
+			})
+
+			n.POST("/read", func(c *gin.Context) {
+				var readRequest struct {
+					UserID string   `json:"userId"`
+					IDs    []string `json:"ids"`
+				}
+				if err := c.ShouldBindJSON(&readRequest); err != nil {
+					_ = c.Error(invalidBodyError(err))
+					return
+				}
+
+				result, err := deps.ReadNotificationsUC.Exec(c.Request.Context(), readRequest.UserID, readRequest.IDs)
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				c.JSON(http.StatusOK, result)
+			})
+		}
+
+		// Gateway WebSocket: una conexión por cliente para timeline,
+		// notificaciones y conversaciones.
+		v1.GET("/ws", func(c *gin.Context) {
+			userID, err := deps.Realtime.Authenticate(c.Request)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			deps.Realtime.Serve(c.Writer, c.Request, userID)
+		})
+
 		tl := v1.Group("/timeline")
 		{
 			tl.GET("/:user_id", func(c *gin.Context) {
 				userID := c.Param("user_id")
 				timeline, err := deps.GetTimelineUC.Exec(c.Request.Context(), userID)
 				if err != nil {
-					deps.Logger.Error("Error obteniendo timeline", zap.String("user_id", userID), zap.Error(err))
-					c.JSON(http.StatusInternalServerError, gin.H{"error": "No se pudo obtener el timeline"})
+					_ = c.Error(err)
 					return
 				}
 				c.JSON(http.StatusOK, timeline)
 			})
+			tl.GET("/:user_id/stream", func(c *gin.Context) {
+				streamTimeline(c, deps)
+			})
 		}
 	}
 
 	return engine
 }
+
+// streamTimeline envía por SSE las entradas nuevas del timeline. Si el cliente
+// reconecta con Last-Event-ID, primero recibe las que se perdió.
+func streamTimeline(c *gin.Context, deps *RouterDependencies) {
+	ctx := c.Request.Context()
+	userID := c.Param("user_id")
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("lastEventId")
+	}
+
+	timelineStream, err := deps.StreamTimelineUC.Exec(ctx, userID, lastEventID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer timelineStream.Close()
+
+	for key, value := range sse.Headers {
+		c.Header(key, value)
+	}
+	c.Status(http.StatusOK)
+
+	write := func(entry dmntimeline.TimelineEntry) error {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return sse.WriteEvent(c.Writer, entry.EventID(), "timeline", data)
+	}
+
+	if err := sse.WriteRetry(c.Writer, 3*time.Second); err != nil {
+		return
+	}
+	sent := make(map[string]struct{}, len(timelineStream.Backlog))
+	for _, entry := range timelineStream.Backlog {
+		if err := write(entry); err != nil {
+			return
+		}
+		sent[entry.TweetID] = struct{}{}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(deps.StreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := sse.WriteComment(c.Writer, "ping"); err != nil {
+				return
+			}
+		case entry, ok := <-timelineStream.Live:
+			// Canal cerrado: el cliente no consumió a tiempo o la instancia se
+			// está apagando. Al reconectar retoma desde el último id recibido.
+			if !ok {
+				return
+			}
+			if _, dup := sent[entry.TweetID]; dup {
+				continue
+			}
+			if err := write(entry); err != nil {
+				deps.Logger.Warn("Error escribiendo evento de timeline",
+					zap.String("user_id", userID),
+					zap.Error(err))
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func invalidBodyError(err error) *apperrors.AppError {
+	return apperrors.NewInvalidInputError("No se pudo deserializar el request", err).
+		WithCode(apperrors.CodeInvalidRequestBody)
+}
+
+func queryLimit(c *gin.Context) (int, error) {
+	value := c.Query("limit")
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, apperrors.NewInvalidInputError("El parámetro limit debe ser un entero positivo", err).
+			WithCode(apperrors.CodeInvalidLimit)
+	}
+	return limit, nil
+}
+
+func queryBool(c *gin.Context, param string, defaultValue bool) (bool, error) {
+	value := c.Query(param)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperrors.NewInvalidInputError(fmt.Sprintf("El parámetro %s no es válido", param), err).
+			WithCode(apperrors.CodeInvalidQueryParam).
+			WithDetails(map[string]any{"param": param})
+	}
+	return parsed, nil
+}
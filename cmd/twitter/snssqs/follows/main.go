@@ -1,95 +0,0 @@
-package main
-
-import (
-	"context"
-	"encoding/json"
-	"os"
-	"os/signal"
-	"syscall"
-
-	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
-
-	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
-	"github.com/juanmalvarez3/twit/internal/adapters/queue"
-
-	"github.com/juanmalvarez3/twit/internal/adapters/sns"
-
-	processFollowUC "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/processnewfollow"
-	"github.com/juanmalvarez3/twit/pkg/config"
-	"github.com/juanmalvarez3/twit/pkg/logger"
-
-	"go.uber.org/zap"
-)
-
-func main() {
-	cfg, err := config.New()
-	if err != nil {
-		panic("Error cargando configuración: " + err.Error())
-	}
-
-	appLogger, err := logger.New(cfg.Log.Level, cfg.Log.Environment)
-	if err != nil {
-		panic("Error inicializando logger: " + err.Error())
-	}
-	defer appLogger.Sync()
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	appLogger.Info("Iniciando worker de procesamiento de follows creados",
-		zap.String("env", cfg.Log.Environment),
-		zap.String("queue", cfg.SQS.ProcessFollowQueue))
-
-	sqsAdapter, err := queue.NewAdapter(cfg)
-	if err != nil {
-		appLogger.Fatal("Error inicializando adaptador SQS", zap.Error(err))
-	}
-
-	processFollowUseCase := processFollowUC.Provide(sqsAdapter, cfg, appLogger)
-
-	messageHandler := func(messages []types.Message) error {
-		for _, message := range messages {
-			appLogger.Info("Procesando mensaje SNS", zap.String("messageId", *message.MessageId))
-			appLogger.Info("Body del mensaje", zap.Any("body", *message.Body))
-
-			var snsMessage sns.SNSMessage
-			if err := json.Unmarshal([]byte(*message.Body), &snsMessage); err != nil {
-				appLogger.Error("Error al deserializar mensaje SNS", zap.Error(err))
-				continue
-			}
-			appLogger.Info("SNS Message", zap.Any("SNS Message", snsMessage))
-
-			var followEvent events.FollowCreatedEvent
-			if err := json.Unmarshal([]byte(snsMessage.Message), &followEvent); err != nil {
-				appLogger.Error("Error al deserializar evento de follow", zap.Error(err))
-				continue
-			}
-
-			appLogger.Info("Follow Event Message", zap.Any("Follow Event Message", followEvent))
-
-			if err := processFollowUseCase.ProcessNewFollow(ctx, followEvent); err != nil {
-				appLogger.Error("Error al procesar follow creado",
-					zap.Error(err),
-					zap.String("followerId", followEvent.Follow.FollowerID),
-					zap.String("followedId", followEvent.Follow.FollowedID))
-				continue
-			}
-
-			appLogger.Info("Follow procesado correctamente",
-				zap.String("followerId", followEvent.Follow.FollowerID),
-				zap.String("followedId", followEvent.Follow.FollowedID))
-		}
-		return nil
-	}
-
-	consumer := queue.New(sqsAdapter, cfg.SQS.ProcessFollowQueue, messageHandler, appLogger)
-	go consumer.Start(ctx)
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	appLogger.Info("Cerrando worker...")
-	cancel()
-	appLogger.Info("Worker cerrado correctamente")
-}
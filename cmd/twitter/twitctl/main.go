@@ -0,0 +1,170 @@
+// twitctl agrupa las tareas de operación que no son parte de la API ni de los
+// workers.
+//
+//	twitctl migrate [--check] [--recreate=tabla,...]
+//
+// migrate crea o actualiza, según los backends configurados, las tablas de
+// DynamoDB con sus índices y TTL, los tópicos SNS, las colas SQS con sus DLQ y
+// suscripciones, o las migraciones de la base SQL, y después verifica que lo
+// desplegado coincida con lo que esperan los repositorios. Con --check sólo
+// verifica. Termina con código 1 si queda alguna diferencia.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/internal/provisioning"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	pkgdynamodb "github.com/juanmalvarez3/twit/pkg/dynamodb"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	pkgsns "github.com/juanmalvarez3/twit/pkg/sns"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+	pkgsqs "github.com/juanmalvarez3/twit/pkg/sqs"
+
+	"go.uber.org/zap"
+)
+
+const usage = "uso: twitctl migrate [--check] [--recreate=tabla,...]"
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "migrate" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	check := flags.Bool("check", false, "sólo verificar, sin crear ni modificar recursos")
+	recreate := flags.String("recreate", "",
+		"tablas que se pueden borrar y recrear si su clave no coincide, separadas por coma (se pierden sus datos)")
+	flags.Parse(os.Args[2:])
+
+	cfg, err := config.New()
+	if err != nil {
+		panic("Error cargando configuración: " + err.Error())
+	}
+
+	appLogger, err := logger.New(cfg.Log.Level, cfg.Log.Environment)
+	if err != nil {
+		panic("Error inicializando logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	problems, err := migrate(context.Background(), cfg, *check, parseList(*recreate), appLogger)
+	if err != nil {
+		appLogger.Error("Error en la migración", zap.Error(err))
+		os.Exit(1)
+	}
+	for _, problem := range problems {
+		appLogger.Error("El esquema desplegado no coincide con el declarado",
+			zap.String("resource", problem.Resource),
+			zap.String("detail", problem.Detail))
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+	appLogger.Info("Esquema al día",
+		zap.String("storage", cfg.Backend.Storage),
+		zap.String("messaging", cfg.Backend.Messaging))
+}
+
+// migrate aplica (salvo con check) y verifica el esquema de los backends de
+// almacenamiento y mensajería configurados. Los backends en memoria o sobre
+// Redis no tienen nada que crear.
+func migrate(ctx context.Context, cfg *config.Config, check bool, recreate map[string]bool, log *logger.Logger) ([]provisioning.Problem, error) {
+	declared := provisioning.Declare(cfg.DynamoDB, app.AWSRoutes(cfg), broker.DefaultTopology(), cfg.Broker.MaxDeliveries)
+
+	var (
+		schema   provisioning.Schema
+		problems []provisioning.Problem
+		dynamo   provisioning.DynamoDBAPI
+		snsAPI   provisioning.SNSAPI
+		sqsAPI   provisioning.SQSAPI
+	)
+
+	switch cfg.Backend.Storage {
+	case config.BackendDynamoDB:
+		client, err := pkgdynamodb.New(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error inicializando cliente DynamoDB: %w", err)
+		}
+		dynamo = client
+		schema.Tables = declared.Tables
+	case config.BackendSQL:
+		sqlProblems, err := migrateSQL(ctx, cfg, check, log)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, sqlProblems...)
+	}
+
+	if cfg.Backend.Messaging == config.BackendAWS {
+		snsClient, err := pkgsns.New(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error inicializando cliente SNS: %w", err)
+		}
+		sqsClient, err := pkgsqs.New(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error inicializando cliente SQS: %w", err)
+		}
+		snsAPI, sqsAPI = snsClient, sqsClient
+		schema.Topics = declared.Topics
+		schema.Queues = declared.Queues
+		schema.Subscriptions = declared.Subscriptions
+	}
+
+	m := provisioning.New(dynamo, snsAPI, sqsAPI, provisioning.Options{Recreate: recreate}, log)
+	if !check {
+		if err := m.Apply(ctx, schema); err != nil {
+			return nil, err
+		}
+	}
+	awsProblems, err := m.Verify(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+	return append(problems, awsProblems...), nil
+}
+
+// migrateSQL aplica las migraciones pendientes de la base SQL, o con check
+// informa cuáles faltan.
+func migrateSQL(ctx context.Context, cfg *config.Config, check bool, log *logger.Logger) ([]provisioning.Problem, error) {
+	db, err := sqldb.Open(ctx, cfg.SQL.Driver, cfg.SQL.DSN, 1)
+	if err != nil {
+		return nil, fmt.Errorf("error abriendo la base SQL: %w", err)
+	}
+	defer db.Close()
+
+	if !check {
+		applied, err := sqldb.Migrate(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Migraciones SQL aplicadas", zap.Ints("versions", applied))
+	}
+
+	pending, err := sqldb.Pending(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	var problems []provisioning.Problem
+	for _, version := range pending {
+		problems = append(problems, provisioning.Problem{Resource: "sql", Detail: fmt.Sprintf("falta aplicar la migración %d", version)})
+	}
+	return problems, nil
+}
+
+func parseList(value string) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			set[item] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/internal/workers"
+	"github.com/juanmalvarez3/twit/pkg/admin"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+	cfg, err := config.New()
+	if err != nil {
+		panic("Error cargando configuración: " + err.Error())
+	}
+
+	selected := flag.String("workers", cfg.Worker.Workers,
+		"workers a ejecutar separados por coma, o all ("+strings.Join(workers.Names(), ",")+")")
+	flag.Parse()
+
+	names, err := workers.Parse(*selected)
+	if err != nil {
+		panic("Error en --workers: " + err.Error())
+	}
+
+	appLogger, err := logger.New(cfg.Log.Level, cfg.Log.Environment)
+	if err != nil {
+		panic("Error inicializando logger: " + err.Error())
+	}
+	defer appLogger.Sync()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shutdownTracing, err := tracing.New(ctx, cfg.Tracing, "twit-worker")
+	if err != nil {
+		appLogger.Fatal("Error inicializando tracing", zap.Error(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	adminServer := admin.New(cfg.Metrics.AdminPort, appLogger)
+	adminServer.Start()
+
+	appLogger.Info("Iniciando workers",
+		zap.String("env", cfg.Log.Environment),
+		zap.Strings("workers", names))
+
+	application, err := app.New(ctx, cfg, appLogger)
+	if err != nil {
+		appLogger.Fatal("Error inicializando dependencias", zap.Error(err))
+	}
+	defer func() {
+		if err := application.Close(); err != nil {
+			appLogger.Error("Error cerrando dependencias", zap.Error(err))
+		}
+	}()
+	selectedWorkers := workers.Build(names, application)
+
+	adminServer.WithHealth(health.NewRegistry(
+		time.Duration(cfg.Health.CheckTimeoutMs)*time.Millisecond,
+		workers.Checks(selectedWorkers)...,
+	))
+
+	done := make(chan struct{})
+	go func() {
+		workers.Run(ctx, selectedWorkers, appLogger)
+		close(done)
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	// Los consumidores dejan de recibir mensajes pero terminan el lote en
+	// curso; se espera hasta el timeout antes de cortar.
+	appLogger.Info("Cerrando workers...")
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Duration(cfg.Worker.ShutdownTimeoutSeconds) * time.Second):
+		appLogger.Warn("Timeout esperando que terminen los workers")
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := adminServer.Shutdown(shutdownCtx); err != nil {
+		appLogger.Error("Error cerrando listener administrativo", zap.Error(err))
+	}
+	appLogger.Info("Workers cerrados correctamente")
+}
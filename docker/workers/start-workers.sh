@@ -33,48 +33,7 @@ if [ $RETRY -eq $MAX_RETRIES ]; then
     echo "Continuando de todos modos ya que esto suele funcionar..."
 fi
 
-# Iniciar workers en background
-#echo "Iniciando worker: orchestrate-fanout"
-#/bin/orchestratefanout &
-#ORCHESTRATE_PID=$!
-
-echo "Iniciando worker: update-timeline"
-/bin/update-timeline &
-UPDATE_TIMELINE_PID=$!
-
-echo "Iniciando worker SNS: tweets"
-/bin/tweets &
-TWEETS_PID=$!
-
-echo "Iniciando worker SNS: follows"
-/bin/follows &
-FOLLOWS_PID=$!
-
-# echo "Iniciando worker: process-new-follow"
-# /bin/process-new-follow &
-# PROCESS_FOLLOW_PID=$!
-
-echo "Iniciando worker: populate-cache"
-/bin/populate-cache &
-POPULATE_CACHE_PID=$!
-
-echo "Iniciando worker: rebuild-timeline"
-/bin/rebuild-timeline &
-REBUILD_TIMELINE_PID=$!
-
-echo "Todos los workers iniciados correctamente."
-
-# Función para manejar señales
-handle_signal() {
-    echo "Recibida señal para terminar, deteniendo workers..."
-    kill $UPDATE_TIMELINE_PID $REBUILD_TIMELINE_PID $TWEETS_PID $FOLLOWS_PID $POPULATE_CACHE_PID 2>/dev/null || true
-    wait
-    echo "Todos los workers detenidos."
-    exit 0
-}
-
-# Registrar manejador de señales
-trap handle_signal SIGTERM SIGINT
-
-# Mantener el script en ejecución para que Docker no detenga el contenedor
-wait
+# Un solo proceso corre los workers elegidos en WORKERS (por defecto todos)
+# bajo un supervisor, con un único listener de /metrics y /health en ADMIN_PORT.
+echo "Iniciando workers: ${WORKERS:-all}"
+exec /bin/twit-worker --workers="${WORKERS:-all}"
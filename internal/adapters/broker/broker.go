@@ -0,0 +1,129 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+)
+
+// Tópicos en los que publica la aplicación.
+const (
+	TopicTweets          = "tweets"
+	TopicFollows         = "follows"
+	TopicUpdateTimeline  = "update-timeline"
+	TopicPopulateCache   = "populate-cache"
+	TopicRebuildTimeline = "rebuild-timeline"
+)
+
+// Suscripciones de las que consumen los workers. Cada una recibe su propia
+// copia de los mensajes de sus tópicos.
+const (
+	SubscriptionOrchestrateFanout = "orchestrate-fanout"
+	SubscriptionUpdateTrends      = "update-trends"
+	SubscriptionNotifications     = "notifications"
+	SubscriptionProcessNewFollow  = "process-new-follow"
+	SubscriptionUpdateTimeline    = "update-timeline"
+	SubscriptionPopulateCache     = "populate-cache"
+	SubscriptionRebuildTimeline   = "rebuild-timeline"
+)
+
+// Message es un mensaje recibido de una suscripción.
+type Message struct {
+	ID         string
+	Topic      string
+	Body       []byte
+	Attributes map[string]string
+	// Receipt identifica esta entrega para confirmarla con Ack; lo completa
+	// cada implementación.
+	Receipt string
+}
+
+// Context devuelve el contexto con la traza propagada por el publicador en
+// los atributos del mensaje.
+func (m Message) Context(ctx context.Context) context.Context {
+	return tracing.Extract(ctx, m.Attributes)
+}
+
+// Publisher publica payload, serializado como JSON, en un tópico.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, payload any, attributes map[string]string) error
+}
+
+// Subscriber entrega los mensajes de una suscripción. Un mensaje que no se
+// confirma con Ack se vuelve a entregar cuando vence su visibilidad.
+type Subscriber interface {
+	// Receive espera hasta wait a que haya mensajes y devuelve hasta max.
+	Receive(ctx context.Context, subscription string, max int, wait time.Duration) ([]Message, error)
+	Ack(ctx context.Context, subscription string, message Message) error
+}
+
+// Broker es un Publisher y Subscriber que además sabe verificar sus tópicos y
+// suscripciones.
+type Broker interface {
+	Publisher
+	Subscriber
+	// Ping verifica que exista el tópico o la suscripción name.
+	Ping(ctx context.Context, name string) error
+}
+
+// Topology indica de qué tópicos recibe cada suscripción.
+type Topology map[string][]string
+
+// DefaultTopology es la topología de la aplicación. twitctl migrate crea las
+// suscripciones SNS de los tópicos que van por SNS a partir de ella.
+func DefaultTopology() Topology {
+	return Topology{
+		SubscriptionOrchestrateFanout: {TopicTweets},
+		SubscriptionUpdateTrends:      {TopicTweets},
+		SubscriptionNotifications:     {TopicTweets, TopicFollows},
+		SubscriptionProcessNewFollow:  {TopicFollows},
+		SubscriptionUpdateTimeline:    {TopicUpdateTimeline},
+		SubscriptionPopulateCache:     {TopicPopulateCache},
+		SubscriptionRebuildTimeline:   {TopicRebuildTimeline},
+	}
+}
+
+// Subscriptions devuelve las suscripciones de topic, ordenadas.
+func (t Topology) Subscriptions(topic string) []string {
+	var subscriptions []string
+	for subscription, topics := range t {
+		for _, candidate := range topics {
+			if candidate == topic {
+				subscriptions = append(subscriptions, subscription)
+				break
+			}
+		}
+	}
+	sort.Strings(subscriptions)
+	return subscriptions
+}
+
+// Topics devuelve los tópicos de los que recibe subscription.
+func (t Topology) Topics(subscription string) []string {
+	return t[subscription]
+}
+
+// HasTopic indica si alguna suscripción recibe de topic.
+func (t Topology) HasTopic(topic string) bool {
+	return len(t.Subscriptions(topic)) > 0
+}
+
+// Encode serializa payload y devuelve una copia de attributes con la traza de
+// ctx, para que el consumidor la continúe.
+func Encode(ctx context.Context, payload any, attributes map[string]string) ([]byte, map[string]string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error serializando mensaje: %w", err)
+	}
+
+	carrier := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		carrier[key] = value
+	}
+	tracing.Inject(ctx, carrier)
+	return body, carrier, nil
+}
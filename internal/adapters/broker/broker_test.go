@@ -0,0 +1,131 @@
+package broker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/memory"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+)
+
+// failingSubscriber falla todos los Receive.
+type failingSubscriber struct{}
+
+func (failingSubscriber) Receive(context.Context, string, int, time.Duration) ([]broker.Message, error) {
+	return nil, assert.AnError
+}
+
+func (failingSubscriber) Ack(context.Context, string, broker.Message) error {
+	return nil
+}
+
+// blockingSubscriber deja el Receive abierto hasta que se cancela ctx, como el
+// long polling de SQS.
+type blockingSubscriber struct {
+	polling chan struct{}
+}
+
+func (s blockingSubscriber) Receive(ctx context.Context, _ string, _ int, _ time.Duration) ([]broker.Message, error) {
+	select {
+	case s.polling <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingSubscriber) Ack(context.Context, string, broker.Message) error {
+	return nil
+}
+
+func TestDefaultTopology(t *testing.T) {
+	topology := broker.DefaultTopology()
+
+	assert.Equal(t, []string{
+		broker.SubscriptionNotifications,
+		broker.SubscriptionOrchestrateFanout,
+		broker.SubscriptionUpdateTrends,
+	}, topology.Subscriptions(broker.TopicTweets))
+	assert.Equal(t, []string{
+		broker.SubscriptionNotifications,
+		broker.SubscriptionProcessNewFollow,
+	}, topology.Subscriptions(broker.TopicFollows))
+	assert.Equal(t, []string{broker.TopicTweets, broker.TopicFollows},
+		topology.Topics(broker.SubscriptionNotifications))
+	assert.False(t, topology.HasTopic("missing"))
+}
+
+func TestConsumer_AcksOnlyHandledBatches(t *testing.T) {
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	b := memory.NewBroker(broker.Topology{"sub": {"topic"}}, 50*time.Millisecond)
+
+	consume := func(handlerErr error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		consumer := broker.NewConsumer(b, "sub", func(messages []broker.Message) error {
+			cancel()
+			return handlerErr
+		}, log)
+		consumer.Start(ctx)
+		assert.False(t, consumer.LastSuccessfulPoll().IsZero())
+	}
+
+	require.NoError(t, b.Publish(context.Background(), "topic", "ok", nil))
+	consume(nil)
+
+	require.NoError(t, b.Publish(context.Background(), "topic", "fail", nil))
+	consume(assert.AnError)
+
+	time.Sleep(60 * time.Millisecond)
+	redelivered, err := b.Receive(context.Background(), "sub", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, redelivered, 1)
+	assert.JSONEq(t, `"fail"`, string(redelivered[0].Body))
+}
+
+func TestConsumer_CountsReceiveErrors(t *testing.T) {
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	receiveErrors := metrics.QueueMessages.WithLabelValues("failing-sub", metrics.StageReceive, metrics.ResultError)
+	before := testutil.ToFloat64(receiveErrors)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	consumer := broker.NewConsumer(failingSubscriber{}, "failing-sub", func([]broker.Message) error { return nil }, log)
+	go func() {
+		consumer.Start(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return testutil.ToFloat64(receiveErrors) == before+1 }, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+	assert.True(t, consumer.LastSuccessfulPoll().IsZero())
+}
+
+func TestConsumer_ShutdownIsNotAnError(t *testing.T) {
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	receiveErrors := metrics.QueueMessages.WithLabelValues("idle-sub", metrics.StageReceive, metrics.ResultError)
+	subscriber := blockingSubscriber{polling: make(chan struct{}, 1)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	consumer := broker.NewConsumer(subscriber, "idle-sub", func([]broker.Message) error { return nil }, log)
+	go func() {
+		consumer.Start(ctx)
+		close(done)
+	}()
+
+	<-subscriber.polling
+	cancel()
+	<-done
+	assert.Equal(t, float64(0), testutil.ToFloat64(receiveErrors))
+}
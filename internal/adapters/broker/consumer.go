@@ -0,0 +1,112 @@
+package broker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMaxMessages = 10
+	defaultWaitTime    = 20 * time.Second
+)
+
+type MessageHandler func([]Message) error
+
+// Consumer recibe lotes de una suscripción y los confirma cuando handler los
+// procesa sin error.
+type Consumer struct {
+	subscriber   Subscriber
+	subscription string
+	handler      MessageHandler
+	logger       *logger.Logger
+	maxMessages  int
+	waitTime     time.Duration
+	lastPoll     atomic.Int64
+}
+
+func NewConsumer(subscriber Subscriber, subscription string, handler MessageHandler, logger *logger.Logger) *Consumer {
+	return &Consumer{
+		subscriber:   subscriber,
+		subscription: subscription,
+		handler:      handler,
+		logger:       logger,
+		maxMessages:  defaultMaxMessages,
+		waitTime:     defaultWaitTime,
+	}
+}
+
+func (c *Consumer) Start(ctx context.Context) {
+	c.logger.Info("Iniciando consumo de mensajes",
+		zap.String("subscription", c.subscription))
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.logger.Info("Deteniendo consumo de mensajes",
+				zap.String("subscription", c.subscription))
+			return
+		default:
+			receiveStart := time.Now()
+			messages, err := c.subscriber.Receive(ctx, c.subscription, c.maxMessages, c.waitTime)
+			if err != nil && ctx.Err() != nil {
+				// El Receive se cortó porque el worker se está cerrando.
+				continue
+			}
+			metrics.ObserveQueue(c.subscription, metrics.StageReceive, receiveStart, len(messages), err)
+			if err != nil {
+				c.logger.Error("Error recibiendo mensajes",
+					zap.String("subscription", c.subscription),
+					zap.Error(err))
+				select { // Ventana de reintentos, debería ser configurable via configs
+				case <-ctx.Done():
+				case <-time.After(5 * time.Second):
+				}
+				continue
+			}
+			c.lastPoll.Store(time.Now().UnixNano())
+
+			if len(messages) == 0 {
+				continue
+			}
+
+			handleStart := time.Now()
+			err = c.handler(messages)
+			metrics.ObserveQueue(c.subscription, metrics.StageHandle, handleStart, len(messages), err)
+			if err != nil {
+				c.logger.Error("Error procesando mensajes",
+					zap.String("subscription", c.subscription),
+					zap.Error(err))
+				continue
+			}
+
+			// Un lote ya procesado se confirma aunque se esté cerrando el
+			// worker, para que no se vuelva a entregar.
+			for _, msg := range messages {
+				ackStart := time.Now()
+				err := c.subscriber.Ack(context.WithoutCancel(ctx), c.subscription, msg)
+				metrics.ObserveQueue(c.subscription, metrics.StageDelete, ackStart, 1, err)
+				if err != nil {
+					c.logger.Error("Error confirmando mensaje procesado",
+						zap.String("subscription", c.subscription),
+						zap.String("message_id", msg.ID),
+						zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// LastSuccessfulPoll devuelve el momento del último Receive exitoso, o el
+// valor cero si todavía no hubo ninguno.
+func (c *Consumer) LastSuccessfulPoll() time.Time {
+	nanos := c.lastPoll.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
@@ -1,39 +1,32 @@
-package sns
+package broker
 
 import (
 	"context"
 	"fmt"
-	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
 
-	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
 	"github.com/juanmalvarez3/twit/pkg/logger"
 	"go.uber.org/zap"
 )
 
-type FollowSNSPublisher struct {
-	client   *SNSClient
-	topicARN string
-	logger   *logger.Logger
+type FollowPublisher struct {
+	publisher Publisher
+	logger    *logger.Logger
 }
 
-func NewFollowSNSPublisher(
-	client *SNSClient,
-	cfg *config.Config,
-	logger *logger.Logger,
-) *FollowSNSPublisher {
-	return &FollowSNSPublisher{
-		client:   client,
-		topicARN: cfg.SNS.FollowsTopic,
-		logger:   logger.With(zap.String("component", "follow_sns_publisher")),
+func NewFollowPublisher(publisher Publisher, logger *logger.Logger) *FollowPublisher {
+	return &FollowPublisher{
+		publisher: publisher,
+		logger:    logger.With(zap.String("component", "follow_publisher")),
 	}
 }
 
-func (p *FollowSNSPublisher) Publish(ctx context.Context, event events.Event) error {
-	p.logger.Debug("Publicando evento de follow creado en SNS",
+func (p *FollowPublisher) Publish(ctx context.Context, event events.Event) error {
+	p.logger.Debug("Publicando evento de follow creado",
 		zap.String("follow_id", event.Follow.ID),
 		zap.String("follower_id", event.Follow.FollowerID),
 		zap.String("followed_id", event.Follow.FollowedID),
-		zap.String("topic_arn", p.topicARN))
+		zap.String("topic", TopicFollows))
 
 	payload := events.Event{Follow: event.Follow}
 
@@ -42,11 +35,11 @@ func (p *FollowSNSPublisher) Publish(ctx context.Context, event events.Event) er
 		"resource_type": events.ResourceType,
 	}
 
-	err := p.client.PublishMessage(ctx, p.topicARN, payload, messageAttributes)
+	err := p.publisher.Publish(ctx, TopicFollows, payload, messageAttributes)
 	if err != nil {
-		p.logger.Error("Error publicando evento de follow creado en SNS",
+		p.logger.Error("Error publicando evento de follow creado",
 			zap.String("follow_id", event.Follow.ID),
-			zap.String("topic_arn", p.topicARN),
+			zap.String("topic", TopicFollows),
 			zap.Error(err))
 		return fmt.Errorf("error publicando evento de follow: %w", err)
 	}
@@ -0,0 +1,290 @@
+package redisstreams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	streamPrefix     = "broker:"
+	deadLetterPrefix = "broker:dead:"
+
+	defaultVisibilityTimeout = 30 * time.Second
+	defaultMaxDeliveries     = 5
+	defaultBlock             = 5 * time.Second
+)
+
+// StreamClient son los comandos de streams que usa el broker; lo cumple
+// redis.Client.
+type StreamClient interface {
+	AddToStream(ctx context.Context, stream string, maxLen int64, values map[string]string) (string, error)
+	CreateGroup(ctx context.Context, stream, group string) error
+	ReadGroup(ctx context.Context, group, consumer string, streams []string, count int64, block time.Duration) ([]redis.StreamEntry, error)
+	PendingIdle(ctx context.Context, stream, group string, minIdle time.Duration, count int64) ([]redis.PendingEntry, error)
+	Claim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, ids ...string) ([]redis.StreamEntry, error)
+	Ack(ctx context.Context, stream, group string, ids ...string) error
+	Ping(ctx context.Context) error
+}
+
+type Options struct {
+	// Consumer identifica al proceso dentro de cada grupo; vacío usa hostname
+	// y PID.
+	Consumer string
+	// VisibilityTimeout es lo que tiene que pasar sin confirmación para que
+	// otro consumidor reclame un mensaje entregado.
+	VisibilityTimeout time.Duration
+	// MaxDeliveries son las entregas tras las que un mensaje pasa al stream de
+	// mensajes muertos de su suscripción.
+	MaxDeliveries int64
+	// MaxLen recorta cada stream aproximadamente a esa cantidad de entradas;
+	// cero no recorta.
+	MaxLen int64
+	// Block acota la espera de XREADGROUP, que no se corta al cancelar el
+	// contexto, para que los consumidores frenen a tiempo.
+	Block time.Duration
+}
+
+// Broker implementa broker.Broker sobre Redis Streams: un stream por tópico y
+// un grupo de consumidores por suscripción en cada stream de sus tópicos.
+type Broker struct {
+	client   StreamClient
+	topology broker.Topology
+	options  Options
+	logger   *logger.Logger
+
+	mu sync.Mutex
+	// groups son los grupos ya creados, por stream y grupo.
+	groups map[[2]string]struct{}
+}
+
+func New(client StreamClient, topology broker.Topology, options Options, logger *logger.Logger) *Broker {
+	if options.Consumer == "" {
+		hostname, _ := os.Hostname()
+		options.Consumer = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if options.VisibilityTimeout <= 0 {
+		options.VisibilityTimeout = defaultVisibilityTimeout
+	}
+	if options.MaxDeliveries <= 0 {
+		options.MaxDeliveries = defaultMaxDeliveries
+	}
+	if options.Block <= 0 {
+		options.Block = defaultBlock
+	}
+	return &Broker{
+		client:   client,
+		topology: topology,
+		options:  options,
+		logger:   logger,
+		groups:   make(map[[2]string]struct{}),
+	}
+}
+
+// Publish agrega el mensaje al stream del tópico. Los grupos de sus
+// suscripciones se crean antes, para que ninguna pierda el mensaje aunque
+// todavía no haya consumido nunca.
+func (b *Broker) Publish(ctx context.Context, topic string, payload any, attributes map[string]string) error {
+	subscriptions := b.topology.Subscriptions(topic)
+	if len(subscriptions) == 0 {
+		return fmt.Errorf("el tópico %s no existe", topic)
+	}
+	for _, subscription := range subscriptions {
+		if err := b.ensureGroup(ctx, topic, subscription); err != nil {
+			return err
+		}
+	}
+
+	body, attributes, err := broker.Encode(ctx, payload, attributes)
+	if err != nil {
+		return err
+	}
+	encodedAttributes, err := json.Marshal(attributes)
+	if err != nil {
+		return fmt.Errorf("error serializando atributos: %w", err)
+	}
+
+	_, err = b.client.AddToStream(ctx, streamKey(topic), b.options.MaxLen, map[string]string{
+		"body":       string(body),
+		"attributes": string(encodedAttributes),
+	})
+	return err
+}
+
+// Receive devuelve primero los mensajes de subscription que otro consumidor
+// dejó sin confirmar más allá de la visibilidad, reclamándolos con XCLAIM; si
+// no hay, espera mensajes nuevos con XREADGROUP.
+func (b *Broker) Receive(ctx context.Context, subscription string, max int, wait time.Duration) ([]broker.Message, error) {
+	topics := b.topology.Topics(subscription)
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("la suscripción %s no existe", subscription)
+	}
+	for _, topic := range topics {
+		if err := b.ensureGroup(ctx, topic, subscription); err != nil {
+			return nil, err
+		}
+	}
+
+	messages, err := b.reclaim(ctx, subscription, topics, max)
+	if err != nil || len(messages) > 0 {
+		return messages, err
+	}
+
+	streams := make([]string, len(topics))
+	for i, topic := range topics {
+		streams[i] = streamKey(topic)
+	}
+	if wait > b.options.Block {
+		wait = b.options.Block
+	}
+	entries, err := b.client.ReadGroup(ctx, subscription, b.options.Consumer, streams, int64(max), wait)
+	if err != nil {
+		// Si Redis perdió los grupos, se vuelven a crear en el próximo
+		// Receive.
+		b.forgetGroups()
+		return nil, err
+	}
+
+	messages = make([]broker.Message, len(entries))
+	for i, entry := range entries {
+		messages[i] = message(entry)
+	}
+	return messages, nil
+}
+
+func (b *Broker) Ack(ctx context.Context, subscription string, message broker.Message) error {
+	return b.client.Ack(ctx, streamKey(message.Topic), subscription, message.Receipt)
+}
+
+// Ping verifica Redis si name es un tópico o una suscripción conocidos.
+func (b *Broker) Ping(ctx context.Context, name string) error {
+	if len(b.topology.Topics(name)) == 0 && !b.topology.HasTopic(name) {
+		return fmt.Errorf("el tópico o suscripción %s no existe", name)
+	}
+	return b.client.Ping(ctx)
+}
+
+// reclaim reclama hasta max mensajes vencidos de subscription. Los que ya se
+// entregaron MaxDeliveries veces se mueven al stream de mensajes muertos y se
+// confirman en vez de devolverse.
+func (b *Broker) reclaim(ctx context.Context, subscription string, topics []string, max int) ([]broker.Message, error) {
+	var messages []broker.Message
+	for _, topic := range topics {
+		if len(messages) >= max {
+			break
+		}
+		stream := streamKey(topic)
+
+		pending, err := b.client.PendingIdle(ctx, stream, subscription, b.options.VisibilityTimeout, int64(max-len(messages)))
+		if err != nil {
+			return nil, err
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		ids := make([]string, len(pending))
+		exhausted := make(map[string]int64)
+		for i, entry := range pending {
+			ids[i] = entry.ID
+			if entry.Deliveries >= b.options.MaxDeliveries {
+				exhausted[entry.ID] = entry.Deliveries
+			}
+		}
+
+		claimed, err := b.client.Claim(ctx, stream, subscription, b.options.Consumer, b.options.VisibilityTimeout, ids...)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range claimed {
+			deliveries, dead := exhausted[entry.ID]
+			if !dead {
+				messages = append(messages, message(entry))
+				continue
+			}
+			if err := b.deadLetter(ctx, subscription, topic, entry, deliveries); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return messages, nil
+}
+
+func (b *Broker) deadLetter(ctx context.Context, subscription, topic string, entry redis.StreamEntry, deliveries int64) error {
+	values := make(map[string]string, len(entry.Values)+3)
+	for key, value := range entry.Values {
+		values[key] = value
+	}
+	values["topic"] = topic
+	values["id"] = entry.ID
+	values["deliveries"] = fmt.Sprint(deliveries)
+
+	if _, err := b.client.AddToStream(ctx, deadLetterPrefix+subscription, b.options.MaxLen, values); err != nil {
+		return err
+	}
+	if err := b.client.Ack(ctx, entry.Stream, subscription, entry.ID); err != nil {
+		return err
+	}
+
+	b.logger.Warn("Mensaje movido a mensajes muertos",
+		zap.String("subscription", subscription),
+		zap.String("topic", topic),
+		zap.String("message_id", entry.ID),
+		zap.Int64("deliveries", deliveries))
+	return nil
+}
+
+func (b *Broker) ensureGroup(ctx context.Context, topic, subscription string) error {
+	key := [2]string{streamKey(topic), subscription}
+
+	b.mu.Lock()
+	_, ok := b.groups[key]
+	b.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	if err := b.client.CreateGroup(ctx, key[0], key[1]); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.groups[key] = struct{}{}
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *Broker) forgetGroups() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.groups = make(map[[2]string]struct{})
+}
+
+func message(entry redis.StreamEntry) broker.Message {
+	attributes := make(map[string]string)
+	// Atributos ilegibles no impiden procesar el mensaje: sólo se pierde la
+	// traza.
+	_ = json.Unmarshal([]byte(entry.Values["attributes"]), &attributes)
+
+	return broker.Message{
+		ID:         entry.ID,
+		Topic:      strings.TrimPrefix(entry.Stream, streamPrefix),
+		Body:       []byte(entry.Values["body"]),
+		Attributes: attributes,
+		Receipt:    entry.ID,
+	}
+}
+
+func streamKey(topic string) string {
+	return streamPrefix + topic
+}
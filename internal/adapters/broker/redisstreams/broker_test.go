@@ -0,0 +1,229 @@
+package redisstreams_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker/redisstreams"
+	"github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+// fakeStreams reproduce la semántica de grupos de consumidores que usa el
+// broker: cada grupo lee desde su creación y lleva su lista de pendientes.
+type fakeStreams struct {
+	mu      sync.Mutex
+	seq     int
+	streams map[string][]redis.StreamEntry
+	groups  map[[2]string]*fakeGroup
+}
+
+type fakeGroup struct {
+	next    int
+	pending map[string]*fakePending
+}
+
+type fakePending struct {
+	consumer    string
+	deliveredAt time.Time
+	deliveries  int64
+}
+
+func newFakeStreams() *fakeStreams {
+	return &fakeStreams{
+		streams: make(map[string][]redis.StreamEntry),
+		groups:  make(map[[2]string]*fakeGroup),
+	}
+}
+
+func (f *fakeStreams) AddToStream(_ context.Context, stream string, _ int64, values map[string]string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	id := fmt.Sprintf("%d-0", f.seq)
+	f.streams[stream] = append(f.streams[stream], redis.StreamEntry{Stream: stream, ID: id, Values: values})
+	return id, nil
+}
+
+func (f *fakeStreams) CreateGroup(_ context.Context, stream, group string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := [2]string{stream, group}
+	if _, ok := f.groups[key]; !ok {
+		f.groups[key] = &fakeGroup{next: len(f.streams[stream]), pending: make(map[string]*fakePending)}
+	}
+	return nil
+}
+
+func (f *fakeStreams) ReadGroup(_ context.Context, group, consumer string, streams []string, count int64, _ time.Duration) ([]redis.StreamEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var entries []redis.StreamEntry
+	for _, stream := range streams {
+		g, ok := f.groups[[2]string{stream, group}]
+		if !ok {
+			return nil, fmt.Errorf("NOGROUP %s %s", stream, group)
+		}
+		for g.next < len(f.streams[stream]) && int64(len(entries)) < count {
+			entry := f.streams[stream][g.next]
+			g.next++
+			g.pending[entry.ID] = &fakePending{consumer: consumer, deliveredAt: time.Now(), deliveries: 1}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeStreams) PendingIdle(_ context.Context, stream, group string, minIdle time.Duration, count int64) ([]redis.PendingEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var entries []redis.PendingEntry
+	for id, pending := range f.groups[[2]string{stream, group}].pending {
+		if idle := time.Since(pending.deliveredAt); idle >= minIdle {
+			entries = append(entries, redis.PendingEntry{ID: id, Consumer: pending.consumer, Idle: idle, Deliveries: pending.deliveries})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	if int64(len(entries)) > count {
+		entries = entries[:count]
+	}
+	return entries, nil
+}
+
+func (f *fakeStreams) Claim(_ context.Context, stream, group, consumer string, minIdle time.Duration, ids ...string) ([]redis.StreamEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var entries []redis.StreamEntry
+	g := f.groups[[2]string{stream, group}]
+	for _, id := range ids {
+		pending, ok := g.pending[id]
+		if !ok || time.Since(pending.deliveredAt) < minIdle {
+			continue
+		}
+		pending.consumer = consumer
+		pending.deliveredAt = time.Now()
+		pending.deliveries++
+		for _, entry := range f.streams[stream] {
+			if entry.ID == id {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeStreams) Ack(_ context.Context, stream, group string, ids ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range ids {
+		delete(f.groups[[2]string{stream, group}].pending, id)
+	}
+	return nil
+}
+
+func (f *fakeStreams) Ping(context.Context) error { return nil }
+
+var topology = broker.Topology{
+	broker.SubscriptionOrchestrateFanout: {broker.TopicTweets},
+	broker.SubscriptionNotifications:     {broker.TopicTweets, broker.TopicFollows},
+}
+
+func newBroker(t *testing.T, client redisstreams.StreamClient, consumer string) *redisstreams.Broker {
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	return redisstreams.New(client, topology, redisstreams.Options{
+		Consumer:          consumer,
+		VisibilityTimeout: 30 * time.Millisecond,
+		MaxDeliveries:     2,
+	}, log)
+}
+
+func TestBroker_PublishReachesEverySubscription(t *testing.T) {
+	ctx := context.Background()
+	b := newBroker(t, newFakeStreams(), "worker-1")
+
+	require.NoError(t, b.Publish(ctx, broker.TopicTweets, map[string]string{"id": "twt-1"},
+		map[string]string{"event_type": "created"}))
+	require.NoError(t, b.Publish(ctx, broker.TopicFollows, map[string]string{"id": "flw-1"}, nil))
+
+	fanout, err := b.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, fanout, 1)
+	assert.Equal(t, broker.TopicTweets, fanout[0].Topic)
+	assert.JSONEq(t, `{"id":"twt-1"}`, string(fanout[0].Body))
+	assert.Equal(t, "created", fanout[0].Attributes["event_type"])
+
+	notifications, err := b.Receive(ctx, broker.SubscriptionNotifications, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, broker.TopicTweets, notifications[0].Topic)
+	assert.Equal(t, broker.TopicFollows, notifications[1].Topic)
+
+	assert.Error(t, b.Publish(ctx, "missing", "payload", nil))
+}
+
+func TestBroker_ClaimsMessagesStuckInAnotherConsumer(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeStreams()
+	crashed := newBroker(t, client, "worker-1")
+	survivor := newBroker(t, client, "worker-2")
+
+	require.NoError(t, crashed.Publish(ctx, broker.TopicTweets, "payload", nil))
+	first, err := crashed.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	hidden, err := survivor.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+
+	time.Sleep(40 * time.Millisecond)
+	claimed, err := survivor.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, first[0].ID, claimed[0].ID)
+
+	require.NoError(t, survivor.Ack(ctx, broker.SubscriptionOrchestrateFanout, claimed[0]))
+	time.Sleep(40 * time.Millisecond)
+	gone, err := survivor.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, gone)
+}
+
+func TestBroker_MovesExhaustedMessagesToDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeStreams()
+	b := newBroker(t, client, "worker-1")
+
+	require.NoError(t, b.Publish(ctx, broker.TopicTweets, "payload", nil))
+	for delivery := 1; delivery <= 2; delivery++ {
+		messages, err := b.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, messages, 1, "entrega %d", delivery)
+		time.Sleep(40 * time.Millisecond)
+	}
+
+	messages, err := b.Receive(ctx, broker.SubscriptionOrchestrateFanout, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	dead := client.streams["broker:dead:"+broker.SubscriptionOrchestrateFanout]
+	require.Len(t, dead, 1)
+	assert.Equal(t, broker.TopicTweets, dead[0].Values["topic"])
+	assert.Equal(t, "2", dead[0].Values["deliveries"])
+	assert.JSONEq(t, `"payload"`, dead[0].Values["body"])
+}
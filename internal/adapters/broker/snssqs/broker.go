@@ -0,0 +1,218 @@
+package snssqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/sns"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TopicClient publica en tópicos SNS; lo cumple sns.SNSClient.
+type TopicClient interface {
+	Publish(ctx context.Context, topicARN string, body []byte, attributes map[string]string) error
+	Ping(ctx context.Context, topicARN string) error
+}
+
+// QueueClient opera sobre colas SQS; lo cumple queue.SQSClient.
+type QueueClient interface {
+	Send(ctx context.Context, queueURL string, body []byte, attributes map[string]string) error
+	ReceiveMessages(ctx context.Context, queueURL string, maxMessages int32, waitTimeSeconds int32) ([]types.Message, error)
+	DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error
+	Ping(ctx context.Context, queueURL string) error
+}
+
+// Routes ubica los tópicos y suscripciones en AWS.
+type Routes struct {
+	// Topics son los ARN de los tópicos SNS. Un tópico sin ARN se publica
+	// directo en las colas de sus suscripciones.
+	Topics map[string]string
+	// Queues son las URL de la cola SQS de cada suscripción.
+	Queues map[string]string
+}
+
+// Broker implementa broker.Broker sobre SNS y SQS: cada suscripción es una
+// cola, suscrita a los tópicos SNS que correspondan según la topología.
+type Broker struct {
+	topics   TopicClient
+	queues   QueueClient
+	topology broker.Topology
+	routes   Routes
+	// topicNames resuelve el tópico de un sobre SNS por su ARN.
+	topicNames map[string]string
+	logger     *logger.Logger
+}
+
+func New(topics TopicClient, queues QueueClient, topology broker.Topology, routes Routes, logger *logger.Logger) *Broker {
+	topicNames := make(map[string]string, len(routes.Topics))
+	for topic, arn := range routes.Topics {
+		topicNames[arn] = topic
+	}
+	return &Broker{
+		topics:     topics,
+		queues:     queues,
+		topology:   topology,
+		routes:     routes,
+		topicNames: topicNames,
+		logger:     logger,
+	}
+}
+
+func (b *Broker) Publish(ctx context.Context, topic string, payload any, attributes map[string]string) error {
+	body, attributes, err := broker.Encode(ctx, payload, attributes)
+	if err != nil {
+		return err
+	}
+
+	if arn, ok := b.routes.Topics[topic]; ok {
+		return b.topics.Publish(ctx, arn, body, attributes)
+	}
+
+	subscriptions := b.topology.Subscriptions(topic)
+	if len(subscriptions) == 0 {
+		return fmt.Errorf("el tópico %s no existe", topic)
+	}
+	for _, subscription := range subscriptions {
+		queueURL, err := b.queueURL(subscription)
+		if err != nil {
+			return err
+		}
+		if err := b.queues.Send(ctx, queueURL, body, attributes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Receive hace long polling sobre la cola de subscription. Los mensajes que
+// llegan por SNS se desarman del sobre; un sobre inválido se borra, porque
+// reintentarlo no lo va a arreglar.
+func (b *Broker) Receive(ctx context.Context, subscription string, max int, wait time.Duration) ([]broker.Message, error) {
+	queueURL, err := b.queueURL(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := b.queues.ReceiveMessages(ctx, queueURL, int32(max), int32(wait/time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]broker.Message, 0, len(received))
+	for _, sqsMessage := range received {
+		message, err := b.message(subscription, sqsMessage)
+		if err != nil {
+			b.logger.Error("Mensaje inválido, se descarta",
+				zap.String("subscription", subscription),
+				zap.String("message_id", stringValue(sqsMessage.MessageId)),
+				zap.Error(err))
+			if err := b.queues.DeleteMessage(ctx, queueURL, stringValue(sqsMessage.ReceiptHandle)); err != nil {
+				b.logger.Error("Error descartando mensaje inválido",
+					zap.String("subscription", subscription),
+					zap.Error(err))
+			}
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (b *Broker) Ack(ctx context.Context, subscription string, message broker.Message) error {
+	queueURL, err := b.queueURL(subscription)
+	if err != nil {
+		return err
+	}
+	return b.queues.DeleteMessage(ctx, queueURL, message.Receipt)
+}
+
+// Ping verifica el tópico SNS o la cola que corresponde a name. Un tópico sin
+// ARN se verifica por las colas de sus suscripciones.
+func (b *Broker) Ping(ctx context.Context, name string) error {
+	if arn, ok := b.routes.Topics[name]; ok {
+		return b.topics.Ping(ctx, arn)
+	}
+	if queueURL, ok := b.routes.Queues[name]; ok {
+		return b.queues.Ping(ctx, queueURL)
+	}
+
+	subscriptions := b.topology.Subscriptions(name)
+	if len(subscriptions) == 0 {
+		return fmt.Errorf("el tópico o suscripción %s no existe", name)
+	}
+	for _, subscription := range subscriptions {
+		if err := b.Ping(ctx, subscription); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Broker) queueURL(subscription string) (string, error) {
+	queueURL, ok := b.routes.Queues[subscription]
+	if !ok {
+		return "", fmt.Errorf("la suscripción %s no tiene cola", subscription)
+	}
+	return queueURL, nil
+}
+
+func (b *Broker) message(subscription string, sqsMessage types.Message) (broker.Message, error) {
+	attributes := make(map[string]string, len(sqsMessage.MessageAttributes))
+	for key, value := range sqsMessage.MessageAttributes {
+		if value.StringValue != nil {
+			attributes[key] = *value.StringValue
+		}
+	}
+	message := broker.Message{
+		ID:         stringValue(sqsMessage.MessageId),
+		Body:       []byte(stringValue(sqsMessage.Body)),
+		Attributes: attributes,
+		Receipt:    stringValue(sqsMessage.ReceiptHandle),
+	}
+
+	if !b.fedBySNS(subscription) {
+		if topics := b.topology.Topics(subscription); len(topics) > 0 {
+			message.Topic = topics[0]
+		}
+		return message, nil
+	}
+
+	var envelope sns.SNSMessage
+	if err := json.Unmarshal(message.Body, &envelope); err != nil {
+		return broker.Message{}, fmt.Errorf("error al deserializar sobre SNS: %w", err)
+	}
+	topic, ok := b.topicNames[envelope.TopicArn]
+	if !ok {
+		return broker.Message{}, fmt.Errorf("sobre SNS de un tópico desconocido: %s", envelope.TopicArn)
+	}
+	for key, attribute := range envelope.MessageAttributes {
+		message.Attributes[key] = attribute.Value
+	}
+	message.ID = envelope.MessageId
+	message.Topic = topic
+	message.Body = []byte(envelope.Message)
+	return message, nil
+}
+
+// fedBySNS indica si la cola de subscription recibe de tópicos SNS, y por lo
+// tanto sus mensajes vienen envueltos en el sobre SNS.
+func (b *Broker) fedBySNS(subscription string) bool {
+	for _, topic := range b.topology.Topics(subscription) {
+		if _, ok := b.routes.Topics[topic]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
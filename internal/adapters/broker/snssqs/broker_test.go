@@ -0,0 +1,228 @@
+package snssqs_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker/snssqs"
+	"github.com/juanmalvarez3/twit/internal/adapters/sns"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+)
+
+type published struct {
+	destination string
+	body        string
+	attributes  map[string]string
+}
+
+type fakeAWS struct {
+	published []published
+	received  map[string][]types.Message
+	deleted   []string
+}
+
+func (f *fakeAWS) Publish(_ context.Context, topicARN string, body []byte, attributes map[string]string) error {
+	f.published = append(f.published, published{topicARN, string(body), attributes})
+	return nil
+}
+
+func (f *fakeAWS) Send(_ context.Context, queueURL string, body []byte, attributes map[string]string) error {
+	f.published = append(f.published, published{queueURL, string(body), attributes})
+	return nil
+}
+
+func (f *fakeAWS) ReceiveMessages(_ context.Context, queueURL string, _ int32, _ int32) ([]types.Message, error) {
+	return f.received[queueURL], nil
+}
+
+func (f *fakeAWS) DeleteMessage(_ context.Context, _ string, receiptHandle string) error {
+	f.deleted = append(f.deleted, receiptHandle)
+	return nil
+}
+
+func (f *fakeAWS) Ping(context.Context, string) error { return nil }
+
+var routes = snssqs.Routes{
+	Topics: map[string]string{broker.TopicTweets: "arn:aws:sns:us-east-1:000000000000:tweets"},
+	Queues: map[string]string{
+		broker.SubscriptionNotifications:  "http://sqs/notifications",
+		broker.SubscriptionUpdateTimeline: "http://sqs/update-timeline",
+	},
+}
+
+var topology = broker.Topology{
+	broker.SubscriptionNotifications:  {broker.TopicTweets},
+	broker.SubscriptionUpdateTimeline: {broker.TopicUpdateTimeline},
+}
+
+func newBroker(t *testing.T, aws *fakeAWS) *snssqs.Broker {
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	return snssqs.New(aws, aws, topology, routes, log)
+}
+
+func TestBroker_PublishRoutesBySNSTopicOrQueue(t *testing.T) {
+	fake := &fakeAWS{}
+	b := newBroker(t, fake)
+	ctx := context.Background()
+
+	require.NoError(t, b.Publish(ctx, broker.TopicTweets, map[string]string{"id": "twt-1"},
+		map[string]string{"event_type": "created"}))
+	require.NoError(t, b.Publish(ctx, broker.TopicUpdateTimeline, map[string]string{"user_id": "usr-1"}, nil))
+	assert.Error(t, b.Publish(ctx, "missing", "payload", nil))
+
+	require.Len(t, fake.published, 2)
+	assert.Equal(t, routes.Topics[broker.TopicTweets], fake.published[0].destination)
+	assert.JSONEq(t, `{"id":"twt-1"}`, fake.published[0].body)
+	assert.Equal(t, "created", fake.published[0].attributes["event_type"])
+	assert.Equal(t, routes.Queues[broker.SubscriptionUpdateTimeline], fake.published[1].destination)
+}
+
+func TestBroker_ReceiveUnwrapsSNSEnvelope(t *testing.T) {
+	envelope, err := json.Marshal(sns.SNSMessage{
+		Type:      "Notification",
+		MessageId: "sns-1",
+		TopicArn:  routes.Topics[broker.TopicTweets],
+		Message:   `{"id":"twt-1"}`,
+		MessageAttributes: map[string]sns.SNSMessageAttribute{
+			"event_type": {Type: "String", Value: "created"},
+		},
+	})
+	require.NoError(t, err)
+
+	fake := &fakeAWS{received: map[string][]types.Message{
+		routes.Queues[broker.SubscriptionNotifications]: {
+			{MessageId: aws.String("sqs-1"), ReceiptHandle: aws.String("r-1"), Body: aws.String(string(envelope))},
+			{MessageId: aws.String("sqs-2"), ReceiptHandle: aws.String("r-2"), Body: aws.String("not json")},
+		},
+	}}
+	b := newBroker(t, fake)
+
+	messages, err := b.Receive(context.Background(), broker.SubscriptionNotifications, 10, time.Second)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "sns-1", messages[0].ID)
+	assert.Equal(t, broker.TopicTweets, messages[0].Topic)
+	assert.JSONEq(t, `{"id":"twt-1"}`, string(messages[0].Body))
+	assert.Equal(t, "created", messages[0].Attributes["event_type"])
+	assert.Equal(t, "r-1", messages[0].Receipt)
+
+	// El sobre inválido se descarta en vez de reintentarse.
+	assert.Equal(t, []string{"r-2"}, fake.deleted)
+
+	require.NoError(t, b.Ack(context.Background(), broker.SubscriptionNotifications, messages[0]))
+	assert.Equal(t, []string{"r-2", "r-1"}, fake.deleted)
+}
+
+func TestBroker_ReceiveDirectQueue(t *testing.T) {
+	fake := &fakeAWS{received: map[string][]types.Message{
+		routes.Queues[broker.SubscriptionUpdateTimeline]: {{
+			MessageId:     aws.String("sqs-1"),
+			ReceiptHandle: aws.String("r-1"),
+			Body:          aws.String(`{"user_id":"usr-1"}`),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				"traceparent": {DataType: aws.String("String"), StringValue: aws.String("00-abc")},
+			},
+		}},
+	}}
+	b := newBroker(t, fake)
+
+	messages, err := b.Receive(context.Background(), broker.SubscriptionUpdateTimeline, 10, time.Second)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, broker.TopicUpdateTimeline, messages[0].Topic)
+	assert.JSONEq(t, `{"user_id":"usr-1"}`, string(messages[0].Body))
+	assert.Equal(t, "00-abc", messages[0].Attributes["traceparent"])
+}
+
+// TestBroker_PropagatesTrace publica dentro de un span y comprueba que el
+// consumidor recupera la misma traza, tanto por los atributos del sobre SNS
+// como por los atributos de un mensaje SQS directo.
+func TestBroker_PropagatesTrace(t *testing.T) {
+	shutdown, err := tracing.New(context.Background(), config.TracingConfig{}, "test")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = shutdown(context.Background()) })
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	fake := &fakeAWS{}
+	b := newBroker(t, fake)
+	ctx, span := provider.Tracer("test").Start(context.Background(), "publish")
+	defer span.End()
+
+	require.NoError(t, b.Publish(ctx, broker.TopicTweets, map[string]string{"id": "twt-1"}, nil))
+	require.NoError(t, b.Publish(ctx, broker.TopicUpdateTimeline, map[string]string{"user_id": "usr-1"}, nil))
+	require.Len(t, fake.published, 2)
+
+	snsAttributes := make(map[string]sns.SNSMessageAttribute)
+	for key, value := range fake.published[0].attributes {
+		snsAttributes[key] = sns.SNSMessageAttribute{Type: "String", Value: value}
+	}
+	envelope, err := json.Marshal(sns.SNSMessage{
+		MessageId:         "sns-1",
+		TopicArn:          routes.Topics[broker.TopicTweets],
+		Message:           fake.published[0].body,
+		MessageAttributes: snsAttributes,
+	})
+	require.NoError(t, err)
+
+	sqsAttributes := make(map[string]types.MessageAttributeValue)
+	for key, value := range fake.published[1].attributes {
+		sqsAttributes[key] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+	}
+
+	fake.received = map[string][]types.Message{
+		routes.Queues[broker.SubscriptionNotifications]: {{
+			MessageId: aws.String("sqs-1"), ReceiptHandle: aws.String("r-1"), Body: aws.String(string(envelope)),
+		}},
+		routes.Queues[broker.SubscriptionUpdateTimeline]: {{
+			MessageId: aws.String("sqs-2"), ReceiptHandle: aws.String("r-2"), Body: aws.String(fake.published[1].body),
+			MessageAttributes: sqsAttributes,
+		}},
+	}
+
+	for _, subscription := range []string{broker.SubscriptionNotifications, broker.SubscriptionUpdateTimeline} {
+		messages, err := b.Receive(context.Background(), subscription, 10, time.Second)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+
+		remote := trace.SpanContextFromContext(messages[0].Context(context.Background()))
+		assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID(), subscription)
+		assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID(), subscription)
+	}
+}
+
+func TestBroker_MessageWithoutTrace(t *testing.T) {
+	fake := &fakeAWS{received: map[string][]types.Message{
+		routes.Queues[broker.SubscriptionUpdateTimeline]: {{
+			MessageId: aws.String("sqs-1"), ReceiptHandle: aws.String("r-1"), Body: aws.String(`{"user_id":"usr-1"}`),
+		}},
+	}}
+	b := newBroker(t, fake)
+
+	messages, err := b.Receive(context.Background(), broker.SubscriptionUpdateTimeline, 10, time.Second)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	ctx := messages[0].Context(context.Background())
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	_, span := provider.Tracer("test").Start(ctx, "consume")
+	defer span.End()
+	assert.True(t, span.SpanContext().IsValid())
+	assert.False(t, span.(sdktrace.ReadOnlySpan).Parent().IsValid(), "sin traza recibida el span es raíz")
+}
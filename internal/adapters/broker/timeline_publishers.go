@@ -0,0 +1,91 @@
+package broker
+
+import (
+	"context"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// UpdateTimelinePayload es el mensaje de update-timeline: un tweet a agregar
+// al timeline TimelineID.
+type UpdateTimelinePayload struct {
+	Tweet struct {
+		ID        string `json:"id"`
+		UserID    string `json:"userId"`
+		Content   string `json:"content"`
+		CreatedAt string `json:"createdAt"`
+	} `json:"tweet"`
+	TimelineID string `json:"user_id"`
+}
+
+type UpdateTimelinePublisher struct {
+	publisher Publisher
+}
+
+func NewUpdateTimelinePublisher(publisher Publisher) *UpdateTimelinePublisher {
+	return &UpdateTimelinePublisher{publisher: publisher}
+}
+
+func (p *UpdateTimelinePublisher) Publish(ctx context.Context, tweet dmntweet.Tweet, timelineID string) error {
+	var payload UpdateTimelinePayload
+
+	payload.Tweet.ID = tweet.ID
+	payload.Tweet.UserID = tweet.UserID
+	payload.Tweet.Content = tweet.Content
+	payload.Tweet.CreatedAt = tweet.CreatedAt
+	payload.TimelineID = timelineID
+
+	return p.publisher.Publish(ctx, TopicUpdateTimeline, payload, nil)
+}
+
+type PopulateTimelineCachePublisher struct {
+	publisher Publisher
+	logger    logger.LoggerInterface
+}
+
+func NewPopulateTimelineCachePublisher(publisher Publisher, logger logger.LoggerInterface) *PopulateTimelineCachePublisher {
+	return &PopulateTimelineCachePublisher{
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (p *PopulateTimelineCachePublisher) Publish(ctx context.Context, timeline dmntimeline.Timeline) error {
+	err := p.publisher.Publish(ctx, TopicPopulateCache, timeline, nil)
+	if err != nil {
+		p.logger.Error("Error publicando timeline en cola", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RebuildTimelinePayload es el mensaje de rebuild-timeline.
+type RebuildTimelinePayload struct {
+	UserID string `json:"user_id"`
+}
+
+type RebuildTimelinePublisher struct {
+	publisher Publisher
+	logger    logger.LoggerInterface
+}
+
+func NewRebuildTimelinePublisher(publisher Publisher, logger logger.LoggerInterface) *RebuildTimelinePublisher {
+	return &RebuildTimelinePublisher{
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (p *RebuildTimelinePublisher) Publish(ctx context.Context, userID string) error {
+	err := p.publisher.Publish(ctx, TopicRebuildTimeline, RebuildTimelinePayload{UserID: userID}, nil)
+	if err != nil {
+		p.logger.Error("Error publicando solicitud de reconstrucción", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -1,38 +1,31 @@
-package sns
+package broker
 
 import (
 	"context"
 	"fmt"
 
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/events"
-	"github.com/juanmalvarez3/twit/pkg/config"
 	"github.com/juanmalvarez3/twit/pkg/logger"
 	"go.uber.org/zap"
 )
 
-type TweetSNSPublisher struct {
-	client   *SNSClient
-	topicARN string
-	logger   *logger.Logger
+type TweetPublisher struct {
+	publisher Publisher
+	logger    *logger.Logger
 }
 
-func NewTweetSNSPublisher(
-	client *SNSClient,
-	cfg *config.Config,
-	logger *logger.Logger,
-) *TweetSNSPublisher {
-	return &TweetSNSPublisher{
-		client:   client,
-		topicARN: cfg.SNS.TweetsTopic,
-		logger:   logger.With(zap.String("component", "tweet_sns_publisher")),
+func NewTweetPublisher(publisher Publisher, logger *logger.Logger) *TweetPublisher {
+	return &TweetPublisher{
+		publisher: publisher,
+		logger:    logger.With(zap.String("component", "tweet_publisher")),
 	}
 }
 
-func (p *TweetSNSPublisher) Publish(ctx context.Context, event events.Event) error {
-	p.logger.Debug("Publicando evento de tweet en SNS",
+func (p *TweetPublisher) Publish(ctx context.Context, event events.Event) error {
+	p.logger.Debug("Publicando evento de tweet",
 		zap.String("tweet_id", event.Tweet.ID),
 		zap.String("event_type", event.Type.String()),
-		zap.String("topic_arn", p.topicARN))
+		zap.String("topic", TopicTweets))
 
 	var payload interface{}
 
@@ -51,11 +44,11 @@ func (p *TweetSNSPublisher) Publish(ctx context.Context, event events.Event) err
 		"resource_type": events.ResourceType,
 	}
 
-	err := p.client.PublishMessage(ctx, p.topicARN, payload, messageAttributes)
+	err := p.publisher.Publish(ctx, TopicTweets, payload, messageAttributes)
 	if err != nil {
-		p.logger.Error("Error publicando evento de tweet en SNS",
+		p.logger.Error("Error publicando evento de tweet",
 			zap.String("tweet_id", event.Tweet.ID),
-			zap.String("topic_arn", p.topicARN),
+			zap.String("topic", TopicTweets),
 			zap.Error(err))
 		return fmt.Errorf("error publicando evento de tweet: %w", err)
 	}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+)
+
+// DefaultVisibilityTimeout es lo que tarda en volver a entregarse un mensaje
+// recibido y no confirmado, igual que el valor por defecto de SQS.
+const DefaultVisibilityTimeout = 30 * time.Second
+
+// Broker implementa broker.Broker dentro del proceso. Cada suscripción de la
+// topología es una cola con la semántica que usan los consumidores: long
+// polling, recibos y reentrega al vencer la visibilidad.
+type Broker struct {
+	mu         sync.Mutex
+	topology   broker.Topology
+	queues     map[string]*brokerQueue
+	visibility time.Duration
+	now        func() time.Time
+}
+
+type brokerQueue struct {
+	ready    []broker.Message
+	inFlight map[string]inFlightMessage
+	// arrived se cierra y se reemplaza con cada mensaje nuevo para despertar
+	// a los receptores en espera.
+	arrived chan struct{}
+}
+
+type inFlightMessage struct {
+	message  broker.Message
+	deadline time.Time
+}
+
+func NewBroker(topology broker.Topology, visibility time.Duration) *Broker {
+	if visibility <= 0 {
+		visibility = DefaultVisibilityTimeout
+	}
+	queues := make(map[string]*brokerQueue, len(topology))
+	for subscription := range topology {
+		queues[subscription] = &brokerQueue{
+			inFlight: make(map[string]inFlightMessage),
+			arrived:  make(chan struct{}),
+		}
+	}
+	return &Broker{
+		topology:   topology,
+		queues:     queues,
+		visibility: visibility,
+		now:        time.Now,
+	}
+}
+
+// Publish encola una copia del mensaje en cada suscripción de topic.
+func (b *Broker) Publish(ctx context.Context, topic string, payload any, attributes map[string]string) error {
+	body, attributes, err := broker.Encode(ctx, payload, attributes)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := b.topology.Subscriptions(topic)
+	if len(subscriptions) == 0 {
+		return fmt.Errorf("el tópico %s no existe", topic)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	message := broker.Message{
+		ID:         uuid.New().String(),
+		Topic:      topic,
+		Body:       body,
+		Attributes: attributes,
+	}
+	for _, subscription := range subscriptions {
+		b.queues[subscription].push(message)
+	}
+	return nil
+}
+
+// Receive espera hasta wait a que haya mensajes y devuelve hasta max, que
+// quedan invisibles hasta que se confirmen o venza la visibilidad.
+func (b *Broker) Receive(ctx context.Context, subscription string, max int, wait time.Duration) ([]broker.Message, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	for {
+		b.mu.Lock()
+		q, ok := b.queues[subscription]
+		if !ok {
+			b.mu.Unlock()
+			return nil, fmt.Errorf("la suscripción %s no existe", subscription)
+		}
+		now := b.now()
+		q.requeueExpired(now)
+		if len(q.ready) > 0 {
+			messages := q.take(max, now.Add(b.visibility))
+			b.mu.Unlock()
+			return messages, nil
+		}
+		arrived := q.arrived
+		b.mu.Unlock()
+
+		select {
+		case <-arrived:
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (b *Broker) Ack(_ context.Context, subscription string, message broker.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q, ok := b.queues[subscription]
+	if !ok {
+		return fmt.Errorf("la suscripción %s no existe", subscription)
+	}
+	delete(q.inFlight, message.Receipt)
+	return nil
+}
+
+// Ping verifica que exista el tópico o la suscripción name.
+func (b *Broker) Ping(_ context.Context, name string) error {
+	if _, ok := b.topology[name]; ok {
+		return nil
+	}
+	if b.topology.HasTopic(name) {
+		return nil
+	}
+	return fmt.Errorf("el tópico o suscripción %s no existe", name)
+}
+
+func (q *brokerQueue) push(message broker.Message) {
+	q.ready = append(q.ready, message)
+	close(q.arrived)
+	q.arrived = make(chan struct{})
+}
+
+func (q *brokerQueue) take(max int, deadline time.Time) []broker.Message {
+	if max <= 0 || max > len(q.ready) {
+		max = len(q.ready)
+	}
+	messages := make([]broker.Message, max)
+	for i, message := range q.ready[:max] {
+		message.Receipt = uuid.New().String()
+		q.inFlight[message.Receipt] = inFlightMessage{message: message, deadline: deadline}
+		messages[i] = message
+	}
+	q.ready = append([]broker.Message{}, q.ready[max:]...)
+	return messages
+}
+
+// requeueExpired devuelve a la cola los mensajes cuya visibilidad venció, con
+// un recibo nuevo en la próxima entrega como en SQS.
+func (q *brokerQueue) requeueExpired(now time.Time) {
+	for receipt, pending := range q.inFlight {
+		if now.Before(pending.deadline) {
+			continue
+		}
+		delete(q.inFlight, receipt)
+		pending.message.Receipt = ""
+		q.ready = append(q.ready, pending.message)
+	}
+}
@@ -0,0 +1,89 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/memory"
+)
+
+var topology = broker.Topology{
+	"sub-a": {"topic"},
+	"sub-b": {"topic", "other"},
+}
+
+func TestBroker_PublishFansOutToSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	b := memory.NewBroker(topology, time.Minute)
+
+	require.NoError(t, b.Publish(ctx, "topic", map[string]string{"id": "twt-1"},
+		map[string]string{"event_type": "created"}))
+
+	for _, subscription := range []string{"sub-a", "sub-b"} {
+		messages, err := b.Receive(ctx, subscription, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+
+		assert.Equal(t, "topic", messages[0].Topic)
+		assert.JSONEq(t, `{"id":"twt-1"}`, string(messages[0].Body))
+		assert.Equal(t, "created", messages[0].Attributes["event_type"])
+	}
+}
+
+func TestBroker_RedeliversAfterVisibilityTimeout(t *testing.T) {
+	ctx := context.Background()
+	b := memory.NewBroker(topology, 50*time.Millisecond)
+	require.NoError(t, b.Publish(ctx, "other", "payload", nil))
+
+	first, err := b.Receive(ctx, "sub-b", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	hidden, err := b.Receive(ctx, "sub-b", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+
+	time.Sleep(60 * time.Millisecond)
+	again, err := b.Receive(ctx, "sub-b", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	assert.Equal(t, first[0].ID, again[0].ID)
+	assert.NotEqual(t, first[0].Receipt, again[0].Receipt)
+
+	require.NoError(t, b.Ack(ctx, "sub-b", again[0]))
+	time.Sleep(60 * time.Millisecond)
+	gone, err := b.Receive(ctx, "sub-b", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, gone)
+}
+
+func TestBroker_ReceiveWaitsForMessages(t *testing.T) {
+	ctx := context.Background()
+	b := memory.NewBroker(topology, time.Minute)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = b.Publish(ctx, "topic", "payload", nil)
+	}()
+
+	messages, err := b.Receive(ctx, "sub-a", 10, 5*time.Second)
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+}
+
+func TestBroker_UnknownTopicOrSubscription(t *testing.T) {
+	ctx := context.Background()
+	b := memory.NewBroker(topology, time.Minute)
+
+	assert.Error(t, b.Publish(ctx, "missing", "payload", nil))
+	_, err := b.Receive(ctx, "missing", 10, 0)
+	assert.Error(t, err)
+	assert.Error(t, b.Ping(ctx, "missing"))
+	assert.NoError(t, b.Ping(ctx, "other"))
+	assert.NoError(t, b.Ping(ctx, "sub-a"))
+}
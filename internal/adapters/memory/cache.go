@@ -0,0 +1,317 @@
+package memory
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/redis"
+)
+
+// Cache implementa en memoria el mismo contrato que el cliente de Redis:
+// valores con TTL, contadores, sets y pub/sub por patrón. Sirve para tests y
+// para levantar todo en un proceso sin servicios externos.
+type Cache struct {
+	mu          sync.Mutex
+	entries     map[string]*entry
+	subscribers map[*subscriber]struct{}
+	now         func() time.Time
+}
+
+// entry guarda un valor de cualquiera de los tipos que usa la aplicación; como
+// en Redis, todos comparten el espacio de claves y la expiración.
+type entry struct {
+	value     []byte
+	counters  map[string]int64
+	members   map[string]struct{}
+	expiresAt time.Time
+}
+
+type subscriber struct {
+	patterns []string
+	messages chan redis.Message
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		entries:     make(map[string]*entry),
+		subscribers: make(map[*subscriber]struct{}),
+		now:         time.Now,
+	}
+}
+
+func (c *Cache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.lookup(key)
+	if e == nil || e.value == nil {
+		return nil, nil
+	}
+	return clone(e.value), nil
+}
+
+func (c *Cache) MGet(_ context.Context, keys ...string) ([][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([][]byte, len(keys))
+	for i, key := range keys {
+		if e := c.lookup(key); e != nil && e.value != nil {
+			result[i] = clone(e.value)
+		}
+	}
+	return result, nil
+}
+
+func (c *Cache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = &entry{value: clone(value), expiresAt: c.deadline(ttl)}
+	return nil
+}
+
+func (c *Cache) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lookup(key) != nil {
+		return false, nil
+	}
+	c.entries[key] = &entry{value: clone(value), expiresAt: c.deadline(ttl)}
+	return true, nil
+}
+
+func (c *Cache) Expire(_ context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e := c.lookup(key); e != nil {
+		e.expiresAt = c.deadline(ttl)
+	}
+	return nil
+}
+
+func (c *Cache) Del(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *Cache) Ping(context.Context) error {
+	return nil
+}
+
+// Increment guarda el valor como texto, igual que INCR, para que Get lo
+// devuelva como lo haría Redis.
+func (c *Cache) Increment(_ context.Context, key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var n int64
+	if e := c.lookup(key); e != nil && e.value != nil {
+		parsed, err := strconv.ParseInt(string(e.value), 10, 64)
+		if err != nil {
+			return err
+		}
+		n = parsed
+	}
+	c.entries[key] = &entry{value: []byte(strconv.FormatInt(n+1, 10)), expiresAt: c.deadline(ttl)}
+	return nil
+}
+
+func (c *Cache) IncrementCounter(_ context.Context, key, field string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.lookup(key)
+	if e == nil {
+		e = &entry{}
+		c.entries[key] = e
+	}
+	if e.counters == nil {
+		e.counters = make(map[string]int64)
+	}
+	e.counters[field]++
+	e.expiresAt = c.deadline(ttl)
+	return nil
+}
+
+func (c *Cache) Counters(_ context.Context, keys ...string) ([]map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := make([]map[string]string, len(keys))
+	for i, key := range keys {
+		counters := make(map[string]string)
+		if e := c.lookup(key); e != nil {
+			for field, value := range e.counters {
+				counters[field] = strconv.FormatInt(value, 10)
+			}
+		}
+		result[i] = counters
+	}
+	return result, nil
+}
+
+// AddUnique guarda los elementos exactos; el cliente de Redis usa HyperLogLog,
+// así que allá el conteo es aproximado y acá no.
+func (c *Cache) AddUnique(_ context.Context, key string, ttl time.Duration, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.addMembers(key, members)
+	c.entries[key].expiresAt = c.deadline(ttl)
+	return nil
+}
+
+func (c *Cache) CountUnique(_ context.Context, keys ...string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	union := make(map[string]struct{})
+	for _, key := range keys {
+		if e := c.lookup(key); e != nil {
+			for member := range e.members {
+				union[member] = struct{}{}
+			}
+		}
+	}
+	return int64(len(union)), nil
+}
+
+func (c *Cache) AddMembers(_ context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.addMembers(key, members)
+	return nil
+}
+
+func (c *Cache) PopMembers(_ context.Context, key string, count int64) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := c.lookup(key)
+	if e == nil {
+		return nil, nil
+	}
+
+	var popped []string
+	for member := range e.members {
+		if int64(len(popped)) >= count {
+			break
+		}
+		popped = append(popped, member)
+		delete(e.members, member)
+	}
+	if len(e.members) == 0 {
+		delete(c.entries, key)
+	}
+	return popped, nil
+}
+
+// Publish entrega payload a cada suscripción cuyo patrón coincide con
+// channel. Igual que en Redis no hay garantía de entrega: si el suscriptor
+// no está leyendo, el mensaje se descarta.
+func (c *Cache) Publish(_ context.Context, channel string, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for sub := range c.subscribers {
+		if !sub.matches(channel) {
+			continue
+		}
+		select {
+		case sub.messages <- redis.Message{Channel: channel, Payload: clone(payload)}:
+		default:
+		}
+	}
+	return nil
+}
+
+// PSubscribe entrega los mensajes publicados en los canales que coinciden con
+// patterns hasta que se cancele ctx. Los patrones siguen la sintaxis de
+// path.Match, que cubre los comodines que usa la aplicación.
+func (c *Cache) PSubscribe(ctx context.Context, patterns ...string) (<-chan redis.Message, error) {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := &subscriber{
+		patterns: patterns,
+		messages: make(chan redis.Message, subscriberBuffer),
+	}
+
+	c.mu.Lock()
+	c.subscribers[sub] = struct{}{}
+	c.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		delete(c.subscribers, sub)
+		close(sub.messages)
+		c.mu.Unlock()
+	}()
+
+	return sub.messages, nil
+}
+
+const subscriberBuffer = 256
+
+func (s *subscriber) matches(channel string) bool {
+	for _, pattern := range s.patterns {
+		if ok, _ := path.Match(pattern, channel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup devuelve la entrada viva de key y borra la que ya venció.
+func (c *Cache) lookup(key string) *entry {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		delete(c.entries, key)
+		return nil
+	}
+	return e
+}
+
+func (c *Cache) addMembers(key string, members []string) {
+	e := c.lookup(key)
+	if e == nil {
+		e = &entry{}
+		c.entries[key] = e
+	}
+	if e.members == nil {
+		e.members = make(map[string]struct{}, len(members))
+	}
+	for _, member := range members {
+		e.members[member] = struct{}{}
+	}
+}
+
+func (c *Cache) deadline(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return c.now().Add(ttl)
+}
+
+func clone(value []byte) []byte {
+	return append([]byte{}, value...)
+}
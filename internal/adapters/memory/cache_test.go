@@ -0,0 +1,82 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/memory"
+)
+
+func TestCache_ValuesExpire(t *testing.T) {
+	ctx := context.Background()
+	cache := memory.NewCache()
+
+	require.NoError(t, cache.Set(ctx, "key", []byte("value"), 30*time.Millisecond))
+	ok, err := cache.SetNX(ctx, "key", []byte("other"), time.Minute)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	values, err := cache.MGet(ctx, "key", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value"), nil}, values)
+
+	time.Sleep(40 * time.Millisecond)
+	value, err := cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestCache_CountersAndSets(t *testing.T) {
+	ctx := context.Background()
+	cache := memory.NewCache()
+
+	require.NoError(t, cache.IncrementCounter(ctx, "bucket", "golang", time.Minute))
+	require.NoError(t, cache.IncrementCounter(ctx, "bucket", "golang", time.Minute))
+	counters, err := cache.Counters(ctx, "bucket", "empty")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"golang": "2"}, {}}, counters)
+
+	require.NoError(t, cache.Increment(ctx, "version", time.Minute))
+	require.NoError(t, cache.Increment(ctx, "version", time.Minute))
+	version, err := cache.Get(ctx, "version")
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(version))
+
+	require.NoError(t, cache.AddUnique(ctx, "a", time.Minute, "u1", "u2"))
+	require.NoError(t, cache.AddUnique(ctx, "b", time.Minute, "u2", "u3"))
+	count, err := cache.CountUnique(ctx, "a", "b")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	require.NoError(t, cache.AddMembers(ctx, "pending", "u1", "u2", "u3"))
+	popped, err := cache.PopMembers(ctx, "pending", 2)
+	require.NoError(t, err)
+	assert.Len(t, popped, 2)
+	rest, err := cache.PopMembers(ctx, "pending", 10)
+	require.NoError(t, err)
+	assert.Len(t, rest, 1)
+	assert.NotContains(t, popped, rest[0])
+}
+
+func TestCache_PSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cache := memory.NewCache()
+
+	messages, err := cache.PSubscribe(ctx, "timeline:stream:*")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Publish(ctx, "notifications:stream:u1", []byte("ignored")))
+	require.NoError(t, cache.Publish(ctx, "timeline:stream:u1", []byte("entry")))
+
+	msg := <-messages
+	assert.Equal(t, "timeline:stream:u1", msg.Channel)
+	assert.Equal(t, []byte("entry"), msg.Payload)
+
+	cancel()
+	_, open := <-messages
+	assert.False(t, open)
+}
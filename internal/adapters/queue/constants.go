@@ -1,7 +0,0 @@
-package queue
-
-// Default values
-const (
-	defaultMaxMessages = 10
-	defaultWaitTime    = 20
-)
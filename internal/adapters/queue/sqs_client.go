@@ -2,7 +2,6 @@ package queue
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -24,20 +23,15 @@ func NewSQSClient(client *sqs.Client, logger *logger.Logger) *SQSClient {
 	}
 }
 
-func (c *SQSClient) Publish(ctx context.Context, queueURL string, payload any) error {
+// Send encola body con attributes como atributos de mensaje.
+func (c *SQSClient) Send(ctx context.Context, queueURL string, body []byte, attributes map[string]string) error {
 	c.logger.Debug("Preparando envío de mensaje a SQS",
 		zap.String("queue_url", queueURL))
 
-	jsonBytes, err := json.Marshal(payload)
-	if err != nil {
-		c.logger.Error("Error serializando payload para SQS",
-			zap.Error(err))
-		return fmt.Errorf("error serializando payload: %w", err)
-	}
-
-	_, err = c.client.SendMessage(ctx, &sqs.SendMessageInput{
-		QueueUrl:    aws.String(queueURL),
-		MessageBody: aws.String(string(jsonBytes)),
+	_, err := c.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(string(body)),
+		MessageAttributes: messageAttributes(attributes),
 	})
 
 	if err != nil {
@@ -59,9 +53,10 @@ func (c *SQSClient) ReceiveMessages(ctx context.Context, queueURL string, maxMes
 		zap.Int32("wait_time_seconds", waitTimeSeconds))
 
 	result, err := c.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-		QueueUrl:            aws.String(queueURL),
-		MaxNumberOfMessages: maxMessages,
-		WaitTimeSeconds:     waitTimeSeconds,
+		QueueUrl:              aws.String(queueURL),
+		MaxNumberOfMessages:   maxMessages,
+		WaitTimeSeconds:       waitTimeSeconds,
+		MessageAttributeNames: []string{"All"},
 	})
 
 	if err != nil {
@@ -101,6 +96,24 @@ func (c *SQSClient) DeleteMessage(ctx context.Context, queueURL string, receiptH
 	return nil
 }
 
-func (c *SQSClient) Send(ctx context.Context, queueURL string, payload any) error {
-	return c.Publish(ctx, queueURL, payload)
+func (c *SQSClient) Ping(ctx context.Context, queueURL string) error {
+	_, err := c.client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+	})
+	if err != nil {
+		return fmt.Errorf("error consultando cola SQS %s: %w", queueURL, err)
+	}
+	return nil
+}
+
+func messageAttributes(values map[string]string) map[string]types.MessageAttributeValue {
+	attributes := make(map[string]types.MessageAttributeValue, len(values))
+	for key, value := range values {
+		attributes[key] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return attributes
 }
@@ -10,6 +10,9 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/juanmalvarez3/twit/pkg/config"
 	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -54,10 +57,15 @@ func NewClient(cfg *config.Config, logger logger.LoggerInterface) (*Client, erro
 }
 
 func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, span := startSpan(ctx, "GET", key)
+	defer span.End()
+
 	val, err := c.client.Get(ctx, key).Result()
 	if errors.Is(err, redis.Nil) {
 		return nil, nil
 	} else if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "get")
 		c.logger.Error("Error obteniendo valor de Redis",
 			zap.String("key", key),
 			zap.String("error", err.Error()),
@@ -67,10 +75,41 @@ func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
 	return []byte(val), nil
 }
 
+// MGet devuelve los valores de las claves en el mismo orden; las claves que no
+// existen quedan en nil.
+func (c *Client) MGet(ctx context.Context, keys ...string) ([][]byte, error) {
+	ctx, span := startSpan(ctx, "MGET", keys...)
+	defer span.End()
+
+	vals, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "mget")
+		c.logger.Error("Error obteniendo valores de Redis",
+			zap.Int("keys_count", len(keys)),
+			zap.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	result := make([][]byte, len(vals))
+	for i, val := range vals {
+		if str, ok := val.(string); ok {
+			result[i] = []byte(str)
+		}
+	}
+	return result, nil
+}
+
 func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	ctx, span := startSpan(ctx, "SET", key)
+	defer span.End()
+
 	strValue := string(value)
 	err := c.client.Set(ctx, key, strValue, ttl).Err()
 	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "set")
 		c.logger.Error("Error estableciendo valor en Redis",
 			zap.String("key", key),
 			zap.String("error", err.Error()),
@@ -80,9 +119,49 @@ func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Dur
 	return nil
 }
 
+// SetNX guarda value en key sólo si no existe. Devuelve true si lo guardó.
+func (c *Client) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	ctx, span := startSpan(ctx, "SETNX", key)
+	defer span.End()
+
+	ok, err := c.client.SetNX(ctx, key, string(value), ttl).Result()
+	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "setnx")
+		c.logger.Error("Error estableciendo valor condicional en Redis",
+			zap.String("key", key),
+			zap.String("error", err.Error()),
+		)
+		return false, err
+	}
+	return ok, nil
+}
+
+// Expire renueva la expiración de key.
+func (c *Client) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	ctx, span := startSpan(ctx, "EXPIRE", key)
+	defer span.End()
+
+	if err := c.client.Expire(ctx, key, ttl).Err(); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "expire")
+		c.logger.Error("Error renovando expiración en Redis",
+			zap.String("key", key),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
 func (c *Client) Del(ctx context.Context, keys ...string) error {
+	ctx, span := startSpan(ctx, "DEL", keys...)
+	defer span.End()
+
 	err := c.client.Del(ctx, keys...).Err()
 	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "del")
 		c.logger.Error("Error eliminando clave(s) de Redis",
 			zap.Any("keys", keys),
 			zap.String("error", err.Error()),
@@ -91,3 +170,30 @@ func (c *Client) Del(ctx context.Context, keys ...string) error {
 	}
 	return nil
 }
+
+// Close cierra el pool de conexiones.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "PING")
+	defer span.End()
+
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "ping")
+		return err
+	}
+	return nil
+}
+
+func startSpan(ctx context.Context, operation string, keys ...string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "redis."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			tracing.String("db.system", "redis"),
+			tracing.String("db.operation", operation),
+			tracing.Int("db.redis.keys_count", len(keys)),
+		))
+}
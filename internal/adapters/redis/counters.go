@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// IncrementCounter incrementa field en el hash key y renueva su expiración en
+// un único round-trip.
+func (c *Client) IncrementCounter(ctx context.Context, key, field string, ttl time.Duration) error {
+	ctx, span := startSpan(ctx, "HINCRBY", key)
+	defer span.End()
+
+	pipe := c.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, field, 1)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "hincrby")
+		c.logger.Error("Error incrementando contador en Redis",
+			zap.String("key", key),
+			zap.String("field", field),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// Increment incrementa el entero guardado en key y renueva su expiración en un
+// único round-trip.
+func (c *Client) Increment(ctx context.Context, key string, ttl time.Duration) error {
+	ctx, span := startSpan(ctx, "INCR", key)
+	defer span.End()
+
+	pipe := c.client.TxPipeline()
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "incr")
+		c.logger.Error("Error incrementando valor en Redis",
+			zap.String("key", key),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// Counters devuelve el contenido de varios hashes, en el mismo orden que keys.
+// Las claves inexistentes se devuelven como un mapa vacío.
+func (c *Client) Counters(ctx context.Context, keys ...string) ([]map[string]string, error) {
+	ctx, span := startSpan(ctx, "HGETALL", keys...)
+	defer span.End()
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.HGetAll(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "hgetall")
+		c.logger.Error("Error obteniendo contadores de Redis",
+			zap.Int("keys", len(keys)),
+			zap.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	result := make([]map[string]string, len(keys))
+	for i, cmd := range cmds {
+		result[i] = cmd.Val()
+	}
+	return result, nil
+}
+
+// AddUnique agrega members al HyperLogLog key y renueva su expiración.
+func (c *Client) AddUnique(ctx context.Context, key string, ttl time.Duration, members ...string) error {
+	ctx, span := startSpan(ctx, "PFADD", key)
+	defer span.End()
+
+	args := make([]interface{}, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+
+	pipe := c.client.TxPipeline()
+	pipe.PFAdd(ctx, key, args...)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "pfadd")
+		c.logger.Error("Error agregando elementos a HyperLogLog en Redis",
+			zap.String("key", key),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// CountUnique devuelve la cardinalidad aproximada de la unión de los
+// HyperLogLog indicados.
+func (c *Client) CountUnique(ctx context.Context, keys ...string) (int64, error) {
+	ctx, span := startSpan(ctx, "PFCOUNT", keys...)
+	defer span.End()
+
+	count, err := c.client.PFCount(ctx, keys...).Result()
+	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "pfcount")
+		c.logger.Error("Error contando elementos de HyperLogLog en Redis",
+			zap.Strings("keys", keys),
+			zap.String("error", err.Error()),
+		)
+		return 0, err
+	}
+	return count, nil
+}
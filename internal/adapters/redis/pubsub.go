@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// Message es un mensaje recibido por pub/sub.
+type Message struct {
+	Channel string
+	Payload []byte
+}
+
+func (c *Client) Publish(ctx context.Context, channel string, payload []byte) error {
+	ctx, span := startSpan(ctx, "PUBLISH", channel)
+	defer span.End()
+
+	if err := c.client.Publish(ctx, channel, payload).Err(); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "publish")
+		c.logger.Error("Error publicando mensaje en Redis",
+			zap.String("channel", channel),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// PSubscribe se suscribe a los canales que coinciden con patterns y entrega
+// sus mensajes hasta que se cancele ctx. El cliente de Redis reconecta solo
+// si se pierde la conexión; los mensajes publicados mientras tanto se pierden.
+func (c *Client) PSubscribe(ctx context.Context, patterns ...string) (<-chan Message, error) {
+	pubsub := c.client.PSubscribe(ctx, patterns...)
+	// Redis confirma cada patrón por separado.
+	for range patterns {
+		if _, err := pubsub.Receive(ctx); err != nil {
+			_ = pubsub.Close()
+			metrics.DependencyError(metrics.DependencyRedis, "psubscribe")
+			c.logger.Error("Error suscribiendo a canales de Redis",
+				zap.Strings("patterns", patterns),
+				zap.String("error", err.Error()),
+			)
+			return nil, err
+		}
+	}
+
+	messages := make(chan Message)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		source := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-source:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- Message{Channel: msg.Channel, Payload: []byte(msg.Payload)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
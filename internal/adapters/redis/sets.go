@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// AddMembers agrega members al set key.
+func (c *Client) AddMembers(ctx context.Context, key string, members ...string) error {
+	ctx, span := startSpan(ctx, "SADD", key)
+	defer span.End()
+
+	args := make([]interface{}, len(members))
+	for i, member := range members {
+		args[i] = member
+	}
+
+	if err := c.client.SAdd(ctx, key, args...).Err(); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "sadd")
+		c.logger.Error("Error agregando elementos a set en Redis",
+			zap.String("key", key),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// PopMembers saca y devuelve hasta count elementos al azar del set key.
+func (c *Client) PopMembers(ctx context.Context, key string, count int64) ([]string, error) {
+	ctx, span := startSpan(ctx, "SPOP", key)
+	defer span.End()
+
+	members, err := c.client.SPopN(ctx, key, count).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "spop")
+		c.logger.Error("Error sacando elementos de set en Redis",
+			zap.String("key", key),
+			zap.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	return members, nil
+}
@@ -0,0 +1,199 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// StreamEntry es una entrada de un stream.
+type StreamEntry struct {
+	Stream string
+	ID     string
+	Values map[string]string
+}
+
+// PendingEntry es una entrada entregada a un consumidor y todavía sin
+// confirmar.
+type PendingEntry struct {
+	ID         string
+	Consumer   string
+	Idle       time.Duration
+	Deliveries int64
+}
+
+// AddToStream agrega values a stream, recortándolo aproximadamente a maxLen
+// entradas si maxLen es positivo, y devuelve el ID de la entrada.
+func (c *Client) AddToStream(ctx context.Context, stream string, maxLen int64, values map[string]string) (string, error) {
+	ctx, span := startSpan(ctx, "XADD", stream)
+	defer span.End()
+
+	fields := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		fields[key] = value
+	}
+
+	id, err := c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: maxLen > 0,
+		Values: fields,
+	}).Result()
+	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "xadd")
+		c.logger.Error("Error agregando entrada a stream en Redis",
+			zap.String("stream", stream),
+			zap.String("error", err.Error()),
+		)
+		return "", err
+	}
+	return id, nil
+}
+
+// CreateGroup crea el grupo de consumidores group en stream, creando el
+// stream si no existe. El grupo recibe sólo las entradas posteriores a su
+// creación; crearlo dos veces no tiene efecto.
+func (c *Client) CreateGroup(ctx context.Context, stream, group string) error {
+	err := c.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		metrics.DependencyError(metrics.DependencyRedis, "xgroup")
+		c.logger.Error("Error creando grupo de consumidores en Redis",
+			zap.String("stream", stream),
+			zap.String("group", group),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// ReadGroup lee para consumer hasta count entradas nuevas de streams en el
+// grupo group, esperando hasta block a que lleguen. Las lecturas no abren
+// span: serían uno por cada poll vacío.
+func (c *Client) ReadGroup(ctx context.Context, group, consumer string, streams []string, count int64, block time.Duration) ([]StreamEntry, error) {
+	args := make([]string, 0, 2*len(streams))
+	args = append(args, streams...)
+	for range streams {
+		args = append(args, ">")
+	}
+	if block <= 0 {
+		block = -1
+	}
+
+	result, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  args,
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		metrics.DependencyError(metrics.DependencyRedis, "xreadgroup")
+		return nil, fmt.Errorf("error leyendo streams del grupo %s: %w", group, err)
+	}
+
+	var entries []StreamEntry
+	for _, stream := range result {
+		for _, message := range stream.Messages {
+			entries = append(entries, streamEntry(stream.Stream, message))
+		}
+	}
+	return entries, nil
+}
+
+// PendingIdle devuelve hasta count entradas de stream entregadas en group que
+// llevan al menos minIdle sin confirmarse.
+func (c *Client) PendingIdle(ctx context.Context, stream, group string, minIdle time.Duration, count int64) ([]PendingEntry, error) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  group,
+		Idle:   minIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil {
+		metrics.DependencyError(metrics.DependencyRedis, "xpending")
+		return nil, fmt.Errorf("error consultando pendientes de %s en el grupo %s: %w", stream, group, err)
+	}
+
+	entries := make([]PendingEntry, len(pending))
+	for i, entry := range pending {
+		entries[i] = PendingEntry{
+			ID:         entry.ID,
+			Consumer:   entry.Consumer,
+			Idle:       entry.Idle,
+			Deliveries: entry.RetryCount,
+		}
+	}
+	return entries, nil
+}
+
+// Claim pasa a consumer las entradas ids de stream que sigan llevando al
+// menos minIdle sin confirmarse, y las devuelve.
+func (c *Client) Claim(ctx context.Context, stream, group, consumer string, minIdle time.Duration, ids ...string) ([]StreamEntry, error) {
+	ctx, span := startSpan(ctx, "XCLAIM", stream)
+	defer span.End()
+
+	messages, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "xclaim")
+		c.logger.Error("Error reclamando entradas de stream en Redis",
+			zap.String("stream", stream),
+			zap.String("group", group),
+			zap.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	entries := make([]StreamEntry, len(messages))
+	for i, message := range messages {
+		entries[i] = streamEntry(stream, message)
+	}
+	return entries, nil
+}
+
+// Ack confirma las entradas ids de stream en group.
+func (c *Client) Ack(ctx context.Context, stream, group string, ids ...string) error {
+	ctx, span := startSpan(ctx, "XACK", stream)
+	defer span.End()
+
+	if err := c.client.XAck(ctx, stream, group, ids...).Err(); err != nil {
+		tracing.RecordError(span, err)
+		metrics.DependencyError(metrics.DependencyRedis, "xack")
+		c.logger.Error("Error confirmando entradas de stream en Redis",
+			zap.String("stream", stream),
+			zap.String("group", group),
+			zap.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+func streamEntry(stream string, message redis.XMessage) StreamEntry {
+	values := make(map[string]string, len(message.Values))
+	for key, value := range message.Values {
+		if s, ok := value.(string); ok {
+			values[key] = s
+		}
+	}
+	return StreamEntry{Stream: stream, ID: message.ID, Values: values}
+}
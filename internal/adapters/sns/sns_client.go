@@ -2,7 +2,6 @@ package sns
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -24,19 +23,13 @@ func NewSNSClient(client *sns.Client, logger *logger.Logger) *SNSClient {
 	}
 }
 
-func (c *SNSClient) PublishMessage(ctx context.Context, topicARN string, message interface{}, messageAttributes map[string]string) error {
+// Publish publica body en topicARN con messageAttributes como atributos de
+// mensaje.
+func (c *SNSClient) Publish(ctx context.Context, topicARN string, body []byte, messageAttributes map[string]string) error {
 	c.logger.Debug("Preparando publicación en SNS",
 		zap.String("topic_arn", topicARN))
 
-	jsonBytes, err := json.Marshal(message)
-	if err != nil {
-		c.logger.Error("Error serializando mensaje para SNS",
-			zap.String("topic_arn", topicARN),
-			zap.Error(err))
-		return fmt.Errorf("error serializando mensaje para SNS: %w", err)
-	}
-
-	attributes := make(map[string]types.MessageAttributeValue)
+	attributes := make(map[string]types.MessageAttributeValue, len(messageAttributes))
 	for key, value := range messageAttributes {
 		attributes[key] = types.MessageAttributeValue{
 			DataType:    aws.String("String"),
@@ -44,9 +37,9 @@ func (c *SNSClient) PublishMessage(ctx context.Context, topicARN string, message
 		}
 	}
 
-	_, err = c.client.Publish(ctx, &sns.PublishInput{
+	_, err := c.client.Publish(ctx, &sns.PublishInput{
 		TopicArn:          aws.String(topicARN),
-		Message:           aws.String(string(jsonBytes)),
+		Message:           aws.String(string(body)),
 		MessageAttributes: attributes,
 	})
 
@@ -62,6 +55,18 @@ func (c *SNSClient) PublishMessage(ctx context.Context, topicARN string, message
 	return nil
 }
 
+func (c *SNSClient) Ping(ctx context.Context, topicARN string) error {
+	_, err := c.client.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{
+		TopicArn: aws.String(topicARN),
+	})
+	if err != nil {
+		return fmt.Errorf("error consultando tópico SNS %s: %w", topicARN, err)
+	}
+	return nil
+}
+
+// SNSMessage es el sobre con el que SNS entrega un mensaje a una cola
+// suscrita sin raw delivery.
 type SNSMessage struct {
 	Type             string `json:"Type"`
 	MessageId        string `json:"MessageId"`
@@ -73,4 +78,11 @@ type SNSMessage struct {
 	Signature        string `json:"Signature"`
 	SigningCertURL   string `json:"SigningCertURL"`
 	UnsubscribeURL   string `json:"UnsubscribeURL"`
+
+	MessageAttributes map[string]SNSMessageAttribute `json:"MessageAttributes,omitempty"`
+}
+
+type SNSMessageAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
 }
@@ -0,0 +1,87 @@
+// Package app es la raíz de composición: construye una sola vez los clientes
+// de infraestructura a partir de la configuración y los inyecta en
+// repositorios, servicios y casos de uso.
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+type App struct {
+	Config       *config.Config
+	Logger       *logger.Logger
+	Infra        Infra
+	Repositories Repositories
+	Publishers   Publishers
+	Services     Services
+
+	stopInvalidations context.CancelFunc
+	invalidationsDone chan struct{}
+}
+
+// New arma la aplicación completa sobre los backends elegidos en cfg.Backend.
+func New(ctx context.Context, cfg *config.Config, log *logger.Logger) (*App, error) {
+	infra, err := NewInfra(ctx, cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compose(cfg, log, infra, NewRepositories(cfg, infra, log), NewPublishers(cfg, infra, log)), nil
+}
+
+// Compose arma los servicios sobre los repositorios y publishers dados, lo
+// que permite reemplazar cualquiera de ellos sin tocar el resto.
+func Compose(cfg *config.Config, log *logger.Logger, infra Infra, repos Repositories, publishers Publishers) *App {
+	return &App{
+		Config:       cfg,
+		Logger:       log,
+		Infra:        infra,
+		Repositories: repos,
+		Publishers:   publishers,
+		Services:     NewServices(cfg, repos, publishers, log),
+	}
+}
+
+// RunInvalidations aplica en esta instancia las invalidaciones de caché que
+// publican las demás hasta que se llame a Close.
+func (a *App) RunInvalidations() {
+	if a.Infra.Invalidator == nil || a.stopInvalidations != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopInvalidations = cancel
+	a.invalidationsDone = make(chan struct{})
+	go func() {
+		defer close(a.invalidationsDone)
+		a.Infra.Invalidator.Run(ctx, a.Logger)
+	}()
+}
+
+// Close corta la suscripción a invalidaciones y libera las conexiones que
+// abrió New: la base SQL y los clientes de Redis, si se usan. Los backends en
+// memoria no tienen nada que cerrar.
+func (a *App) Close() error {
+	if a.stopInvalidations != nil {
+		a.stopInvalidations()
+		<-a.invalidationsDone
+		a.stopInvalidations = nil
+	}
+
+	var errs []error
+	if a.Infra.SQL != nil {
+		errs = append(errs, a.Infra.SQL.Close())
+	}
+	if closer, ok := a.Infra.Cache.(io.Closer); ok {
+		errs = append(errs, closer.Close())
+	}
+	if a.Infra.BrokerRedis != nil {
+		errs = append(errs, a.Infra.BrokerRedis.Close())
+	}
+	return errors.Join(errs...)
+}
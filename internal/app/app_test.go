@@ -0,0 +1,183 @@
+package app_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"github.com/juanmalvarez3/twit/internal/app"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/workers"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+// fakePubSub registra la suscripción a invalidaciones para poder ver cuándo
+// se corta.
+type fakePubSub struct {
+	subscribed chan context.Context
+}
+
+func (f *fakePubSub) Publish(context.Context, string, []byte) error {
+	return nil
+}
+
+func (f *fakePubSub) PSubscribe(ctx context.Context, _ ...string) (<-chan redisadapter.Message, error) {
+	messages := make(chan redisadapter.Message)
+	go func() {
+		<-ctx.Done()
+		close(messages)
+	}()
+	f.subscribed <- ctx
+	return messages, nil
+}
+
+func newConfig(t *testing.T, storage string) *config.Config {
+	t.Helper()
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.Backend = config.BackendConfig{
+		Storage:   storage,
+		Messaging: config.BackendMemory,
+		Cache:     config.BackendMemory,
+	}
+	cfg.Realtime.AuthSecret = "secreto"
+	return cfg
+}
+
+func newLogger(t *testing.T) *logger.Logger {
+	t.Helper()
+
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	return log
+}
+
+func newApp(t *testing.T, infra app.Infra) *app.App {
+	t.Helper()
+
+	return app.Compose(newConfig(t, config.BackendMemory), newLogger(t), infra, app.Repositories{}, app.Publishers{})
+}
+
+// assertWired arma todos los casos de uso y workers: si falta alguna
+// dependencia falla acá y no al arrancar el proceso.
+func assertWired(t *testing.T, a *app.App) {
+	t.Helper()
+
+	assert.NotNil(t, a.CreateTweet())
+	assert.NotNil(t, a.GetTweet())
+	assert.NotNil(t, a.GetHashtagTweets())
+	assert.NotNil(t, a.GetUserTweets())
+	assert.NotNil(t, a.CreateFollow())
+	assert.NotNil(t, a.GetFollow())
+	assert.NotNil(t, a.ProcessNewFollow())
+	assert.NotNil(t, a.GetTrends())
+	assert.NotNil(t, a.RecordTweet())
+	assert.NotNil(t, a.GetNotifications())
+	assert.NotNil(t, a.ReadNotifications())
+	assert.NotNil(t, a.NotifyTweet())
+	assert.NotNil(t, a.NotifyFollow())
+	assert.NotNil(t, a.GetTimeline())
+	assert.NotNil(t, a.UpdateTimeline())
+	assert.NotNil(t, a.PopulateCache())
+	assert.NotNil(t, a.RebuildTimeline())
+	assert.NotNil(t, a.TrimTimelines())
+	assert.NotNil(t, a.OrchestrateFanout())
+
+	hub := a.TimelineHub()
+	require.NotNil(t, hub)
+	assert.NotNil(t, a.StreamTimeline(hub))
+
+	gw, err := a.RealtimeGateway()
+	require.NoError(t, err)
+	assert.NotNil(t, gw)
+
+	assert.Len(t, workers.Build(workers.Names(), a), len(workers.Names()))
+}
+
+func TestNew_Memory(t *testing.T) {
+	ctx := context.Background()
+
+	a, err := app.New(ctx, newConfig(t, config.BackendMemory), newLogger(t))
+	require.NoError(t, err)
+	assertWired(t, a)
+
+	_, err = a.CreateTweet().CreateTweet(ctx, &dmntweet.Tweet{UserID: "user1", Content: "hola"})
+	require.NoError(t, err)
+
+	a.RunInvalidations()
+	assert.NoError(t, a.Close())
+}
+
+func TestNew_SQL(t *testing.T) {
+	ctx := context.Background()
+	cfg := newConfig(t, config.BackendSQL)
+	cfg.SQL = config.SQLConfig{
+		Driver:      sqldb.DriverSQLite,
+		DSN:         filepath.Join(t.TempDir(), "twit.db"),
+		AutoMigrate: true,
+	}
+
+	a, err := app.New(ctx, cfg, newLogger(t))
+	require.NoError(t, err)
+	require.NotNil(t, a.Infra.SQL)
+	assertWired(t, a)
+
+	_, err = a.CreateTweet().CreateTweet(ctx, &dmntweet.Tweet{UserID: "user1", Content: "hola"})
+	require.NoError(t, err)
+
+	require.NoError(t, a.Close())
+	assert.Error(t, a.Infra.SQL.PingContext(ctx), "la base debería quedar cerrada")
+}
+
+func TestNew_UnknownBackend(t *testing.T) {
+	_, err := app.New(context.Background(), newConfig(t, "cassandra"), newLogger(t))
+
+	assert.ErrorContains(t, err, "backend de almacenamiento desconocido")
+}
+
+func TestClose_StopsInvalidations(t *testing.T) {
+	pubsub := &fakePubSub{subscribed: make(chan context.Context, 1)}
+	a := newApp(t, app.Infra{Invalidator: localcache.NewInvalidator(pubsub)})
+
+	a.RunInvalidations()
+	var subscription context.Context
+	select {
+	case subscription = <-pubsub.subscribed:
+	case <-time.After(time.Second):
+		t.Fatal("no se suscribió a las invalidaciones")
+	}
+
+	require.NoError(t, a.Close())
+	assert.Error(t, subscription.Err())
+	assert.NoError(t, a.Close())
+}
+
+func TestRealtimeGateway_RequiresSecretOutsideDevelopment(t *testing.T) {
+	a := newApp(t, app.Infra{})
+
+	a.Config.Log.Environment = "production"
+	a.Config.Realtime.AuthSecret = ""
+	_, err := a.RealtimeGateway()
+	assert.ErrorContains(t, err, "WS_AUTH_SECRET")
+
+	a.Config.Log.Environment = config.EnvironmentDevelopment
+	gw, err := a.RealtimeGateway()
+	require.NoError(t, err)
+	assert.NotNil(t, gw)
+
+	a.Config.Log.Environment = "production"
+	a.Config.Realtime.AuthSecret = "secreto"
+	gw, err = a.RealtimeGateway()
+	require.NoError(t, err)
+	assert.NotNil(t, gw)
+}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"context"
+
+	"github.com/juanmalvarez3/twit/pkg/health"
+)
+
+// TableCheck verifica una tabla del almacenamiento. Con SQL verifica la
+// conexión, compartida por todas las tablas. En memoria no hay nada que pueda
+// fallar, así que el check siempre pasa.
+func (a *App) TableCheck(table string) health.Checker {
+	if a.Infra.SQL != nil {
+		return health.SQL(a.Infra.SQL)
+	}
+	if a.Infra.DynamoDB == nil {
+		return health.NewCheck("memory:"+table, func(context.Context) error { return nil })
+	}
+	return health.DynamoDBTable(a.Infra.DynamoDB, table)
+}
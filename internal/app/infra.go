@@ -0,0 +1,196 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	awsdynamodb "github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker/redisstreams"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker/snssqs"
+	"github.com/juanmalvarez3/twit/internal/adapters/memory"
+	"github.com/juanmalvarez3/twit/internal/adapters/queue"
+	"github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"github.com/juanmalvarez3/twit/internal/adapters/sns"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	pkgdynamodb "github.com/juanmalvarez3/twit/pkg/dynamodb"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	pkgsns "github.com/juanmalvarez3/twit/pkg/sns"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+	pkgsqs "github.com/juanmalvarez3/twit/pkg/sqs"
+	"go.uber.org/zap"
+)
+
+// Cache es el contrato del cliente de Redis que usa la aplicación. Lo cumplen
+// redis.Client y memory.Cache.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	MGet(ctx context.Context, keys ...string) ([][]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Expire(ctx context.Context, key string, ttl time.Duration) error
+	Increment(ctx context.Context, key string, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+	Ping(ctx context.Context) error
+	IncrementCounter(ctx context.Context, key, field string, ttl time.Duration) error
+	Counters(ctx context.Context, keys ...string) ([]map[string]string, error)
+	AddUnique(ctx context.Context, key string, ttl time.Duration, members ...string) error
+	CountUnique(ctx context.Context, keys ...string) (int64, error)
+	AddMembers(ctx context.Context, key string, members ...string) error
+	PopMembers(ctx context.Context, key string, count int64) ([]string, error)
+	Publish(ctx context.Context, channel string, payload []byte) error
+	PSubscribe(ctx context.Context, patterns ...string) (<-chan redis.Message, error)
+}
+
+// Infra son los clientes de infraestructura compartidos por todo el proceso.
+// De DynamoDB y SQL sólo se crea el del almacenamiento elegido; el otro queda
+// en nil, y los dos en memoria.
+type Infra struct {
+	DynamoDB *awsdynamodb.Client
+	SQL      *sqldb.DB
+	Broker   broker.Broker
+	Cache    Cache
+	// BrokerRedis es la conexión propia de los streams cuando la caché no
+	// está en Redis; si lo está, los streams comparten Cache y queda en nil.
+	BrokerRedis *redis.Client
+	// Invalidator reparte entre las instancias las invalidaciones de los
+	// cachés en memoria de timelines y tweets.
+	Invalidator *localcache.Invalidator
+}
+
+// NewInfra crea los clientes de cada dependencia según el backend elegido en
+// cfg.Backend.
+func NewInfra(ctx context.Context, cfg *config.Config, log *logger.Logger) (Infra, error) {
+	var infra Infra
+
+	switch cfg.Backend.Storage {
+	case config.BackendDynamoDB:
+		dynamoClient, err := pkgdynamodb.New(ctx, cfg)
+		if err != nil {
+			return Infra{}, fmt.Errorf("error inicializando cliente DynamoDB: %w", err)
+		}
+		infra.DynamoDB = dynamoClient
+	case config.BackendSQL:
+		db, err := NewSQL(ctx, cfg, log)
+		if err != nil {
+			return Infra{}, err
+		}
+		infra.SQL = db
+	case config.BackendMemory:
+	default:
+		return Infra{}, fmt.Errorf("backend de almacenamiento desconocido: %q", cfg.Backend.Storage)
+	}
+
+	switch cfg.Backend.Cache {
+	case config.BackendRedis:
+		redisClient, err := redis.NewClient(cfg, log)
+		if err != nil {
+			return Infra{}, fmt.Errorf("error inicializando cliente Redis: %w", err)
+		}
+		infra.Cache = redisClient
+	case config.BackendMemory:
+		infra.Cache = memory.NewCache()
+	default:
+		return Infra{}, fmt.Errorf("backend de caché desconocido: %q", cfg.Backend.Cache)
+	}
+
+	switch cfg.Backend.Messaging {
+	case config.BackendAWS:
+		awsBroker, err := NewAWSBroker(ctx, cfg, log)
+		if err != nil {
+			return Infra{}, err
+		}
+		infra.Broker = awsBroker
+	case config.BackendRedis:
+		// Con la caché en Redis, los streams usan la misma conexión.
+		redisClient, ok := infra.Cache.(*redis.Client)
+		if !ok {
+			var err error
+			redisClient, err = redis.NewClient(cfg, log)
+			if err != nil {
+				return Infra{}, fmt.Errorf("error inicializando cliente Redis: %w", err)
+			}
+			infra.BrokerRedis = redisClient
+		}
+		infra.Broker = redisstreams.New(redisClient, broker.DefaultTopology(), redisstreams.Options{
+			Consumer:          cfg.Broker.ConsumerName,
+			VisibilityTimeout: time.Duration(cfg.Broker.VisibilityTimeoutSeconds) * time.Second,
+			MaxDeliveries:     int64(cfg.Broker.MaxDeliveries),
+			MaxLen:            int64(cfg.Broker.StreamMaxLen),
+		}, log)
+	case config.BackendMemory:
+		infra.Broker = NewBroker(cfg)
+	default:
+		return Infra{}, fmt.Errorf("backend de mensajería desconocido: %q", cfg.Backend.Messaging)
+	}
+
+	infra.Invalidator = localcache.NewInvalidator(infra.Cache)
+
+	return infra, nil
+}
+
+// NewAWSBroker arma el broker sobre los tópicos SNS y las colas SQS que crea
+// twitctl migrate.
+func NewAWSBroker(ctx context.Context, cfg *config.Config, log *logger.Logger) (*snssqs.Broker, error) {
+	snsClient, err := pkgsns.New(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error inicializando cliente SNS: %w", err)
+	}
+	sqsClient, err := pkgsqs.New(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error inicializando cliente SQS: %w", err)
+	}
+
+	return snssqs.New(sns.NewSNSClient(snsClient, log), queue.NewSQSClient(sqsClient, log),
+		broker.DefaultTopology(), AWSRoutes(cfg), log), nil
+}
+
+// AWSRoutes son los tópicos SNS y las colas SQS de la configuración que
+// corresponden a cada tópico y suscripción del broker.
+func AWSRoutes(cfg *config.Config) snssqs.Routes {
+	return snssqs.Routes{
+		Topics: map[string]string{
+			broker.TopicTweets:  cfg.SNS.TweetsTopic,
+			broker.TopicFollows: cfg.SNS.FollowsTopic,
+		},
+		Queues: map[string]string{
+			broker.SubscriptionOrchestrateFanout: cfg.SQS.OrchestrateQueue,
+			broker.SubscriptionUpdateTrends:      cfg.SQS.UpdateTrendsQueue,
+			broker.SubscriptionNotifications:     cfg.SQS.NotificationsQueue,
+			broker.SubscriptionProcessNewFollow:  cfg.SQS.ProcessFollowQueue,
+			broker.SubscriptionUpdateTimeline:    cfg.SQS.UpdateTimelineQueue,
+			broker.SubscriptionPopulateCache:     cfg.SQS.PopulateCacheQueue,
+			broker.SubscriptionRebuildTimeline:   cfg.SQS.RebuildTimelineQueue,
+		},
+	}
+}
+
+// NewSQL abre la base relacional y, con SQL_AUTO_MIGRATE, le aplica las
+// migraciones pendientes.
+func NewSQL(ctx context.Context, cfg *config.Config, log *logger.Logger) (*sqldb.DB, error) {
+	db, err := sqldb.Open(ctx, cfg.SQL.Driver, cfg.SQL.DSN, cfg.SQL.MaxOpenConns)
+	if err != nil {
+		return nil, fmt.Errorf("error abriendo la base SQL: %w", err)
+	}
+	if !cfg.SQL.AutoMigrate {
+		return db, nil
+	}
+
+	applied, err := sqldb.Migrate(ctx, db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if len(applied) > 0 {
+		log.Info("Migraciones SQL aplicadas", zap.Ints("versions", applied))
+	}
+	return db, nil
+}
+
+// NewBroker arma el broker en memoria con la topología de la aplicación.
+func NewBroker(cfg *config.Config) *memory.Broker {
+	return memory.NewBroker(broker.DefaultTopology(),
+		time.Duration(cfg.Broker.VisibilityTimeoutSeconds)*time.Second)
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/redis"
+	followrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository"
+	followmemory "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository/memory"
+	followsqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository/sqlstore"
+	followservices "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/services"
+	notificationrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository"
+	notificationmemory "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository/memory"
+	notificationsqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository/sqlstore"
+	notificationservice "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/service"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	timelinerepository "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository"
+	timelinememory "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository/memory"
+	timelinesqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository/sqlstore"
+	timelineservice "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/service"
+	trendrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/repository"
+	trendservice "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/service"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	tweetrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository"
+	tweetmemory "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/memory"
+	tweetsqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/sqlstore"
+	tweetservices "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/services"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+// Repositories agrupa el almacenamiento de cada dominio detrás de las
+// interfaces que consumen sus servicios.
+type Repositories struct {
+	Tweets        tweetservices.Repository
+	Follows       followservices.Repository
+	Timelines     timelineservice.Repository
+	Trends        trendservice.Repository
+	Notifications notificationservice.Repository
+}
+
+// NewRepositories crea los repositorios sobre DynamoDB y la caché, o sobre
+// SQL o en memoria si así lo pide cfg.Backend.Storage. Las tendencias viven
+// siempre en la caché, igual que los avisos de timelines y notificaciones.
+func NewRepositories(cfg *config.Config, infra Infra, log *logger.Logger) Repositories {
+	storeContent := cfg.Timeline.StorageMode != dmntimeline.StorageIDs
+	maxAge := time.Duration(cfg.Timeline.MaxAgeDays) * 24 * time.Hour
+
+	switch cfg.Backend.Storage {
+	case config.BackendSQL:
+		return Repositories{
+			Tweets:        tweetsqlstore.NewRepository(infra.SQL),
+			Follows:       followsqlstore.NewRepository(infra.SQL),
+			Timelines:     timelinesqlstore.NewRepository(infra.SQL, infra.Cache, storeContent, maxAge),
+			Trends:        trendrepository.NewTrendRepository(infra.Cache, log),
+			Notifications: notificationsqlstore.NewRepository(infra.SQL, infra.Cache),
+		}
+	case config.BackendMemory:
+		return Repositories{
+			Tweets:        tweetmemory.NewRepository(),
+			Follows:       followmemory.NewRepository(),
+			Timelines:     timelinememory.NewRepository(infra.Cache, storeContent, maxAge),
+			Trends:        trendrepository.NewTrendRepository(infra.Cache, log),
+			Notifications: notificationmemory.NewRepository(infra.Cache),
+		}
+	}
+
+	return Repositories{
+		Tweets:        newTweetRepository(cfg, infra, log),
+		Follows:       followrepository.NewRepository(infra.DynamoDB, cfg.DynamoDB.FollowsTable, log),
+		Timelines:     newTimelineRepository(cfg, infra, log),
+		Trends:        trendrepository.NewTrendRepository(infra.Cache, log),
+		Notifications: notificationrepository.NewRepository(infra.DynamoDB, infra.Cache, cfg.DynamoDB.NotificationsTable, log),
+	}
+}
+
+func newTweetRepository(cfg *config.Config, infra Infra, log *logger.Logger) *tweetrepository.TweetRepository {
+	// Un TTL de cero desactiva la caché de la primera página de tweets por
+	// usuario. Es corto porque una lectura concurrente con un alta puede
+	// volver a cachear la página vieja después de la invalidación.
+	var cacheTTL, tweetCacheTTL time.Duration
+	var local *localcache.LRU[dmntweet.Tweet]
+	if cfg.Cache.Enabled {
+		cacheTTL = time.Duration(cfg.Cache.UserTweetsTTL) * time.Second
+		tweetCacheTTL = time.Duration(cfg.Cache.TweetTTL) * time.Second
+		if cfg.Cache.L1Enabled {
+			local = localcache.New[dmntweet.Tweet](cfg.Cache.L1TweetsSize,
+				time.Duration(cfg.Cache.L1TTLMs)*time.Millisecond)
+			infra.Invalidator.Register(localcache.KindTweet, local)
+		}
+	}
+
+	return tweetrepository.NewTweetRepository(infra.DynamoDB, infra.Cache, cfg.DynamoDB.TweetsTable,
+		cfg.DynamoDB.HashtagsTable, cacheTTL, tweetCacheTTL, local, log)
+}
+
+func newTimelineRepository(cfg *config.Config, infra Infra, log *logger.Logger) *timelinerepository.TimelineRepository {
+	cacheTTL := time.Duration(cfg.Redis.TimelineTTL) * time.Second
+	if cacheTTL <= 0 {
+		cacheTTL = time.Duration(cfg.Cache.TTL) * time.Second
+	}
+	if cacheTTL <= 0 {
+		cacheTTL = redis.DefaultTTL
+	}
+	cache := timelinerepository.CacheOptions{
+		Enabled:           cfg.Cache.Enabled,
+		TTL:               cacheTTL,
+		JitterPercent:     cfg.Cache.JitterPercent,
+		SlidingExpiration: cfg.Cache.SlidingExpiration,
+		LockTTL:           time.Duration(cfg.Cache.LockMs) * time.Millisecond,
+		LockWait:          time.Duration(cfg.Cache.LockWaitMs) * time.Millisecond,
+	}
+	if cfg.Cache.Enabled && cfg.Cache.L1Enabled {
+		cache.Local = localcache.New[dmntimeline.Timeline](cfg.Cache.L1TimelinesSize,
+			time.Duration(cfg.Cache.L1TTLMs)*time.Millisecond)
+		cache.Invalidator = infra.Invalidator
+		infra.Invalidator.Register(localcache.KindTimeline, cache.Local)
+	}
+
+	storeContent := cfg.Timeline.StorageMode != dmntimeline.StorageIDs
+	maxAge := time.Duration(cfg.Timeline.MaxAgeDays) * 24 * time.Hour
+	return timelinerepository.NewTimelineRepository(infra.DynamoDB, infra.Cache, cfg.DynamoDB.TimelinesTable,
+		storeContent, maxAge, cache, log)
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	followservices "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/services"
+	notificationservice "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/service"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifytweet"
+	realtimepublisher "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/publisher"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/publisher"
+	timelineservice "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/service"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/orchestratefanout"
+	trendservice "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/service"
+	tweetservices "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/services"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+// Publishers agrupa los destinos de los eventos que emiten servicios y casos
+// de uso.
+type Publishers struct {
+	Tweets          tweetservices.Publisher
+	Follows         followservices.Publisher
+	UpdateTimeline  orchestratefanout.Publisher
+	PopulateCache   publisher.TimelinePublisher
+	RebuildTimeline publisher.RebuildPublisher
+	Conversations   notifytweet.ConversationPublisher
+}
+
+// NewPublishers crea los publishers sobre el broker y la caché.
+func NewPublishers(cfg *config.Config, infra Infra, log *logger.Logger) Publishers {
+	// Un miss de caché de un usuario muy leído dispara a lo sumo un pedido de
+	// populate-cache y uno de rebuild-timeline por ventana.
+	dedupWindow := time.Duration(cfg.Cache.DedupSeconds) * time.Second
+
+	return Publishers{
+		Tweets:         broker.NewTweetPublisher(infra.Broker, log),
+		Follows:        broker.NewFollowPublisher(infra.Broker, log),
+		UpdateTimeline: broker.NewUpdateTimelinePublisher(infra.Broker),
+		PopulateCache: publisher.NewDedupTimelinePublisher(
+			broker.NewPopulateTimelineCachePublisher(infra.Broker, log),
+			infra.Cache, dedupWindow, log),
+		RebuildTimeline: publisher.NewDedupRebuildPublisher(
+			broker.NewRebuildTimelinePublisher(infra.Broker, log),
+			infra.Cache, dedupWindow, log),
+		Conversations: realtimepublisher.New(infra.Cache, log),
+	}
+}
+
+type Services struct {
+	Tweets        tweetservices.Service
+	Follows       followservices.Service
+	Timelines     timelineservice.Service
+	Trends        trendservice.Service
+	Notifications notificationservice.Service
+}
+
+// NewServices crea los servicios de cada dominio.
+func NewServices(cfg *config.Config, repos Repositories, publishers Publishers, log *logger.Logger) Services {
+	return Services{
+		Tweets:        tweetservices.NewService(repos.Tweets, publishers.Tweets, log),
+		Follows:       followservices.New(repos.Follows, publishers.Follows, log),
+		Timelines:     timelineservice.New(repos.Timelines, log),
+		Trends:        trendservice.New(repos.Trends, cfg.Trends, log),
+		Notifications: notificationservice.New(repos.Notifications, log),
+	}
+}
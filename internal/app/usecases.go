@@ -0,0 +1,171 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/createfollow"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/getfollow"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/processnewfollow"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/getnotifications"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifyfollow"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifytweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/readnotifications"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/gateway"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/stream"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/fallbacktimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/gettimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/orchestratefanout"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/populatecache"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/streamtimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/trimtimelines"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/updatetimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/gettrends"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/recordtweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/createtweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/gethashtagtweets"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/gettweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/getusertweets"
+	"github.com/juanmalvarez3/twit/pkg/config"
+)
+
+func (a *App) CreateTweet() createtweet.UseCase {
+	return createtweet.NewUseCase(a.Services.Tweets, a.Logger)
+}
+
+func (a *App) GetTweet() gettweet.UseCase {
+	return gettweet.NewUseCase(a.Services.Tweets, a.Logger)
+}
+
+func (a *App) GetHashtagTweets() gethashtagtweets.UseCase {
+	return gethashtagtweets.NewUseCase(a.Services.Tweets, a.Logger)
+}
+
+func (a *App) GetUserTweets() getusertweets.UseCase {
+	return getusertweets.NewUseCase(a.Services.Tweets, a.Logger)
+}
+
+func (a *App) CreateFollow() createfollow.UseCase {
+	return createfollow.NewUseCase(a.Services.Follows, a.Logger)
+}
+
+func (a *App) GetFollow() getfollow.UseCase {
+	return getfollow.NewUseCase(a.Services.Follows, a.Logger)
+}
+
+func (a *App) ProcessNewFollow() processnewfollow.UseCase {
+	return processnewfollow.NewUseCase(
+		a.Services.Follows,
+		a.Logger,
+		a.Services.Tweets,
+		a.Services.Timelines,
+		a.Config.Follow.BackfillCount,
+		time.Duration(a.Config.Follow.BackfillMaxAgeHours)*time.Hour,
+	)
+}
+
+func (a *App) GetTrends() gettrends.UseCase {
+	return gettrends.NewUseCase(a.Services.Trends, a.Logger)
+}
+
+func (a *App) RecordTweet() recordtweet.UseCase {
+	return recordtweet.NewUseCase(a.Services.Trends, a.Logger)
+}
+
+func (a *App) GetNotifications() getnotifications.UseCase {
+	return getnotifications.NewUseCase(a.Services.Notifications, a.Logger)
+}
+
+func (a *App) ReadNotifications() readnotifications.UseCase {
+	return readnotifications.NewUseCase(a.Services.Notifications, a.Logger)
+}
+
+func (a *App) NotifyTweet() notifytweet.UseCase {
+	return notifytweet.NewUseCase(a.Services.Notifications, a.Publishers.Conversations, a.Logger)
+}
+
+func (a *App) NotifyFollow() notifyfollow.UseCase {
+	return notifyfollow.NewUseCase(a.Services.Notifications, a.Logger)
+}
+
+func (a *App) GetTimeline() gettimeline.UseCase {
+	var tweetService gettimeline.TweetService
+	if a.Config.Timeline.StorageMode == dmntimeline.StorageIDs {
+		tweetService = a.Services.Tweets
+	}
+
+	var publisher gettimeline.Publisher
+	if a.Config.Cache.Enabled {
+		publisher = a.Publishers.PopulateCache
+	}
+
+	return gettimeline.New(a.Services.Timelines, tweetService, publisher, a.Publishers.RebuildTimeline, a.Logger)
+}
+
+func (a *App) StreamTimeline(hub *stream.Hub) streamtimeline.UseCase {
+	var tweetService streamtimeline.TweetService
+	if a.Config.Timeline.StorageMode == dmntimeline.StorageIDs {
+		tweetService = a.Services.Tweets
+	}
+
+	return streamtimeline.New(a.Services.Timelines, tweetService, hub, a.Config.Stream.ResumeLimit, a.Logger)
+}
+
+func (a *App) UpdateTimeline() *updatetimeline.UseCase {
+	return updatetimeline.New(a.Services.Timelines, a.Logger)
+}
+
+func (a *App) PopulateCache() populatecache.UseCase {
+	return populatecache.New(a.Services.Timelines, a.Logger)
+}
+
+func (a *App) RebuildTimeline() fallbacktimeline.UseCase {
+	return fallbacktimeline.New(
+		a.Services.Follows,
+		a.Services.Tweets,
+		a.Services.Timelines,
+		a.Config.Timeline.RebuildConcurrency,
+		a.Config.Timeline.RebuildPageSize,
+		a.Config.Timeline.RebuildSize,
+	)
+}
+
+func (a *App) TrimTimelines() trimtimelines.UseCase {
+	return trimtimelines.New(a.Services.Timelines, a.Config.Timeline.MaxEntries, a.Config.Timeline.TrimBatchSize, a.Logger)
+}
+
+func (a *App) OrchestrateFanout() orchestratefanout.UseCase {
+	return orchestratefanout.New(a.Services.Follows, a.Publishers.UpdateTimeline, a.Logger)
+}
+
+// TimelineHub crea el hub que reparte los eventos de timeline entre las
+// conexiones de streaming de la instancia.
+func (a *App) TimelineHub() *stream.Hub {
+	return stream.NewHub(a.Infra.Cache, a.Config.Stream.BufferSize, a.Logger)
+}
+
+// RealtimeGateway crea el gateway WebSocket. Sin WS_AUTH_SECRET el usuario
+// sale del parámetro user_id, así que sólo se acepta con APP_ENV=development.
+func (a *App) RealtimeGateway() (*gateway.Gateway, error) {
+	cfg := a.Config.Realtime
+
+	var authenticator gateway.Authenticator
+	switch {
+	case cfg.AuthSecret != "":
+		authenticator = gateway.NewTokenAuthenticator(cfg.AuthSecret)
+	case a.Config.Log.Environment == config.EnvironmentDevelopment:
+		a.Logger.Warn("WS_AUTH_SECRET vacío: el gateway WebSocket confía en el parámetro user_id")
+		authenticator = gateway.QueryAuthenticator{}
+	default:
+		return nil, errors.New("WS_AUTH_SECRET es obligatorio fuera de APP_ENV=development")
+	}
+
+	return gateway.New(a.Infra.Cache, authenticator, gateway.Options{
+		SendBuffer:       cfg.SendBuffer,
+		Policy:           gateway.Policy(cfg.SlowConsumerPolicy),
+		PingInterval:     time.Duration(cfg.PingSeconds) * time.Second,
+		MaxSubscriptions: cfg.MaxSubscriptions,
+		AllowedOrigins:   cfg.AllowedOrigins,
+	}, a.Logger), nil
+}
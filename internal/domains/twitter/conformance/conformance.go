@@ -0,0 +1,19 @@
+// Package conformance reúne las pruebas que tiene que pasar cada backend de
+// almacenamiento: las mismas suites corren contra DynamoDB, SQL y memoria
+// para que cambiar STORAGE_BACKEND no cambie el comportamiento.
+//
+// Cada prueba usa IDs propios, así que las suites pueden correr sobre tablas
+// compartidas que ya tienen datos.
+package conformance
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// unique devuelve un prefijo distinto en cada llamada, sin guiones para que
+// sirva como segmento de un ID de follow.
+func unique() string {
+	return strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+}
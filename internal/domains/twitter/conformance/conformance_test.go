@@ -0,0 +1,132 @@
+package conformance_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/memory"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/conformance"
+	followrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository"
+	followmemory "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository/memory"
+	followsqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository/sqlstore"
+	followservices "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/services"
+	notificationrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository"
+	notificationmemory "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository/memory"
+	notificationsqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository/sqlstore"
+	notificationservice "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/service"
+	timelinerepository "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository"
+	timelinememory "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository/memory"
+	timelinesqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository/sqlstore"
+	timelineservice "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/service"
+	tweetrepository "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository"
+	tweetmemory "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/memory"
+	tweetsqlstore "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/sqlstore"
+	tweetservices "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/services"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	pkgdynamodb "github.com/juanmalvarez3/twit/pkg/dynamodb"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+func TestMemory(t *testing.T) {
+	conformance.Tweets(t, func(*testing.T) tweetservices.Repository {
+		return tweetmemory.NewRepository()
+	})
+	conformance.Follows(t, func(*testing.T) followservices.Repository {
+		return followmemory.NewRepository()
+	})
+	conformance.Timelines(t, func(_ *testing.T, opts conformance.TimelineOptions) timelineservice.Repository {
+		return timelinememory.NewRepository(memory.NewCache(), opts.StoreContent, opts.MaxAge)
+	})
+	conformance.Notifications(t, func(*testing.T) notificationservice.Repository {
+		return notificationmemory.NewRepository(memory.NewCache())
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	conformance.Tweets(t, func(t *testing.T) tweetservices.Repository {
+		return tweetsqlstore.NewRepository(openSQLite(t))
+	})
+	conformance.Follows(t, func(t *testing.T) followservices.Repository {
+		return followsqlstore.NewRepository(openSQLite(t))
+	})
+	conformance.Timelines(t, func(t *testing.T, opts conformance.TimelineOptions) timelineservice.Repository {
+		return timelinesqlstore.NewRepository(openSQLite(t), memory.NewCache(), opts.StoreContent, opts.MaxAge)
+	})
+	conformance.Notifications(t, func(t *testing.T) notificationservice.Repository {
+		return notificationsqlstore.NewRepository(openSQLite(t), memory.NewCache())
+	})
+}
+
+// TestPostgres corre las suites contra la base de CONFORMANCE_POSTGRES_DSN.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("CONFORMANCE_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("CONFORMANCE_POSTGRES_DSN no definido")
+	}
+	ctx := context.Background()
+	db, err := sqldb.Open(ctx, sqldb.DriverPostgres, dsn, 4)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = sqldb.Migrate(ctx, db)
+	require.NoError(t, err)
+
+	conformance.Tweets(t, func(*testing.T) tweetservices.Repository {
+		return tweetsqlstore.NewRepository(db)
+	})
+	conformance.Follows(t, func(*testing.T) followservices.Repository {
+		return followsqlstore.NewRepository(db)
+	})
+	conformance.Timelines(t, func(_ *testing.T, opts conformance.TimelineOptions) timelineservice.Repository {
+		return timelinesqlstore.NewRepository(db, memory.NewCache(), opts.StoreContent, opts.MaxAge)
+	})
+	conformance.Notifications(t, func(*testing.T) notificationservice.Repository {
+		return notificationsqlstore.NewRepository(db, memory.NewCache())
+	})
+}
+
+// TestDynamoDB corre las suites contra las tablas de la configuración (por
+// ejemplo las de LocalStack) cuando CONFORMANCE_DYNAMODB=1.
+func TestDynamoDB(t *testing.T) {
+	if os.Getenv("CONFORMANCE_DYNAMODB") != "1" {
+		t.Skip("CONFORMANCE_DYNAMODB no definido")
+	}
+	ctx := context.Background()
+	cfg, err := config.New()
+	require.NoError(t, err)
+	client, err := pkgdynamodb.New(ctx, cfg)
+	require.NoError(t, err)
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+
+	conformance.Tweets(t, func(*testing.T) tweetservices.Repository {
+		return tweetrepository.NewTweetRepository(client, memory.NewCache(), cfg.DynamoDB.TweetsTable,
+			cfg.DynamoDB.HashtagsTable, 0, 0, nil, log)
+	})
+	conformance.Follows(t, func(*testing.T) followservices.Repository {
+		return followrepository.NewRepository(client, cfg.DynamoDB.FollowsTable, log)
+	})
+	conformance.Timelines(t, func(_ *testing.T, opts conformance.TimelineOptions) timelineservice.Repository {
+		return timelinerepository.NewTimelineRepository(client, memory.NewCache(), cfg.DynamoDB.TimelinesTable,
+			opts.StoreContent, opts.MaxAge, timelinerepository.CacheOptions{}, log)
+	})
+	conformance.Notifications(t, func(*testing.T) notificationservice.Repository {
+		return notificationrepository.NewRepository(client, memory.NewCache(), cfg.DynamoDB.NotificationsTable, log)
+	})
+}
+
+func openSQLite(t *testing.T) *sqldb.DB {
+	t.Helper()
+	ctx := context.Background()
+	db, err := sqldb.Open(ctx, sqldb.DriverSQLite, filepath.Join(t.TempDir(), "twit.db"), 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = sqldb.Migrate(ctx, db)
+	require.NoError(t, err)
+	return db
+}
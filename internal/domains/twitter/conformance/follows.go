@@ -0,0 +1,65 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	followservices "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/services"
+)
+
+// Follows corre la suite de follows sobre los repositorios que devuelve
+// newRepository.
+func Follows(t *testing.T, newRepository func(t *testing.T) followservices.Repository) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		follower, followed := "a"+unique(), "b"+unique()
+		follow := dmnfollow.Follow{
+			ID:         "flw-" + follower + "-" + followed,
+			FollowerID: follower,
+			FollowedID: followed,
+			CreatedAt:  "2026-10-19T10:00:00Z",
+		}
+		require.NoError(t, repo.Create(ctx, follow))
+
+		got, err := repo.Get(ctx, follow.ID)
+		require.NoError(t, err)
+		assert.Equal(t, follow, got)
+
+		_, err = repo.Get(ctx, "flw-"+follower+"-"+unique())
+		assert.ErrorIs(t, err, dmnfollow.ErrFollowNotFound)
+		_, err = repo.Get(ctx, "flw")
+		assert.ErrorIs(t, err, dmnfollow.ErrInvalidFollowID)
+	})
+
+	t.Run("FollowersAndFollowing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		prefix := unique()
+		ana, beto, caro := "ana"+prefix, "beto"+prefix, "caro"+prefix
+		for _, pair := range [][2]string{{ana, beto}, {caro, beto}, {beto, ana}, {ana, beto}} {
+			require.NoError(t, repo.Create(ctx, dmnfollow.Follow{
+				ID:         "flw-" + pair[0] + "-" + pair[1],
+				FollowerID: pair[0],
+				FollowedID: pair[1],
+				CreatedAt:  "2026-10-19T10:00:00Z",
+			}))
+		}
+
+		followers, err := repo.GetFollowers(ctx, beto)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{ana, caro}, followers)
+
+		following, err := repo.GetFollowing(ctx, ana)
+		require.NoError(t, err)
+		assert.Equal(t, []string{beto}, following)
+
+		following, err = repo.GetFollowing(ctx, "nadie"+prefix)
+		require.NoError(t, err)
+		assert.Empty(t, following)
+	})
+}
@@ -0,0 +1,98 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	notificationservice "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/service"
+)
+
+// Notifications corre la suite de notificaciones sobre los repositorios que
+// devuelve newRepository.
+func Notifications(t *testing.T, newRepository func(t *testing.T) notificationservice.Repository) {
+	notification := func(recipientID, id string) dmnnotification.Notification {
+		return dmnnotification.Notification{
+			ID:          id,
+			RecipientID: recipientID,
+			Type:        dmnnotification.TypeFollower,
+			ActorID:     "usr-actor",
+			CreatedAt:   "2026-10-19T10:00:00Z",
+		}
+	}
+
+	t.Run("CreateIsIdempotent", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		recipientID := "usr-" + unique()
+		require.NoError(t, repo.Create(ctx, notification(recipientID, "ntf-1")))
+		require.NoError(t, repo.Create(ctx, notification(recipientID, "ntf-1")))
+		require.NoError(t, repo.Create(ctx, notification(recipientID, "ntf-2")))
+
+		unread, err := repo.UnreadCount(ctx, recipientID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), unread)
+
+		notifications, _, err := repo.List(ctx, recipientID, 10, "")
+		require.NoError(t, err)
+		require.Len(t, notifications, 2)
+		assert.Equal(t, notification(recipientID, "ntf-2"), notifications[0])
+	})
+
+	t.Run("ListPaginatesByIDDescending", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		recipientID := "usr-" + unique()
+		for _, id := range []string{"ntf-1", "ntf-3", "ntf-2"} {
+			require.NoError(t, repo.Create(ctx, notification(recipientID, id)))
+		}
+
+		var (
+			ids    []string
+			cursor string
+		)
+		for {
+			page, next, err := repo.List(ctx, recipientID, 2, cursor)
+			require.NoError(t, err)
+			for _, n := range page {
+				ids = append(ids, n.ID)
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		assert.Equal(t, []string{"ntf-3", "ntf-2", "ntf-1"}, ids)
+
+		_, _, err := repo.List(ctx, "usr-"+unique(), 2, cursor)
+		assert.ErrorIs(t, err, dmnnotification.ErrInvalidCursor)
+	})
+
+	t.Run("MarkRead", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		recipientID := "usr-" + unique()
+		for _, id := range []string{"ntf-1", "ntf-2", "ntf-3"} {
+			require.NoError(t, repo.Create(ctx, notification(recipientID, id)))
+		}
+
+		marked, err := repo.MarkRead(ctx, recipientID, []string{"ntf-1", "ntf-3", "ntf-missing"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, marked)
+
+		marked, err = repo.MarkRead(ctx, recipientID, []string{"ntf-1"})
+		require.NoError(t, err)
+		assert.Zero(t, marked)
+
+		ids, err := repo.UnreadIDs(ctx, recipientID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ntf-2"}, ids)
+
+		unread, err := repo.UnreadCount(ctx, recipientID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), unread)
+	})
+}
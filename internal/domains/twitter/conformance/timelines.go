@@ -0,0 +1,153 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	timelineservice "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/service"
+)
+
+// TimelineOptions son las opciones de almacenamiento con las que la suite
+// pide cada repositorio.
+type TimelineOptions struct {
+	StoreContent bool
+	MaxAge       time.Duration
+}
+
+// Timelines corre la suite de timelines sobre los repositorios que devuelve
+// newRepository. Get no tiene que servir desde una caché.
+func Timelines(t *testing.T, newRepository func(t *testing.T, opts TimelineOptions) timelineservice.Repository) {
+	base := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	entry := func(prefix string, minutes int) dmntimeline.TimelineEntry {
+		return dmntimeline.TimelineEntry{
+			TweetID:   fmt.Sprintf("twt-%s-%02d", prefix, minutes),
+			AuthorID:  "usr-autor",
+			Content:   "contenido",
+			CreatedAt: base.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+
+	t.Run("UpdateAndGetNewestFirst", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t, TimelineOptions{StoreContent: true})
+		prefix := unique()
+		userID := "usr-" + prefix
+		for _, minutes := range []int{1, 3, 2} {
+			require.NoError(t, repo.Update(ctx, entry(prefix, minutes), userID))
+		}
+		require.NoError(t, repo.Update(ctx, entry(prefix, 3), userID))
+
+		timeline, err := repo.GetFromDB(ctx, userID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, userID, timeline.UserID)
+		assert.Equal(t, []string{entry(prefix, 3).TweetID, entry(prefix, 2).TweetID}, entryIDs(timeline))
+		assert.Equal(t, "contenido", timeline.Entries[0].Content)
+		assert.True(t, timeline.Entries[0].CreatedAt.Equal(entry(prefix, 3).CreatedAt))
+		assert.Nil(t, timeline.Entries[0].TTL)
+
+		cached, _, err := repo.Get(ctx, userID, 10)
+		require.NoError(t, err)
+		assert.Len(t, cached.Entries, 3)
+	})
+
+	t.Run("IDsOnlyAndMaxAge", func(t *testing.T) {
+		ctx := context.Background()
+		maxAge := 30 * 24 * time.Hour
+		repo := newRepository(t, TimelineOptions{StoreContent: false, MaxAge: maxAge})
+		prefix := unique()
+		userID := "usr-" + prefix
+		newEntry := entry(prefix, 0)
+		newEntry.CreatedAt = time.Now().UTC().Truncate(time.Second)
+		require.NoError(t, repo.Update(ctx, newEntry, userID))
+
+		timeline, err := repo.GetFromDB(ctx, userID, 10)
+		require.NoError(t, err)
+		require.Len(t, timeline.Entries, 1)
+		assert.Empty(t, timeline.Entries[0].Content)
+		require.NotNil(t, timeline.Entries[0].TTL)
+		assert.True(t, timeline.Entries[0].TTL.Equal(newEntry.CreatedAt.Add(maxAge)))
+	})
+
+	t.Run("BulkInsertOverwrites", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t, TimelineOptions{StoreContent: true})
+		prefix := unique()
+		userID := "usr-" + prefix
+		require.NoError(t, repo.Update(ctx, entry(prefix, 1), userID))
+
+		rebuilt := entry(prefix, 1)
+		rebuilt.Content = "reconstruido"
+		require.NoError(t, repo.BulkInsert(ctx, userID, []dmntimeline.TimelineEntry{rebuilt, entry(prefix, 2)}))
+		require.NoError(t, repo.BulkInsert(ctx, userID, nil))
+
+		timeline, err := repo.GetFromDB(ctx, userID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{entry(prefix, 2).TweetID, entry(prefix, 1).TweetID}, entryIDs(timeline))
+		assert.Equal(t, "reconstruido", timeline.Entries[1].Content)
+	})
+
+	t.Run("TrimKeepsNewest", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t, TimelineOptions{StoreContent: true})
+		prefix := unique()
+		userID := "usr-" + prefix
+		for minutes := 1; minutes <= 5; minutes++ {
+			require.NoError(t, repo.Update(ctx, entry(prefix, minutes), userID))
+		}
+
+		deleted, err := repo.Trim(ctx, userID, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+
+		deleted, err = repo.Trim(ctx, userID, 3)
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		timeline, err := repo.GetFromDB(ctx, userID, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{entry(prefix, 5).TweetID, entry(prefix, 4).TweetID, entry(prefix, 3).TweetID}, entryIDs(timeline))
+	})
+
+	t.Run("PendingTrims", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t, TimelineOptions{StoreContent: true})
+		userID := "usr-" + unique()
+		require.NoError(t, repo.MarkForTrim(ctx, userID))
+		require.NoError(t, repo.MarkForTrim(ctx, userID))
+
+		var popped []string
+		for {
+			users, err := repo.PendingTrims(ctx, 100)
+			require.NoError(t, err)
+			if len(users) == 0 {
+				break
+			}
+			popped = append(popped, users...)
+		}
+		assert.Equal(t, 1, count(popped, userID))
+	})
+}
+
+func entryIDs(timeline dmntimeline.Timeline) []string {
+	ids := make([]string, len(timeline.Entries))
+	for i, entry := range timeline.Entries {
+		ids[i] = entry.TweetID
+	}
+	return ids
+}
+
+func count(values []string, value string) int {
+	n := 0
+	for _, v := range values {
+		if v == value {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,169 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	tweetservices "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/services"
+)
+
+// Tweets corre la suite de tweets sobre los repositorios que devuelve
+// newRepository.
+func Tweets(t *testing.T, newRepository func(t *testing.T) tweetservices.Repository) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		prefix := unique()
+		tweet := dmntweet.Tweet{
+			ID:            "twt-" + prefix,
+			UserID:        "usr-" + prefix,
+			Content:       "hola #go @ana",
+			CreatedAt:     "2026-10-19T10:00:00Z",
+			Hashtags:      []string{"go"},
+			Mentions:      []string{"ana"},
+			ReplyToID:     "twt-otro",
+			ReplyToUserID: "usr-otro",
+		}
+		require.NoError(t, repo.Create(ctx, tweet))
+
+		got, err := repo.Get(ctx, tweet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tweet, got)
+
+		tweet.Content = "editado"
+		require.NoError(t, repo.Create(ctx, tweet))
+		got, err = repo.Get(ctx, tweet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "editado", got.Content)
+
+		_, err = repo.Get(ctx, "twt-"+unique())
+		assert.ErrorIs(t, err, dmntweet.ErrTweetNotFound)
+	})
+
+	t.Run("GetManyKeepsOrderAndSkipsMissing", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		prefix := unique()
+		for _, id := range []string{"1", "2", "3"} {
+			require.NoError(t, repo.Create(ctx, dmntweet.Tweet{
+				ID: "twt-" + prefix + "-" + id, UserID: "usr-" + prefix, Content: id, CreatedAt: "2026-10-19T10:00:00Z",
+			}))
+		}
+
+		tweets, err := repo.GetMany(ctx, []string{
+			"twt-" + prefix + "-3", "twt-" + prefix + "-missing", "twt-" + prefix + "-1", "twt-" + prefix + "-3",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"twt-" + prefix + "-3", "twt-" + prefix + "-1"}, tweetIDs(tweets))
+	})
+
+	t.Run("SearchPaginatesNewestFirst", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		prefix := unique()
+		userID := "usr-" + prefix
+		for _, tweet := range []dmntweet.Tweet{
+			{ID: "twt-" + prefix + "-1", UserID: userID, Content: "uno", CreatedAt: "2026-10-19T10:00:00Z"},
+			{ID: "twt-" + prefix + "-2", UserID: userID, Content: "dos", CreatedAt: "2026-10-19T11:00:00Z", ReplyToID: "twt-x"},
+			{ID: "twt-" + prefix + "-3", UserID: userID, Content: "tres", CreatedAt: "2026-10-19T12:00:00Z", RetweetOfID: "twt-y"},
+			{ID: "twt-" + prefix + "-4", UserID: userID, Content: "cuatro", CreatedAt: "2026-10-19T13:00:00Z"},
+			{ID: "twt-" + prefix + "-5", UserID: userID, Content: "cinco", CreatedAt: "2026-10-19T14:00:00Z"},
+			{ID: "twt-" + prefix + "-6", UserID: "usr-" + prefix + "-otro", Content: "otro", CreatedAt: "2026-10-19T15:00:00Z"},
+		} {
+			require.NoError(t, repo.Create(ctx, tweet))
+		}
+
+		all := collect(t, func(cursor string) ([]dmntweet.Tweet, string, error) {
+			return repo.Search(ctx, userID, 2, cursor, options.NewSearchFilters())
+		})
+		assert.Equal(t, []string{
+			"twt-" + prefix + "-5", "twt-" + prefix + "-4", "twt-" + prefix + "-3", "twt-" + prefix + "-2", "twt-" + prefix + "-1",
+		}, tweetIDs(all))
+
+		filtered := collect(t, func(cursor string) ([]dmntweet.Tweet, string, error) {
+			return repo.Search(ctx, userID, 10, cursor, options.NewSearchFilters().WithExcludeReplies(true).WithExcludeRetweets(true))
+		})
+		assert.Equal(t, []string{"twt-" + prefix + "-5", "twt-" + prefix + "-4", "twt-" + prefix + "-1"}, tweetIDs(filtered))
+	})
+
+	t.Run("SearchRejectsForeignCursor", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		prefix := unique()
+		for _, id := range []string{"1", "2"} {
+			require.NoError(t, repo.Create(ctx, dmntweet.Tweet{
+				ID: "twt-" + prefix + "-" + id, UserID: "usr-" + prefix, Content: id, CreatedAt: "2026-10-19T1" + id + ":00:00Z",
+			}))
+		}
+
+		_, cursor, err := repo.Search(ctx, "usr-"+prefix, 1, "", options.NewSearchFilters())
+		require.NoError(t, err)
+		require.NotEmpty(t, cursor)
+
+		_, _, err = repo.Search(ctx, "usr-"+prefix+"-otro", 1, cursor, options.NewSearchFilters())
+		assert.ErrorIs(t, err, dmntweet.ErrInvalidCursor)
+		_, _, err = repo.Search(ctx, "usr-"+prefix, 1, "no-es-un-cursor", options.NewSearchFilters())
+		assert.ErrorIs(t, err, dmntweet.ErrInvalidCursor)
+	})
+
+	t.Run("SearchByHashtag", func(t *testing.T) {
+		ctx := context.Background()
+		repo := newRepository(t)
+		prefix := unique()
+		tag := "tag" + prefix
+		for _, tweet := range []dmntweet.Tweet{
+			{ID: "twt-" + prefix + "-1", UserID: "usr-a", Content: "uno", CreatedAt: "2026-10-19T10:00:00Z", Hashtags: []string{tag}},
+			{ID: "twt-" + prefix + "-2", UserID: "usr-b", Content: "dos", CreatedAt: "2026-10-19T11:00:00Z", Hashtags: []string{"otro" + prefix}},
+			{ID: "twt-" + prefix + "-3", UserID: "usr-c", Content: "tres", CreatedAt: "2026-10-19T12:00:00Z", Hashtags: []string{"otro" + prefix, tag}},
+			{ID: "twt-" + prefix + "-4", UserID: "usr-a", Content: "cuatro", CreatedAt: "2026-10-19T13:00:00Z", Hashtags: []string{tag}},
+		} {
+			require.NoError(t, repo.Create(ctx, tweet))
+		}
+
+		tweets := collect(t, func(cursor string) ([]dmntweet.Tweet, string, error) {
+			return repo.SearchByHashtag(ctx, tag, 2, cursor)
+		})
+		assert.Equal(t, []string{"twt-" + prefix + "-4", "twt-" + prefix + "-3", "twt-" + prefix + "-1"}, tweetIDs(tweets))
+		assert.Equal(t, "tres", tweets[1].Content)
+
+		_, _, err := repo.SearchByHashtag(ctx, tag, 2, "no-es-un-cursor")
+		assert.ErrorIs(t, err, dmntweet.ErrInvalidCursor)
+	})
+}
+
+// collect recorre todas las páginas de una consulta. Falla si un backend
+// devuelve el mismo cursor dos veces.
+func collect(t *testing.T, page func(cursor string) ([]dmntweet.Tweet, string, error)) []dmntweet.Tweet {
+	t.Helper()
+
+	var (
+		all    []dmntweet.Tweet
+		cursor string
+		seen   = make(map[string]struct{})
+	)
+	for {
+		tweets, next, err := page(cursor)
+		require.NoError(t, err)
+		all = append(all, tweets...)
+		if next == "" {
+			return all
+		}
+		_, repeated := seen[next]
+		require.False(t, repeated, "cursor repetido")
+		seen[next] = struct{}{}
+		cursor = next
+	}
+}
+
+func tweetIDs(tweets []dmntweet.Tweet) []string {
+	ids := make([]string, len(tweets))
+	for i, tweet := range tweets {
+		ids[i] = tweet.ID
+	}
+	return ids
+}
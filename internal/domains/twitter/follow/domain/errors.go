@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+var (
+	ErrFollowNotFound      = errors.New("follow: follow not found")
+	ErrInvalidFollowID     = errors.New("follow: invalid follow id")
+	ErrSelfFollow          = errors.New("follow: user cannot follow themselves")
+	ErrFollowAlreadyExists = errors.New("follow: follow already exists")
+)
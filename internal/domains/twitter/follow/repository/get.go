@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository/daos"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
 	"go.uber.org/zap"
 	"strings"
 )
@@ -21,7 +22,9 @@ func (r *Repository) Get(ctx context.Context, followID string) (dmnfollow.Follow
 
 	parts := strings.Split(followID, "-")
 	if len(parts) < 3 {
-		err := fmt.Errorf("formato de ID de follow inválido: %s", followID)
+		err := apperrors.NewInvalidInputError(fmt.Sprintf("Formato de ID de follow inválido: %s", followID), dmnfollow.ErrInvalidFollowID).
+			WithCode(apperrors.CodeFollowIDInvalid).
+			WithDetails(map[string]any{"follow_id": followID})
 		r.logger.Error("Error al parsear ID de follow",
 			zap.String("follow_id", followID),
 			zap.Error(err),
@@ -60,7 +63,9 @@ func (r *Repository) Get(ctx context.Context, followID string) (dmnfollow.Follow
 			zap.String("follower_id", followerID),
 			zap.String("followed_id", followedID),
 		)
-		return dmnfollow.Follow{}, fmt.Errorf("follow with ID %s not found", followID)
+		return dmnfollow.Follow{}, apperrors.NewNotFoundError(fmt.Sprintf("El follow %s no existe", followID), dmnfollow.ErrFollowNotFound).
+			WithCode(apperrors.CodeFollowNotFound).
+			WithDetails(map[string]any{"follow_id": followID})
 	}
 
 	follow := &daos.FollowDAO{}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/repository/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -19,7 +20,7 @@ func TestRepository_Get_Success(t *testing.T) {
 	repo := NewRepository(mockDB, "follows", mockLogger)
 
 	item := map[string]types.AttributeValue{
-		"id":          &types.AttributeValueMemberS{Value: "f1"},
+		"id":          &types.AttributeValueMemberS{Value: "flw-u1-u2"},
 		"follower_id": &types.AttributeValueMemberS{Value: "u1"},
 		"followed_id": &types.AttributeValueMemberS{Value: "u2"},
 		"created_at":  &types.AttributeValueMemberS{Value: "2023-01-01T00:00:00Z"},
@@ -27,9 +28,9 @@ func TestRepository_Get_Success(t *testing.T) {
 	mockDB.On("GetItem", ctx, mock.Anything).Return(&dynamodb.GetItemOutput{Item: item}, nil)
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
 
-	follow, err := repo.Get(ctx, "f1")
+	follow, err := repo.Get(ctx, "flw-u1-u2")
 	assert.NoError(t, err)
-	assert.Equal(t, "f1", follow.ID)
+	assert.Equal(t, "flw-u1-u2", follow.ID)
 	assert.Equal(t, "u1", follow.FollowerID)
 	assert.Equal(t, "u2", follow.FollowedID)
 	mockDB.AssertExpectations(t)
@@ -46,8 +47,8 @@ func TestRepository_Get_NotFound(t *testing.T) {
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Return()
 
-	_, err := repo.Get(ctx, "notfound")
-	assert.Error(t, err)
+	_, err := repo.Get(ctx, "flw-u1-u3")
+	assert.ErrorIs(t, err, dmnfollow.ErrFollowNotFound)
 	mockDB.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
@@ -62,7 +63,7 @@ func TestRepository_Get_DBError(t *testing.T) {
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
 
-	_, err := repo.Get(ctx, "f1")
+	_, err := repo.Get(ctx, "flw-u1-u2")
 	assert.Error(t, err)
 	mockDB.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
@@ -80,8 +81,23 @@ func TestRepository_Get_UnmarshalError(t *testing.T) {
 	mockDB.On("GetItem", ctx, mock.Anything).Return(&dynamodb.GetItemOutput{Item: item}, nil)
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
 
-	_, err := repo.Get(ctx, "f1")
+	_, err := repo.Get(ctx, "flw-u1-u2")
 	assert.Nil(t, err)
 	mockDB.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
+
+func TestRepository_Get_InvalidID(t *testing.T) {
+	ctx := context.Background()
+	mockDB := &mocks.MockDBInterface{}
+	mockLogger := &mocks.MockLoggerInterface{}
+	repo := NewRepository(mockDB, "follows", mockLogger)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+
+	_, err := repo.Get(ctx, "f1")
+	assert.ErrorIs(t, err, dmnfollow.ErrInvalidFollowID)
+	mockDB.AssertNotCalled(t, "GetItem", mock.Anything, mock.Anything)
+	mockLogger.AssertExpectations(t)
+}
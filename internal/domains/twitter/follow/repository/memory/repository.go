@@ -0,0 +1,89 @@
+// Package memory guarda los follows en memoria, indexados igual que la tabla
+// de DynamoDB: por seguidor y por seguido.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+type Repository struct {
+	mu sync.RWMutex
+	// follows es seguidor -> seguido -> follow.
+	follows map[string]map[string]dmnfollow.Follow
+}
+
+func NewRepository() *Repository {
+	return &Repository{follows: make(map[string]map[string]dmnfollow.Follow)}
+}
+
+func (r *Repository) Create(_ context.Context, follow dmnfollow.Follow) error {
+	if follow.CreatedAt == "" {
+		follow.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	following, ok := r.follows[follow.FollowerID]
+	if !ok {
+		following = make(map[string]dmnfollow.Follow)
+		r.follows[follow.FollowerID] = following
+	}
+	following[follow.FollowedID] = follow
+	return nil
+}
+
+// Get parte followID igual que el repositorio de DynamoDB: el seguidor es el
+// segundo segmento y el seguido, el resto.
+func (r *Repository) Get(_ context.Context, followID string) (dmnfollow.Follow, error) {
+	parts := strings.Split(followID, "-")
+	if len(parts) < 3 {
+		return dmnfollow.Follow{}, apperrors.NewInvalidInputError(fmt.Sprintf("Formato de ID de follow inválido: %s", followID), dmnfollow.ErrInvalidFollowID).
+			WithCode(apperrors.CodeFollowIDInvalid).
+			WithDetails(map[string]any{"follow_id": followID})
+	}
+	followerID := parts[1]
+	followedID := strings.Join(parts[2:], "-")
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	follow, ok := r.follows[followerID][followedID]
+	if !ok {
+		return dmnfollow.Follow{}, apperrors.NewNotFoundError(fmt.Sprintf("El follow %s no existe", followID), dmnfollow.ErrFollowNotFound).
+			WithCode(apperrors.CodeFollowNotFound).
+			WithDetails(map[string]any{"follow_id": followID})
+	}
+	return follow, nil
+}
+
+func (r *Repository) GetFollowers(_ context.Context, followedID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	followers := make([]string, 0)
+	for followerID, following := range r.follows {
+		if _, ok := following[followedID]; ok {
+			followers = append(followers, followerID)
+		}
+	}
+	return followers, nil
+}
+
+func (r *Repository) GetFollowing(_ context.Context, followerID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	following := make([]string, 0, len(r.follows[followerID]))
+	for followedID := range r.follows[followerID] {
+		following = append(following, followedID)
+	}
+	return following, nil
+}
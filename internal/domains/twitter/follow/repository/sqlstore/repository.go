@@ -0,0 +1,89 @@
+// Package sqlstore guarda los follows en una base relacional: una fila por
+// par seguidor-seguido, indexada por los dos lados como la tabla de DynamoDB.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+type Repository struct {
+	db *sqldb.DB
+}
+
+func NewRepository(db *sqldb.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Create sobrescribe el follow si el par ya existía, como PutItem.
+func (r *Repository) Create(ctx context.Context, follow dmnfollow.Follow) error {
+	if follow.CreatedAt == "" {
+		follow.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	_, err := r.db.ExecContext(ctx, `INSERT INTO follows (follower_id, followed_id, id, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (follower_id, followed_id) DO UPDATE SET id = excluded.id, created_at = excluded.created_at`,
+		follow.FollowerID, follow.FollowedID, follow.ID, follow.CreatedAt)
+	return err
+}
+
+// Get parte followID igual que el repositorio de DynamoDB: el seguidor es el
+// segundo segmento y el seguido, el resto.
+func (r *Repository) Get(ctx context.Context, followID string) (dmnfollow.Follow, error) {
+	parts := strings.Split(followID, "-")
+	if len(parts) < 3 {
+		return dmnfollow.Follow{}, apperrors.NewInvalidInputError(fmt.Sprintf("Formato de ID de follow inválido: %s", followID), dmnfollow.ErrInvalidFollowID).
+			WithCode(apperrors.CodeFollowIDInvalid).
+			WithDetails(map[string]any{"follow_id": followID})
+	}
+	followerID := parts[1]
+	followedID := strings.Join(parts[2:], "-")
+
+	var follow dmnfollow.Follow
+	err := r.db.QueryRowContext(ctx, `SELECT id, follower_id, followed_id, created_at FROM follows
+		WHERE follower_id = ? AND followed_id = ?`, followerID, followedID).
+		Scan(&follow.ID, &follow.FollowerID, &follow.FollowedID, &follow.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dmnfollow.Follow{}, apperrors.NewNotFoundError(fmt.Sprintf("El follow %s no existe", followID), dmnfollow.ErrFollowNotFound).
+			WithCode(apperrors.CodeFollowNotFound).
+			WithDetails(map[string]any{"follow_id": followID})
+	}
+	if err != nil {
+		return dmnfollow.Follow{}, err
+	}
+	return follow, nil
+}
+
+func (r *Repository) GetFollowers(ctx context.Context, followedID string) ([]string, error) {
+	return r.ids(ctx, "SELECT follower_id FROM follows WHERE followed_id = ? ORDER BY follower_id", followedID)
+}
+
+func (r *Repository) GetFollowing(ctx context.Context, followerID string) ([]string, error) {
+	return r.ids(ctx, "SELECT followed_id FROM follows WHERE follower_id = ? ORDER BY followed_id", followerID)
+}
+
+func (r *Repository) ids(ctx context.Context, query, userID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -2,16 +2,22 @@ package createfollow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
-	"strings"
 	"time"
 )
 
 func (u UseCase) CreateFollow(ctx context.Context, follow dmnfollow.Follow) error {
+	ctx, span := tracing.Start(ctx, "createfollow.CreateFollow")
+	defer span.End()
+
 	if follow.FollowerID == follow.FollowedID {
-		return fmt.Errorf("un usuario no puede seguirse a sí mismo")
+		return apperrors.NewInvalidInputError("Un usuario no puede seguirse a sí mismo", dmnfollow.ErrSelfFollow).
+			WithCode(apperrors.CodeSelfFollow)
 	}
 
 	if follow.CreatedAt == "" {
@@ -20,14 +26,18 @@ func (u UseCase) CreateFollow(ctx context.Context, follow dmnfollow.Follow) erro
 
 	follow.ID = "flw-" + follow.FollowerID + "-" + follow.FollowedID
 	followFromDB, err := u.service.Get(ctx, follow.ID)
-	if err != nil && !strings.Contains(err.Error(), "not found") {
+	if err != nil && !errors.Is(err, dmnfollow.ErrFollowNotFound) {
 		u.logger.Error(fmt.Sprintf("Error al obtener follow con ID %s. Error: %s", follow.ID, err.Error()))
+		tracing.RecordError(span, err)
 		return err
 	}
 
 	if followFromDB.ID != "" {
-		err := fmt.Errorf("el follow ya existe")
+		err := apperrors.NewAlreadyExistsError("El usuario ya sigue a este usuario", dmnfollow.ErrFollowAlreadyExists).
+			WithCode(apperrors.CodeFollowAlreadyExists).
+			WithDetails(map[string]any{"follow_id": follow.ID})
 		u.logger.Error("Error al crear follow", zap.Error(err))
+		tracing.RecordError(span, err)
 		return err
 	}
 
@@ -38,6 +48,7 @@ func (u UseCase) CreateFollow(ctx context.Context, follow dmnfollow.Follow) erro
 	if err := u.service.Create(ctx, follow); err != nil {
 		u.logger.Error("Error al persistir follow",
 			zap.Error(err))
+		tracing.RecordError(span, err)
 		return err
 	}
 
@@ -16,6 +16,11 @@ func (m *Service) Create(ctx context.Context, follow dmnfollow.Follow) error {
 	return args.Error(0)
 }
 
+func (m *Service) Get(ctx context.Context, followID string) (dmnfollow.Follow, error) {
+	args := m.Called(ctx, followID)
+	return args.Get(0).(dmnfollow.Follow), args.Error(1)
+}
+
 type Publisher struct {
 	mock.Mock
 }
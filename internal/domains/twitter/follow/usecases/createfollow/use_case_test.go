@@ -3,6 +3,7 @@ package createfollow_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/createfollow"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/createfollow/mocks"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
 )
 
 func TestCreateFollow_Success(t *testing.T) {
@@ -26,6 +28,7 @@ func TestCreateFollow_Success(t *testing.T) {
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		expectedID := "flw-user-1-user-2"
 		_, timeErr := time.Parse(time.RFC3339, f.CreatedAt)
@@ -54,6 +57,7 @@ func TestCreateFollow_SameUserError(t *testing.T) {
 	err := uc.CreateFollow(context.Background(), follow)
 
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, dmnfollow.ErrSelfFollow)
 	assert.True(t, strings.Contains(err.Error(), "no puede seguirse a sí mismo"))
 	mockService.AssertNotCalled(t, "Create")
 }
@@ -71,6 +75,7 @@ func TestCreateFollow_ServiceError(t *testing.T) {
 	serviceErr := errors.New("service error")
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		return f.FollowerID == follow.FollowerID && f.FollowedID == follow.FollowedID
 	})).Return(serviceErr)
@@ -96,6 +101,7 @@ func TestCreateFollow_WithExistingCreatedAt(t *testing.T) {
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		return f.CreatedAt == existingTime
 	})).Return(nil)
@@ -120,6 +126,7 @@ func TestCreateFollow_WithInvalidCreatedAt(t *testing.T) {
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		return f.CreatedAt == invalidTime
 	})).Return(nil)
@@ -147,6 +154,7 @@ func TestCreateFollow_CanceledContext(t *testing.T) {
 	
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		return f.FollowerID == follow.FollowerID && f.FollowedID == follow.FollowedID
 	})).Return(contextErr)
@@ -170,6 +178,7 @@ func TestCreateFollow_EmptyFollowerID(t *testing.T) {
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		expectedID := "flw--user-2"
 		return f.ID == expectedID
@@ -193,6 +202,7 @@ func TestCreateFollow_EmptyFollowedID(t *testing.T) {
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, mock.Anything).Return(dmnfollow.Follow{}, dmnfollow.ErrFollowNotFound)
 	mockService.On("Create", mock.Anything, mock.MatchedBy(func(f dmnfollow.Follow) bool {
 		expectedID := "flw-user-1-"
 		return f.ID == expectedID
@@ -204,3 +214,45 @@ func TestCreateFollow_EmptyFollowedID(t *testing.T) {
 	mockService.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
+
+func TestCreateFollow_AlreadyExists(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockLogger := new(mocks.Logger)
+	uc := createfollow.NewUseCase(mockService, mockLogger)
+
+	follow := dmnfollow.Follow{
+		FollowerID: "user-1",
+		FollowedID: "user-2",
+	}
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, "flw-user-1-user-2").
+		Return(dmnfollow.Follow{ID: "flw-user-1-user-2"}, nil)
+
+	err := uc.CreateFollow(context.Background(), follow)
+
+	assert.ErrorIs(t, err, dmnfollow.ErrFollowAlreadyExists)
+	assert.Equal(t, http.StatusConflict, apperrors.GetStatusCode(err))
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateFollow_GetError(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockLogger := new(mocks.Logger)
+	uc := createfollow.NewUseCase(mockService, mockLogger)
+
+	follow := dmnfollow.Follow{
+		FollowerID: "user-1",
+		FollowedID: "user-2",
+	}
+
+	getErr := errors.New("db error")
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Get", mock.Anything, "flw-user-1-user-2").Return(dmnfollow.Follow{}, getErr)
+
+	err := uc.CreateFollow(context.Background(), follow)
+
+	assert.Equal(t, getErr, err)
+	mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -3,44 +3,103 @@ package processnewfollow
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	dmnoptions "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 )
 
+// backfillPageSize es la página de tweets pedida por consulta al completar el
+// timeline del seguidor.
+const backfillPageSize = 25
+
+// ProcessNewFollow copia al timeline del seguidor los tweets recientes del
+// usuario seguido: hasta backfillCount, sin pasar de backfillMaxAge de
+// antigüedad. Las entradas se escriben en lote y el timeline cacheado del
+// seguidor se descarta para que el nuevo autor aparezca en la próxima lectura.
 func (uc *UseCase) ProcessNewFollow(ctx context.Context, followEvent events.FollowCreatedEvent) error {
+	ctx, span := tracing.Start(ctx, "processnewfollow.ProcessNewFollow")
+	defer span.End()
+
+	followerID := followEvent.Follow.FollowerID
+	followedID := followEvent.Follow.FollowedID
+
 	uc.logger.Info("Procesando nuevo follow",
-		zap.String("follower_id", followEvent.Follow.FollowerID),
-		zap.String("followed_id", followEvent.Follow.FollowedID))
+		zap.String("follower_id", followerID),
+		zap.String("followed_id", followedID))
 
-	tweets, _, err := uc.tweetService.Search(ctx, dmnoptions.SearchOptions{
-		Filters:    dmnoptions.SearchFilters{UserID: &followEvent.Follow.FollowedID},
-		Pagination: dmnoptions.SearchPagination{Limit: 10, Offset: 0}})
+	if followerID == "" || followedID == "" {
+		uc.logger.Warn("Follow sin seguidor o seguido, se descarta",
+			zap.String("follow_id", followEvent.Follow.ID))
+		return nil
+	}
 
+	entries, err := uc.recentEntries(ctx, followedID)
 	if err != nil {
 		uc.logger.Error("Error al buscar tweets",
-			zap.String("followed_id", followEvent.Follow.FollowedID),
+			zap.String("followed_id", followedID),
 			zap.Error(err))
+		tracing.RecordError(span, err)
 		return fmt.Errorf("error al buscar tweets: %w", err)
 	}
 
-	if uc.updateTimelinePublisher != nil {
+	if len(entries) == 0 {
+		uc.logger.Debug("El usuario seguido no tiene tweets recientes para copiar",
+			zap.String("follower_id", followerID),
+			zap.String("followed_id", followedID))
+		return nil
+	}
+
+	if err := uc.timelineService.BulkInsert(ctx, followerID, entries); err != nil {
+		uc.logger.Error("Error al copiar tweets al timeline del seguidor",
+			zap.String("follower_id", followerID),
+			zap.String("followed_id", followedID),
+			zap.Error(err))
+		tracing.RecordError(span, err)
+		return fmt.Errorf("error al actualizar timeline: %w", err)
+	}
+
+	uc.logger.Debug("Timeline del seguidor completado con tweets del seguido",
+		zap.String("follower_id", followerID),
+		zap.String("followed_id", followedID),
+		zap.Int("entries_count", len(entries)))
+	return nil
+}
+
+// recentEntries pagina los tweets del usuario, del más nuevo al más viejo,
+// hasta juntar backfillCount o llegar a uno más viejo que backfillMaxAge.
+func (uc *UseCase) recentEntries(ctx context.Context, userID string) ([]dmntimeline.TimelineEntry, error) {
+	var cutoff time.Time
+	if uc.backfillMaxAge > 0 {
+		cutoff = time.Now().Add(-uc.backfillMaxAge)
+	}
+
+	entries := make([]dmntimeline.TimelineEntry, 0, uc.backfillCount)
+	cursor := ""
+	for len(entries) < uc.backfillCount {
+		tweets, next, err := uc.tweetService.Search(ctx, dmnoptions.SearchOptions{
+			Filters:    dmnoptions.SearchFilters{UserID: &userID},
+			Pagination: dmnoptions.SearchPagination{Limit: min(backfillPageSize, uc.backfillCount-len(entries)), Cursor: cursor},
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		for _, tweet := range tweets {
-			if err := uc.updateTimelinePublisher.Publish(ctx, tweet, followEvent.Follow.FollowedID); err != nil {
-				uc.logger.Error("Error al publicar mensaje de actualización de timeline",
-					zap.String("follower_id", followEvent.Follow.FollowerID),
-					zap.String("followed_id", followEvent.Follow.FollowedID),
-					zap.Error(err))
-				return fmt.Errorf("error al publicar mensaje: %w", err)
+			entry := dmntimeline.NewTimelineEntryFromTweet(tweet)
+			if !cutoff.IsZero() && entry.CreatedAt.Before(cutoff) {
+				return entries, nil
 			}
-			uc.logger.Debug("Mensaje enviado a cola de actualización de timeline",
-				zap.String("follower_id", followEvent.Follow.FollowerID),
-				zap.String("followed_id", followEvent.Follow.FollowedID))
+			entries = append(entries, entry)
 		}
-	} else {
-		uc.logger.Warn("No se ha configurado publicador para actualización de timeline",
-			zap.String("follower_id", followEvent.Follow.FollowerID),
-			zap.String("followed_id", followEvent.Follow.FollowedID))
+
+		if next == "" || len(tweets) == 0 {
+			break
+		}
+		cursor = next
 	}
-	return nil
+	return entries, nil
 }
@@ -2,6 +2,7 @@ package processnewfollow
 
 import (
 	"context"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
 	"go.uber.org/zap"
@@ -23,6 +24,6 @@ type Logger interface {
 	Error(msg string, fields ...zap.Field)
 }
 
-type UpdateTimelineSQSPublisher interface {
-	Publish(ctx context.Context, tweet dmntweet.Tweet, userID string) error
+type TimelineService interface {
+	BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error
 }
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
 	"github.com/stretchr/testify/mock"
@@ -51,11 +52,11 @@ func (m *Logger) Error(msg string, fields ...zap.Field) {
 	m.Called(msg, fields)
 }
 
-type UpdateTimelineSQSPublisher struct {
+type TimelineService struct {
 	mock.Mock
 }
 
-func (m *UpdateTimelineSQSPublisher) Publish(ctx context.Context, tweet dmntweet.Tweet, userID string) error {
-	args := m.Called(ctx, tweet, userID)
+func (m *TimelineService) BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error {
+	args := m.Called(ctx, userID, entries)
 	return args.Error(0)
 }
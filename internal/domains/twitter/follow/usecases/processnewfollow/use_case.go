@@ -3,26 +3,36 @@ package processnewfollow
 import (
 	"context"
 	"go.uber.org/zap"
+	"time"
 )
 
 type UseCase struct {
-	service                 Service
-	logger                  Logger
-	tweetService            TweetsService
-	updateTimelinePublisher UpdateTimelineSQSPublisher
+	service         Service
+	logger          Logger
+	tweetService    TweetsService
+	timelineService TimelineService
+	backfillCount   int
+	backfillMaxAge  time.Duration
 }
 
+// NewUseCase recibe la profundidad del backfill de un follow nuevo: cuántos
+// tweets del seguido se copian como máximo y hasta qué antigüedad. Con
+// backfillMaxAge en cero no se filtra por antigüedad.
 func NewUseCase(
 	service Service,
 	logger Logger,
 	tweetsService TweetsService,
-	updateTimelinePublisher UpdateTimelineSQSPublisher,
+	timelineService TimelineService,
+	backfillCount int,
+	backfillMaxAge time.Duration,
 ) UseCase {
 	return UseCase{
-		service:                 service,
-		logger:                  logger,
-		tweetService:            tweetsService,
-		updateTimelinePublisher: updateTimelinePublisher,
+		service:         service,
+		logger:          logger,
+		tweetService:    tweetsService,
+		timelineService: timelineService,
+		backfillCount:   backfillCount,
+		backfillMaxAge:  backfillMaxAge,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -11,41 +12,52 @@ import (
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/processnewfollow"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/usecases/processnewfollow/mocks"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
 )
 
-func TestProcessNewFollow_Success(t *testing.T) {
-	mockService := new(mocks.Service)
-	mockLogger := new(mocks.Logger)
-	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
-
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
+func newFollowEvent(followerID, followedID string) events.FollowCreatedEvent {
+	return events.FollowCreatedEvent{
 		Type: "FollowCreatedEventType",
 		Follow: events.Follow{
 			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
+			FollowerID: followerID,
+			FollowedID: followedID,
 			CreatedAt:  "2025-06-10T23:00:00Z",
 		},
 	}
+}
+
+func entryIDs(entries []dmntimeline.TimelineEntry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.TweetID
+	}
+	return ids
+}
+
+func TestProcessNewFollow_Success(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockLogger := new(mocks.Logger)
+	mockTweetService := new(mocks.TweetsService)
+	mockTimelineService := new(mocks.TimelineService)
+
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
 
 	tweets := []dmntweet.Tweet{
-		{
-			ID:        "twt-1",
-			UserID:    "user-2",
-			Content:   "Hello world!",
-			CreatedAt: "2025-06-10T22:00:00Z",
-		},
 		{
 			ID:        "twt-2",
 			UserID:    "user-2",
 			Content:   "Another tweet",
 			CreatedAt: "2025-06-10T22:30:00Z",
 		},
+		{
+			ID:        "twt-1",
+			UserID:    "user-2",
+			Content:   "Hello world!",
+			CreatedAt: "2025-06-10T22:00:00Z",
+		},
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
@@ -53,115 +65,116 @@ func TestProcessNewFollow_Success(t *testing.T) {
 
 	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts options.SearchOptions) bool {
 		return opts.Filters.UserID != nil && *opts.Filters.UserID == "user-2" &&
-			opts.Pagination.Limit == 10 && opts.Pagination.Offset == 0
+			opts.Pagination.Limit == 25 && opts.Pagination.Cursor == ""
 	})).Return(tweets, "", nil)
 
-	for _, tweet := range tweets {
-		mockPublisher.On("Publish", mock.Anything, tweet, "user-2").Return(nil).Once()
-	}
+	// Las entradas van al timeline del seguidor, no al del seguido.
+	mockTimelineService.On("BulkInsert", mock.Anything, "user-1", mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+		return assert.ObjectsAreEqual([]string{"twt-2", "twt-1"}, entryIDs(entries)) &&
+			entries[0].AuthorID == "user-2" && entries[0].Content == "Another tweet"
+	})).Return(nil)
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", "user-2"))
 
 	assert.NoError(t, err)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
-	mockLogger.AssertExpectations(t)
+	mockTimelineService.AssertExpectations(t)
 }
 
-func TestProcessNewFollow_SearchError(t *testing.T) {
+func TestProcessNewFollow_PaginatesUpToBackfillCount(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
-
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
+	mockTimelineService := new(mocks.TimelineService)
 
-	searchErr := errors.New("error searching tweets")
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 3, 0)
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return([]dmntweet.Tweet{}, "", searchErr)
+	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts options.SearchOptions) bool {
+		return opts.Pagination.Cursor == "" && opts.Pagination.Limit == 3
+	})).Return([]dmntweet.Tweet{
+		{ID: "twt-5", UserID: "user-2", CreatedAt: "2025-06-10T05:00:00Z"},
+		{ID: "twt-4", UserID: "user-2", CreatedAt: "2025-06-10T04:00:00Z"},
+	}, "cursor-1", nil).Once()
+	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts options.SearchOptions) bool {
+		return opts.Pagination.Cursor == "cursor-1" && opts.Pagination.Limit == 1
+	})).Return([]dmntweet.Tweet{
+		{ID: "twt-3", UserID: "user-2", CreatedAt: "2025-06-10T03:00:00Z"},
+	}, "cursor-2", nil).Once()
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	mockTimelineService.On("BulkInsert", mock.Anything, "user-1", mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+		return assert.ObjectsAreEqual([]string{"twt-5", "twt-4", "twt-3"}, entryIDs(entries))
+	})).Return(nil)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "error al buscar tweets")
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", "user-2"))
+
+	assert.NoError(t, err)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTweetService.AssertNumberOfCalls(t, "Search", 2)
+	mockTimelineService.AssertExpectations(t)
 }
 
-func TestProcessNewFollow_PublishError(t *testing.T) {
+func TestProcessNewFollow_StopsAtBackfillMaxAge(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 24*time.Hour)
 
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
+	now := time.Now().UTC()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 
-	tweets := []dmntweet.Tweet{
-		{
-			ID:        "twt-1",
-			UserID:    "user-2",
-			Content:   "Hello world!",
-			CreatedAt: "2025-06-10T22:00:00Z",
-		},
-	}
+	mockTweetService.On("Search", mock.Anything, mock.Anything).Return([]dmntweet.Tweet{
+		{ID: "twt-3", UserID: "user-2", CreatedAt: now.Add(-time.Hour).Format(time.RFC3339)},
+		{ID: "twt-2", UserID: "user-2", CreatedAt: now.Add(-48 * time.Hour).Format(time.RFC3339)},
+		{ID: "twt-1", UserID: "user-2", CreatedAt: now.Add(-72 * time.Hour).Format(time.RFC3339)},
+	}, "cursor-1", nil).Once()
+
+	mockTimelineService.On("BulkInsert", mock.Anything, "user-1", mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+		return assert.ObjectsAreEqual([]string{"twt-3"}, entryIDs(entries))
+	})).Return(nil)
+
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", "user-2"))
 
-	publishErr := errors.New("error publishing message")
+	assert.NoError(t, err)
+	mockTweetService.AssertNumberOfCalls(t, "Search", 1)
+	mockTimelineService.AssertExpectations(t)
+}
+
+func TestProcessNewFollow_SearchError(t *testing.T) {
+	mockService := new(mocks.Service)
+	mockLogger := new(mocks.Logger)
+	mockTweetService := new(mocks.TweetsService)
+	mockTimelineService := new(mocks.TimelineService)
+
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
+
+	searchErr := errors.New("error searching tweets")
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
-
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(tweets, "", nil)
 
-	mockPublisher.On("Publish", mock.Anything, tweets[0], "user-2").Return(publishErr)
+	mockTweetService.On("Search", mock.Anything, mock.Anything).Return([]dmntweet.Tweet{}, "", searchErr)
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", "user-2"))
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "error al publicar mensaje")
+	assert.Contains(t, err.Error(), "error al buscar tweets")
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
-func TestProcessNewFollow_NoPublisher(t *testing.T) {
+func TestProcessNewFollow_BulkInsertError(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, nil)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
 
 	tweets := []dmntweet.Tweet{
 		{
@@ -173,209 +186,95 @@ func TestProcessNewFollow_NoPublisher(t *testing.T) {
 	}
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
 
 	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(tweets, "", nil)
+	mockTimelineService.On("BulkInsert", mock.Anything, "user-1", mock.Anything).Return(errors.New("dynamodb error"))
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", "user-2"))
 
-	assert.NoError(t, err)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error al actualizar timeline")
 	mockTweetService.AssertExpectations(t)
-	mockLogger.AssertCalled(t, "Warn", mock.MatchedBy(func(msg string) bool {
-		return msg == "No se ha configurado publicador para actualización de timeline"
-	}), mock.Anything)
+	mockTimelineService.AssertExpectations(t)
 }
 
 func TestProcessNewFollow_NoTweets(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
-
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
+	mockTimelineService := new(mocks.TimelineService)
 
-	emptyTweets := []dmntweet.Tweet{}
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(emptyTweets, "", nil)
+	mockTweetService.On("Search", mock.Anything, mock.Anything).Return([]dmntweet.Tweet{}, "", nil)
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", "user-2"))
 
 	assert.NoError(t, err)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
 func TestProcessNewFollow_CanceledContext(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
-	
-	contextErr := context.Canceled
-	
-	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
-
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return([]dmntweet.Tweet{}, "", contextErr)
-
-	err := uc.ProcessNewFollow(ctx, followEvent)
-
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "error al buscar tweets")
-	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertNotCalled(t, "Publish")
-}
-
-func TestProcessNewFollow_MultiplePublishErrors(t *testing.T) {
-	mockService := new(mocks.Service)
-	mockLogger := new(mocks.Logger)
-	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
-
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
-
-	tweets := []dmntweet.Tweet{
-		{
-			ID:        "twt-1",
-			UserID:    "user-2",
-			Content:   "First tweet",
-			CreatedAt: "2025-06-10T22:00:00Z",
-		},
-		{
-			ID:        "twt-2",
-			UserID:    "user-2",
-			Content:   "Second tweet",
-			CreatedAt: "2025-06-10T22:30:00Z",
-		},
-	}
-
-	publishErr := errors.New("error publishing message")
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
-
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(tweets, "", nil)
 
-	mockPublisher.On("Publish", mock.Anything, tweets[0], "user-2").Return(publishErr)
+	mockTweetService.On("Search", mock.Anything, mock.Anything).Return([]dmntweet.Tweet{}, "", context.Canceled)
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(ctx, newFollowEvent("user-1", "user-2"))
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "error al publicar mensaje")
+	assert.Contains(t, err.Error(), "error al buscar tweets")
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
-	mockPublisher.AssertNumberOfCalls(t, "Publish", 1)
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
 func TestProcessNewFollow_EmptyFollowerID(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "",
-			FollowedID: "user-2",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
-
-	tweets := []dmntweet.Tweet{
-		{
-			ID:        "twt-1",
-			UserID:    "user-2",
-			Content:   "Hello world!",
-			CreatedAt: "2025-06-10T22:00:00Z",
-		},
-	}
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
-	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
-
-	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts options.SearchOptions) bool {
-		return opts.Filters.UserID != nil && *opts.Filters.UserID == "user-2"
-	})).Return(tweets, "", nil)
-
-	mockPublisher.On("Publish", mock.Anything, tweets[0], "user-2").Return(nil)
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("", "user-2"))
 
 	assert.NoError(t, err)
-	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
+	mockTweetService.AssertNotCalled(t, "Search")
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
 func TestProcessNewFollow_EmptyFollowedID(t *testing.T) {
 	mockService := new(mocks.Service)
 	mockLogger := new(mocks.Logger)
 	mockTweetService := new(mocks.TweetsService)
-	mockPublisher := new(mocks.UpdateTimelineSQSPublisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockPublisher)
-
-	followEvent := events.FollowCreatedEvent{
-		Type: "FollowCreatedEventType",
-		Follow: events.Follow{
-			ID:         "flw-123",
-			FollowerID: "user-1",
-			FollowedID: "",
-			CreatedAt:  "2025-06-10T23:00:00Z",
-		},
-	}
+	uc := processnewfollow.NewUseCase(mockService, mockLogger, mockTweetService, mockTimelineService, 50, 0)
 
 	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 
-	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts options.SearchOptions) bool {
-		return opts.Filters.UserID != nil && *opts.Filters.UserID == ""
-	})).Return([]dmntweet.Tweet{}, "", nil)
-
-	err := uc.ProcessNewFollow(context.Background(), followEvent)
+	err := uc.ProcessNewFollow(context.Background(), newFollowEvent("user-1", ""))
 
 	assert.NoError(t, err)
-	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTweetService.AssertNotCalled(t, "Search")
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	ErrRecipientRequired = errors.New("notification: recipient is required")
+	ErrInvalidCursor     = errors.New("notification: invalid pagination cursor")
+)
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+type Type string
+
+const (
+	TypeMention  Type = "mention"
+	TypeFollower Type = "follower"
+	TypeReply    Type = "reply"
+)
+
+type Notification struct {
+	ID          string `json:"id"`
+	RecipientID string `json:"recipientId"`
+	Type        Type   `json:"type"`
+	ActorID     string `json:"actorId"`
+	TweetID     string `json:"tweetId,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+	Read        bool   `json:"read"`
+}
+
+type NotificationsPage struct {
+	Notifications []Notification `json:"notifications"`
+	NextCursor    string         `json:"nextCursor,omitempty"`
+	UnreadCount   int64          `json:"unreadCount"`
+}
+
+type ReadResult struct {
+	Marked      int   `json:"marked"`
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+// New arma una notificación con un ID determinístico, de modo que un evento
+// reentregado por SQS no genere duplicados.
+func New(recipientID string, notificationType Type, actorID, tweetID string, createdAt time.Time) Notification {
+	return Notification{
+		ID:          NewID(recipientID, notificationType, actorID, tweetID, createdAt),
+		RecipientID: recipientID,
+		Type:        notificationType,
+		ActorID:     actorID,
+		TweetID:     tweetID,
+		CreatedAt:   createdAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// NewID antepone el timestamp en nanosegundos con ancho fijo para que el
+// orden lexicográfico de los IDs sea el cronológico.
+func NewID(recipientID string, notificationType Type, actorID, tweetID string, createdAt time.Time) string {
+	hash := sha256.Sum256([]byte(recipientID + "|" + string(notificationType) + "|" + actorID + "|" + tweetID))
+	return fmt.Sprintf("%019d-%s", createdAt.UnixNano(), hex.EncodeToString(hash[:8]))
+}
@@ -0,0 +1,23 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+)
+
+func TestNewID_DeterministicAndChronological(t *testing.T) {
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	first := dmnnotification.NewID("user-1", dmnnotification.TypeMention, "user-2", "twt-1", at)
+	again := dmnnotification.NewID("user-1", dmnnotification.TypeMention, "user-2", "twt-1", at)
+	other := dmnnotification.NewID("user-1", dmnnotification.TypeReply, "user-2", "twt-1", at)
+	later := dmnnotification.NewID("user-1", dmnnotification.TypeMention, "user-3", "twt-2", at.Add(time.Second))
+
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first, other)
+	assert.Less(t, first, later)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "strings"
+
+// Canales de Redis por los que el worker de notificaciones avisa de cada
+// notificación nueva al gateway de WebSocket.
+const (
+	streamChannelPrefix  = "notifications:stream:"
+	StreamChannelPattern = streamChannelPrefix + "*"
+)
+
+func StreamChannel(recipientID string) string {
+	return streamChannelPrefix + recipientID
+}
+
+// RecipientIDFromStreamChannel devuelve el destinatario de un canal de
+// StreamChannel.
+func RecipientIDFromStreamChannel(channel string) (string, bool) {
+	recipientID, ok := strings.CutPrefix(channel, streamChannelPrefix)
+	return recipientID, ok && recipientID != ""
+}
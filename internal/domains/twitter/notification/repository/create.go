@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository/daos"
+	"go.uber.org/zap"
+)
+
+// Create guarda la notificación e incrementa el contador de no leídas en una
+// misma transacción. Si la notificación ya existía no hace nada.
+func (r *Repository) Create(ctx context.Context, notification dmnnotification.Notification) error {
+	r.logger.Debug("Guardando notificación",
+		zap.String("notification_id", notification.ID),
+		zap.String("recipient_id", notification.RecipientID),
+		zap.String("type", string(notification.Type)),
+		zap.String("table", r.tableName),
+	)
+
+	item, err := attributevalue.MarshalMap(daos.ToNotificationDAOModel(notification))
+	if err != nil {
+		r.logger.Error("Error al serializar notificación para DynamoDB",
+			zap.String("notification_id", notification.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	_, err = r.dynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName:           aws.String(r.tableName),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(id)"),
+				},
+			},
+			r.counterUpdate(notification.RecipientID, 1),
+		},
+	})
+	if isConditionFailed(err) {
+		r.logger.Debug("La notificación ya existe",
+			zap.String("notification_id", notification.ID),
+			zap.String("recipient_id", notification.RecipientID),
+		)
+		return nil
+	}
+	if err != nil {
+		r.logger.Error("Error al guardar notificación en DynamoDB",
+			zap.String("notification_id", notification.ID),
+			zap.String("recipient_id", notification.RecipientID),
+			zap.String("table", r.tableName),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package daos
+
+import (
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+)
+
+type NotificationDAO struct {
+	RecipientID string `json:"recipient_id" dynamodbav:"recipient_id"`
+	ID          string `json:"id" dynamodbav:"id"`
+	Type        string `json:"type" dynamodbav:"type"`
+	ActorID     string `json:"actor_id" dynamodbav:"actor_id"`
+	TweetID     string `json:"tweet_id,omitempty" dynamodbav:"tweet_id,omitempty"`
+	CreatedAt   string `json:"created_at" dynamodbav:"created_at"`
+	Read        bool   `json:"read" dynamodbav:"read"`
+}
+
+func ToNotificationModel(dao NotificationDAO) dmnnotification.Notification {
+	return dmnnotification.Notification{
+		ID:          dao.ID,
+		RecipientID: dao.RecipientID,
+		Type:        dmnnotification.Type(dao.Type),
+		ActorID:     dao.ActorID,
+		TweetID:     dao.TweetID,
+		CreatedAt:   dao.CreatedAt,
+		Read:        dao.Read,
+	}
+}
+
+func ToNotificationDAOModel(notification dmnnotification.Notification) NotificationDAO {
+	return NotificationDAO{
+		RecipientID: notification.RecipientID,
+		ID:          notification.ID,
+		Type:        string(notification.Type),
+		ActorID:     notification.ActorID,
+		TweetID:     notification.TweetID,
+		CreatedAt:   notification.CreatedAt,
+		Read:        notification.Read,
+	}
+}
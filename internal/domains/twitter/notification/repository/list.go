@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/repository/daos"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type notificationCursor struct {
+	RecipientID string `json:"r"`
+	ID          string `json:"i"`
+}
+
+// List devuelve las notificaciones del usuario de la más nueva a la más vieja.
+func (r *Repository) List(ctx context.Context, recipientID string, limit int, cursor string) ([]dmnnotification.Notification, string, error) {
+	r.logger.Debug("Listando notificaciones",
+		zap.String("recipient_id", recipientID),
+		zap.Int("limit", limit),
+		zap.Bool("has_cursor", cursor != ""),
+	)
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("recipient_id = :recipient AND id > :counter"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":recipient": &types.AttributeValueMemberS{Value: recipientID},
+			":counter":   &types.AttributeValueMemberS{Value: counterID},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(int32(limit)),
+	}
+
+	if cursor != "" {
+		id, err := decodeCursor(cursor, recipientID)
+		if err != nil {
+			r.logger.Warn("Cursor de notificaciones inválido",
+				zap.String("recipient_id", recipientID),
+				zap.String("cursor", cursor),
+				zap.Error(err),
+			)
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmnnotification.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
+		}
+		input.ExclusiveStartKey = r.key(recipientID, id)
+	}
+
+	result, err := r.dynamoDBClient.Query(ctx, input)
+	if err != nil {
+		r.logger.Error("Error al consultar notificaciones en DynamoDB",
+			zap.String("recipient_id", recipientID),
+			zap.Error(err),
+		)
+		return nil, "", err
+	}
+
+	var items []daos.NotificationDAO
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &items); err != nil {
+		r.logger.Error("Error al deserializar notificaciones de DynamoDB",
+			zap.String("recipient_id", recipientID),
+			zap.Error(err),
+		)
+		return nil, "", err
+	}
+
+	notifications := make([]dmnnotification.Notification, len(items))
+	for i, item := range items {
+		notifications[i] = daos.ToNotificationModel(item)
+	}
+
+	var nextCursor string
+	if result.LastEvaluatedKey != nil && len(items) > 0 {
+		nextCursor, err = encodeCursor(notificationCursor{RecipientID: recipientID, ID: items[len(items)-1].ID})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return notifications, nextCursor, nil
+}
+
+// UnreadIDs devuelve los IDs de todas las notificaciones sin leer del usuario.
+func (r *Repository) UnreadIDs(ctx context.Context, recipientID string) ([]string, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.tableName),
+		KeyConditionExpression: aws.String("recipient_id = :recipient AND id > :counter"),
+		FilterExpression:       aws.String("#read = :false"),
+		ProjectionExpression:   aws.String("id"),
+		ExpressionAttributeNames: map[string]string{
+			"#read": "read",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":recipient": &types.AttributeValueMemberS{Value: recipientID},
+			":counter":   &types.AttributeValueMemberS{Value: counterID},
+			":false":     &types.AttributeValueMemberBOOL{Value: false},
+		},
+	}
+
+	var ids []string
+	paginator := dynamodb.NewQueryPaginator(r.dynamoDBClient, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.logger.Error("Error al consultar notificaciones sin leer en DynamoDB",
+				zap.String("recipient_id", recipientID),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		for _, item := range page.Items {
+			if id, ok := item["id"].(*types.AttributeValueMemberS); ok {
+				ids = append(ids, id.Value)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// UnreadCount devuelve el contador de notificaciones sin leer del usuario.
+func (r *Repository) UnreadCount(ctx context.Context, recipientID string) (int64, error) {
+	result, err := r.dynamoDBClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key:       r.key(recipientID, counterID),
+	})
+	if err != nil {
+		r.logger.Error("Error al obtener contador de notificaciones sin leer",
+			zap.String("recipient_id", recipientID),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	if result.Item == nil {
+		return 0, nil
+	}
+
+	var counter struct {
+		Unread int64 `dynamodbav:"unread"`
+	}
+	if err := attributevalue.UnmarshalMap(result.Item, &counter); err != nil {
+		return 0, err
+	}
+	return counter.Unread, nil
+}
+
+func encodeCursor(cursor notificationCursor) (string, error) {
+	bytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func decodeCursor(token, recipientID string) (string, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+
+	var cursor notificationCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return "", err
+	}
+	if cursor.RecipientID != recipientID || cursor.ID <= counterID {
+		return "", dmnnotification.ErrInvalidCursor
+	}
+	return cursor.ID, nil
+}
@@ -0,0 +1,174 @@
+// Package memory guarda las notificaciones en memoria con las reglas de la
+// tabla de DynamoDB: alta idempotente por ID, orden por ID descendente y
+// contador de no leídas.
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+// Publisher es el pub/sub por el que se avisan las notificaciones nuevas:
+// Redis o su reemplazo en memoria.
+type Publisher interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+type Repository struct {
+	publisher Publisher
+
+	mu sync.RWMutex
+	// notifications es destinatario -> ID -> notificación.
+	notifications map[string]map[string]dmnnotification.Notification
+	unread        map[string]int64
+}
+
+func NewRepository(publisher Publisher) *Repository {
+	return &Repository{
+		publisher:     publisher,
+		notifications: make(map[string]map[string]dmnnotification.Notification),
+		unread:        make(map[string]int64),
+	}
+}
+
+type notificationCursor struct {
+	RecipientID string `json:"r"`
+	ID          string `json:"i"`
+}
+
+// Create guarda la notificación y suma una no leída; si ya existía no hace
+// nada.
+func (r *Repository) Create(_ context.Context, notification dmnnotification.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inbox, ok := r.notifications[notification.RecipientID]
+	if !ok {
+		inbox = make(map[string]dmnnotification.Notification)
+		r.notifications[notification.RecipientID] = inbox
+	}
+	if _, exists := inbox[notification.ID]; exists {
+		return nil
+	}
+	inbox[notification.ID] = notification
+	if !notification.Read {
+		r.unread[notification.RecipientID]++
+	}
+	return nil
+}
+
+func (r *Repository) List(_ context.Context, recipientID string, limit int, cursor string) ([]dmnnotification.Notification, string, error) {
+	var after string
+	if cursor != "" {
+		id, err := decodeCursor(cursor, recipientID)
+		if err != nil {
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmnnotification.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
+		}
+		after = id
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notifications := make([]dmnnotification.Notification, 0, limit)
+	var nextCursor string
+	for _, id := range r.sortedIDs(recipientID) {
+		if after != "" && id >= after {
+			continue
+		}
+		if len(notifications) == limit {
+			last := notifications[len(notifications)-1]
+			nextCursor = encodeCursor(notificationCursor{RecipientID: recipientID, ID: last.ID})
+			break
+		}
+		notifications = append(notifications, r.notifications[recipientID][id])
+	}
+	return notifications, nextCursor, nil
+}
+
+func (r *Repository) UnreadIDs(_ context.Context, recipientID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ids []string
+	for _, id := range r.sortedIDs(recipientID) {
+		if !r.notifications[recipientID][id].Read {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *Repository) UnreadCount(_ context.Context, recipientID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.unread[recipientID], nil
+}
+
+// MarkRead ignora las que no existen o ya estaban leídas y devuelve cuántas
+// cambiaron de estado.
+func (r *Repository) MarkRead(_ context.Context, recipientID string, ids []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	marked := 0
+	for _, id := range ids {
+		notification, ok := r.notifications[recipientID][id]
+		if !ok || notification.Read {
+			continue
+		}
+		notification.Read = true
+		r.notifications[recipientID][id] = notification
+		r.unread[recipientID]--
+		marked++
+	}
+	return marked, nil
+}
+
+func (r *Repository) Publish(ctx context.Context, notification dmnnotification.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return r.publisher.Publish(ctx, dmnnotification.StreamChannel(notification.RecipientID), payload)
+}
+
+// sortedIDs devuelve los IDs del destinatario de la notificación más nueva a
+// la más vieja.
+func (r *Repository) sortedIDs(recipientID string) []string {
+	ids := make([]string, 0, len(r.notifications[recipientID]))
+	for id := range r.notifications[recipientID] {
+		ids = append(ids, id)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids
+}
+
+func encodeCursor(cursor notificationCursor) string {
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func decodeCursor(token, recipientID string) (string, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+
+	var cursor notificationCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return "", err
+	}
+	if cursor.RecipientID != recipientID || cursor.ID == "" {
+		return "", dmnnotification.ErrInvalidCursor
+	}
+	return cursor.ID, nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"go.uber.org/zap"
+)
+
+// Publish avisa por Redis pub/sub a las conexiones en tiempo real del
+// destinatario que tiene una notificación nueva.
+func (r *Repository) Publish(ctx context.Context, notification dmnnotification.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		r.logger.Error("Error serializando notificación para publicar",
+			zap.String("notification_id", notification.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+	return r.redisClient.Publish(ctx, dmnnotification.StreamChannel(notification.RecipientID), payload)
+}
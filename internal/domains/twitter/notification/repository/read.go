@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// MarkRead marca como leídas las notificaciones indicadas y descuenta cada
+// una del contador en la misma transacción. Las que no existen o ya estaban
+// leídas se ignoran. Devuelve cuántas cambiaron de estado.
+func (r *Repository) MarkRead(ctx context.Context, recipientID string, ids []string) (int, error) {
+	marked := 0
+	for _, id := range ids {
+		if id <= counterID {
+			continue
+		}
+
+		_, err := r.dynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: []types.TransactWriteItem{
+				{
+					Update: &types.Update{
+						TableName:           aws.String(r.tableName),
+						Key:                 r.key(recipientID, id),
+						UpdateExpression:    aws.String("SET #read = :true"),
+						ConditionExpression: aws.String("attribute_exists(id) AND #read = :false"),
+						ExpressionAttributeNames: map[string]string{
+							"#read": "read",
+						},
+						ExpressionAttributeValues: map[string]types.AttributeValue{
+							":true":  &types.AttributeValueMemberBOOL{Value: true},
+							":false": &types.AttributeValueMemberBOOL{Value: false},
+						},
+					},
+				},
+				r.counterUpdate(recipientID, -1),
+			},
+		})
+		if isConditionFailed(err) {
+			continue
+		}
+		if err != nil {
+			r.logger.Error("Error al marcar notificación como leída",
+				zap.String("recipient_id", recipientID),
+				zap.String("notification_id", id),
+				zap.Error(err),
+			)
+			return marked, err
+		}
+		marked++
+	}
+
+	r.logger.Debug("Notificaciones marcadas como leídas",
+		zap.String("recipient_id", recipientID),
+		zap.Int("requested", len(ids)),
+		zap.Int("marked", marked),
+	)
+	return marked, nil
+}
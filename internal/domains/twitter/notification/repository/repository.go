@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// counterID es el sort key del ítem que guarda la cantidad de notificaciones
+// sin leer de cada usuario. "#" ordena antes que cualquier dígito, así que
+// nunca aparece al listar (los IDs de notificación empiezan con el timestamp).
+const counterID = "#unread"
+
+type Repository struct {
+	dynamoDBClient DBInterface
+	redisClient    RedisInterface
+	tableName      string
+	logger         LoggerInterface
+}
+
+func NewRepository(
+	dynamoDBClient DBInterface,
+	redisClient RedisInterface,
+	tableName string,
+	logger LoggerInterface,
+) *Repository {
+	return &Repository{
+		dynamoDBClient: dynamoDBClient,
+		redisClient:    redisClient,
+		tableName:      tableName,
+		logger:         logger,
+	}
+}
+
+func (r *Repository) key(recipientID, id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"recipient_id": &types.AttributeValueMemberS{Value: recipientID},
+		"id":           &types.AttributeValueMemberS{Value: id},
+	}
+}
+
+// counterUpdate suma delta al contador de no leídas dentro de una transacción.
+func (r *Repository) counterUpdate(recipientID string, delta int) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:        aws.String(r.tableName),
+			Key:              r.key(recipientID, counterID),
+			UpdateExpression: aws.String("ADD unread :delta"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":delta": &types.AttributeValueMemberN{Value: strconv.Itoa(delta)},
+			},
+		},
+	}
+}
+
+// isConditionFailed indica si la transacción se canceló porque no se cumplió
+// alguna condición, es decir, si no había nada que cambiar.
+func isConditionFailed(err error) bool {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) {
+		return false
+	}
+	for _, reason := range canceled.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			return true
+		}
+	}
+	return false
+}
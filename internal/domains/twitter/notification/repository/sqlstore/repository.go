@@ -0,0 +1,172 @@
+// Package sqlstore guarda las notificaciones en una base relacional con las
+// reglas de la tabla de DynamoDB: alta idempotente por ID, orden por ID
+// descendente y contador de no leídas, que acá es un COUNT sobre un índice
+// parcial. El pub/sub de notificaciones nuevas sigue siendo el de la caché.
+package sqlstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+// Publisher es el pub/sub por el que se avisan las notificaciones nuevas:
+// Redis o su reemplazo en memoria.
+type Publisher interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+type Repository struct {
+	db        *sqldb.DB
+	publisher Publisher
+}
+
+func NewRepository(db *sqldb.DB, publisher Publisher) *Repository {
+	return &Repository{db: db, publisher: publisher}
+}
+
+type notificationCursor struct {
+	RecipientID string `json:"r"`
+	ID          string `json:"i"`
+}
+
+// Create guarda la notificación; si ya existía no hace nada.
+func (r *Repository) Create(ctx context.Context, notification dmnnotification.Notification) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO notifications
+		(recipient_id, id, type, actor_id, tweet_id, created_at, is_read)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (recipient_id, id) DO NOTHING`,
+		notification.RecipientID, notification.ID, string(notification.Type), notification.ActorID,
+		notification.TweetID, notification.CreatedAt, notification.Read)
+	return err
+}
+
+func (r *Repository) List(ctx context.Context, recipientID string, limit int, cursor string) ([]dmnnotification.Notification, string, error) {
+	query := `SELECT recipient_id, id, type, actor_id, tweet_id, created_at, is_read
+		FROM notifications WHERE recipient_id = ?`
+	args := []any{recipientID}
+	if cursor != "" {
+		after, err := decodeCursor(cursor, recipientID)
+		if err != nil {
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmnnotification.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
+		}
+		query += " AND id < ?"
+		args = append(args, after)
+	}
+	if limit <= 0 {
+		return []dmnnotification.Notification{}, "", nil
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	notifications := make([]dmnnotification.Notification, 0, limit)
+	for rows.Next() {
+		var (
+			notification     dmnnotification.Notification
+			notificationType string
+		)
+		if err := rows.Scan(&notification.RecipientID, &notification.ID, &notificationType, &notification.ActorID,
+			&notification.TweetID, &notification.CreatedAt, &notification.Read); err != nil {
+			return nil, "", err
+		}
+		notification.Type = dmnnotification.Type(notificationType)
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		last := notifications[len(notifications)-1]
+		nextCursor = encodeCursor(notificationCursor{RecipientID: recipientID, ID: last.ID})
+	}
+	return notifications, nextCursor, nil
+}
+
+func (r *Repository) UnreadIDs(ctx context.Context, recipientID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM notifications WHERE recipient_id = ? AND NOT is_read ORDER BY id DESC",
+		recipientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *Repository) UnreadCount(ctx context.Context, recipientID string) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications WHERE recipient_id = ? AND NOT is_read",
+		recipientID).Scan(&count)
+	return count, err
+}
+
+// MarkRead ignora las que no existen o ya estaban leídas y devuelve cuántas
+// cambiaron de estado.
+func (r *Repository) MarkRead(ctx context.Context, recipientID string, ids []string) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, recipientID)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	result, err := r.db.ExecContext(ctx, "UPDATE notifications SET is_read = TRUE WHERE recipient_id = ? AND NOT is_read AND id IN ("+
+		sqldb.Placeholders(len(ids))+")", args...)
+	if err != nil {
+		return 0, err
+	}
+	marked, err := result.RowsAffected()
+	return int(marked), err
+}
+
+func (r *Repository) Publish(ctx context.Context, notification dmnnotification.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return r.publisher.Publish(ctx, dmnnotification.StreamChannel(notification.RecipientID), payload)
+}
+
+func encodeCursor(cursor notificationCursor) string {
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func decodeCursor(token, recipientID string) (string, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", err
+	}
+
+	var cursor notificationCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return "", err
+	}
+	if cursor.RecipientID != recipientID || cursor.ID == "" {
+		return "", dmnnotification.ErrInvalidCursor
+	}
+	return cursor.ID, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"go.uber.org/zap"
+)
+
+func (s Service) Create(ctx context.Context, notification dmnnotification.Notification) error {
+	if err := s.repository.Create(ctx, notification); err != nil {
+		s.logger.Error("Error al crear notificación",
+			zap.String("notification_id", notification.ID),
+			zap.String("recipient_id", notification.RecipientID),
+			zap.String("type", string(notification.Type)),
+			zap.Error(err),
+			zap.String("action", actionCreate),
+		)
+		return err
+	}
+
+	// El aviso en tiempo real es best-effort: la notificación ya quedó
+	// guardada y el cliente la ve al listar.
+	if err := s.repository.Publish(ctx, notification); err != nil {
+		s.logger.Warn("Error publicando notificación en tiempo real",
+			zap.String("notification_id", notification.ID),
+			zap.String("recipient_id", notification.RecipientID),
+			zap.Error(err),
+			zap.String("action", actionCreate),
+		)
+	}
+
+	s.logger.Debug("Notificación creada",
+		zap.String("notification_id", notification.ID),
+		zap.String("recipient_id", notification.RecipientID),
+		zap.String("type", string(notification.Type)),
+		zap.String("action", actionCreate),
+	)
+	return nil
+}
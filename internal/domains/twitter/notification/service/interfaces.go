@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+)
+
+type Repository interface {
+	Create(ctx context.Context, notification dmnnotification.Notification) error
+	List(ctx context.Context, recipientID string, limit int, cursor string) ([]dmnnotification.Notification, string, error)
+	UnreadIDs(ctx context.Context, recipientID string) ([]string, error)
+	UnreadCount(ctx context.Context, recipientID string) (int64, error)
+	MarkRead(ctx context.Context, recipientID string, ids []string) (int, error)
+	Publish(ctx context.Context, notification dmnnotification.Notification) error
+}
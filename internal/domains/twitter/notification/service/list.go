@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"go.uber.org/zap"
+)
+
+func (s Service) List(ctx context.Context, recipientID string, limit int, cursor string) (dmnnotification.NotificationsPage, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	notifications, nextCursor, err := s.repository.List(ctx, recipientID, limit, cursor)
+	if err != nil {
+		s.logger.Error("Error al listar notificaciones",
+			zap.String("recipient_id", recipientID),
+			zap.Error(err),
+			zap.String("action", actionList),
+		)
+		return dmnnotification.NotificationsPage{}, err
+	}
+
+	unread, err := s.repository.UnreadCount(ctx, recipientID)
+	if err != nil {
+		s.logger.Error("Error al obtener notificaciones sin leer",
+			zap.String("recipient_id", recipientID),
+			zap.Error(err),
+			zap.String("action", actionList),
+		)
+		return dmnnotification.NotificationsPage{}, err
+	}
+
+	return dmnnotification.NotificationsPage{
+		Notifications: notifications,
+		NextCursor:    nextCursor,
+		UnreadCount:   unread,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"go.uber.org/zap"
+)
+
+// MarkRead marca como leídas las notificaciones indicadas o, si no se indica
+// ninguna, todas las del usuario.
+func (s Service) MarkRead(ctx context.Context, recipientID string, ids []string) (dmnnotification.ReadResult, error) {
+	if len(ids) == 0 {
+		unreadIDs, err := s.repository.UnreadIDs(ctx, recipientID)
+		if err != nil {
+			s.logger.Error("Error al obtener notificaciones sin leer",
+				zap.String("recipient_id", recipientID),
+				zap.Error(err),
+				zap.String("action", actionMarkRead),
+			)
+			return dmnnotification.ReadResult{}, err
+		}
+		ids = unreadIDs
+	}
+
+	marked, err := s.repository.MarkRead(ctx, recipientID, ids)
+	if err != nil {
+		s.logger.Error("Error al marcar notificaciones como leídas",
+			zap.String("recipient_id", recipientID),
+			zap.Int("marked", marked),
+			zap.Error(err),
+			zap.String("action", actionMarkRead),
+		)
+		return dmnnotification.ReadResult{}, err
+	}
+
+	unread, err := s.repository.UnreadCount(ctx, recipientID)
+	if err != nil {
+		s.logger.Error("Error al obtener notificaciones sin leer",
+			zap.String("recipient_id", recipientID),
+			zap.Error(err),
+			zap.String("action", actionMarkRead),
+		)
+		return dmnnotification.ReadResult{}, err
+	}
+
+	return dmnnotification.ReadResult{Marked: marked, UnreadCount: unread}, nil
+}
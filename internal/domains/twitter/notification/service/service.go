@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+const (
+	target = "notification_service"
+
+	actionCreate   = "create"
+	actionList     = "list"
+	actionMarkRead = "mark_read"
+
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Service struct {
+	repository Repository
+	logger     logger.LoggerInterface
+}
+
+func New(repository Repository, log logger.LoggerInterface) Service {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	return Service{
+		repository: repository,
+		logger:     log.Named(target),
+	}
+}
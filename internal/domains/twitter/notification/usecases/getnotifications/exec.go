@@ -0,0 +1,33 @@
+package getnotifications
+
+import (
+	"context"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+func (u UseCase) Exec(ctx context.Context, userID string, limit int, cursor string) (dmnnotification.NotificationsPage, error) {
+	ctx, span := tracing.Start(ctx, "getnotifications.Exec")
+	defer span.End()
+
+	if userID == "" {
+		err := apperrors.NewInvalidInputError("El parámetro user_id es obligatorio", dmnnotification.ErrRecipientRequired).
+			WithCode(apperrors.CodeNotificationUserRequired)
+		tracing.RecordError(span, err)
+		return dmnnotification.NotificationsPage{}, err
+	}
+
+	page, err := u.notificationService.List(ctx, userID, limit, cursor)
+	if err != nil {
+		u.logger.Error("Error al obtener notificaciones",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return dmnnotification.NotificationsPage{}, err
+	}
+
+	return page, nil
+}
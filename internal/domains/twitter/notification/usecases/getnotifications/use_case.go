@@ -0,0 +1,23 @@
+package getnotifications
+
+const (
+	target = "use_case_get_notifications"
+
+	getNotifications = "get_notifications"
+)
+
+type UseCase struct {
+	notificationService NotificationService
+	logger              Logger
+}
+
+func NewUseCase(notificationService NotificationService, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
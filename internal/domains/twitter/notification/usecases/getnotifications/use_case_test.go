@@ -0,0 +1,59 @@
+package getnotifications_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/getnotifications"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/getnotifications/mocks"
+)
+
+func TestExec_Success(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := getnotifications.NewUseCase(mockService, mockLogger)
+
+	expected := dmnnotification.NotificationsPage{
+		Notifications: []dmnnotification.Notification{{ID: "1760875200000000000-abc", RecipientID: "user-1", Type: dmnnotification.TypeMention}},
+		NextCursor:    "cursor",
+		UnreadCount:   3,
+	}
+	mockService.On("List", mock.Anything, "user-1", 20, "").Return(expected, nil)
+
+	page, err := uc.Exec(context.Background(), "user-1", 20, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, page)
+	mockService.AssertExpectations(t)
+}
+
+func TestExec_MissingUser(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := getnotifications.NewUseCase(mockService, mockLogger)
+
+	_, err := uc.Exec(context.Background(), "", 20, "")
+
+	assert.ErrorIs(t, err, dmnnotification.ErrRecipientRequired)
+	mockService.AssertNotCalled(t, "List")
+}
+
+func TestExec_ServiceError(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := getnotifications.NewUseCase(mockService, mockLogger)
+
+	serviceErr := errors.New("dynamo error")
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("List", mock.Anything, "user-1", 0, "").Return(dmnnotification.NotificationsPage{}, serviceErr)
+
+	_, err := uc.Exec(context.Background(), "user-1", 0, "")
+
+	assert.Equal(t, serviceErr, err)
+	mockService.AssertExpectations(t)
+}
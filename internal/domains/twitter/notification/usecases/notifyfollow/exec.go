@@ -0,0 +1,38 @@
+package notifyfollow
+
+import (
+	"context"
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Exec avisa al usuario seguido que tiene un nuevo seguidor.
+func (u UseCase) Exec(ctx context.Context, follow dmnfollow.Follow) error {
+	ctx, span := tracing.Start(ctx, "notifyfollow.Exec")
+	defer span.End()
+
+	if follow.FollowerID == follow.FollowedID {
+		return nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, follow.CreatedAt)
+	if err != nil {
+		createdAt = time.Now().UTC()
+	}
+
+	notification := dmnnotification.New(follow.FollowedID, dmnnotification.TypeFollower, follow.FollowerID, "", createdAt)
+	if err := u.notificationService.Create(ctx, notification); err != nil {
+		u.logger.Error("Error al crear notificación de nuevo seguidor",
+			zap.String("follower_id", follow.FollowerID),
+			zap.String("followed_id", follow.FollowedID),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package notifyfollow
+
+const (
+	target = "use_case_notify_follow"
+
+	notifyFollow = "notify_follow"
+)
+
+type UseCase struct {
+	notificationService NotificationService
+	logger              Logger
+}
+
+func NewUseCase(notificationService NotificationService, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
@@ -0,0 +1,47 @@
+package notifyfollow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifyfollow"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifyfollow/mocks"
+)
+
+func TestExec_Success(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := notifyfollow.NewUseCase(mockService, mockLogger)
+
+	follow := dmnfollow.Follow{ID: "flw-user-1-user-2", FollowerID: "user-1", FollowedID: "user-2", CreatedAt: "2026-10-19T12:00:00Z"}
+
+	mockService.On("Create", mock.Anything, mock.MatchedBy(func(n dmnnotification.Notification) bool {
+		return n.RecipientID == "user-2" && n.ActorID == "user-1" && n.Type == dmnnotification.TypeFollower && n.TweetID == ""
+	})).Return(nil)
+
+	err := uc.Exec(context.Background(), follow)
+
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestExec_ServiceError(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := notifyfollow.NewUseCase(mockService, mockLogger)
+
+	serviceErr := errors.New("dynamo error")
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Create", mock.Anything, mock.Anything).Return(serviceErr)
+
+	err := uc.Exec(context.Background(), dmnfollow.Follow{FollowerID: "user-1", FollowedID: "user-2"})
+
+	assert.Equal(t, serviceErr, err)
+	mockService.AssertExpectations(t)
+}
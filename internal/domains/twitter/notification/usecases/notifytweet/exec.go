@@ -0,0 +1,79 @@
+package notifytweet
+
+import (
+	"context"
+	"errors"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Exec notifica al autor del tweet respondido y a los usuarios mencionados.
+// Nadie recibe notificaciones por sus propios tweets y quien es respondido y
+// mencionado a la vez recibe sólo la de respuesta. Las respuestas se publican
+// además en la conversación del tweet respondido.
+func (u UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet) error {
+	ctx, span := tracing.Start(ctx, "notifytweet.Exec")
+	defer span.End()
+
+	createdAt, err := time.Parse(time.RFC3339, tweet.CreatedAt)
+	if err != nil {
+		createdAt = time.Now().UTC()
+	}
+
+	if tweet.ReplyToID != "" {
+		if err := u.conversationPublisher.PublishReply(ctx, tweet); err != nil {
+			u.logger.Warn("Error publicando respuesta en la conversación",
+				zap.String("tweet_id", tweet.ID),
+				zap.String("reply_to_id", tweet.ReplyToID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	var notifications []dmnnotification.Notification
+	notified := map[string]struct{}{tweet.UserID: {}}
+
+	if tweet.ReplyToUserID != "" {
+		if _, ok := notified[tweet.ReplyToUserID]; !ok {
+			notified[tweet.ReplyToUserID] = struct{}{}
+			notifications = append(notifications,
+				dmnnotification.New(tweet.ReplyToUserID, dmnnotification.TypeReply, tweet.UserID, tweet.ID, createdAt))
+		}
+	}
+
+	for _, mention := range tweet.Mentions {
+		if _, ok := notified[mention]; ok {
+			continue
+		}
+		notified[mention] = struct{}{}
+		notifications = append(notifications,
+			dmnnotification.New(mention, dmnnotification.TypeMention, tweet.UserID, tweet.ID, createdAt))
+	}
+
+	var errs []error
+	for _, notification := range notifications {
+		if err := u.notificationService.Create(ctx, notification); err != nil {
+			u.logger.Error("Error al crear notificación de tweet",
+				zap.String("tweet_id", tweet.ID),
+				zap.String("recipient_id", notification.RecipientID),
+				zap.String("type", string(notification.Type)),
+				zap.Error(err),
+			)
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	u.logger.Debug("Notificaciones de tweet creadas",
+		zap.String("tweet_id", tweet.ID),
+		zap.Int("count", len(notifications)),
+	)
+	return nil
+}
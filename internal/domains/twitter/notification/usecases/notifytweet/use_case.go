@@ -0,0 +1,25 @@
+package notifytweet
+
+const (
+	target = "use_case_notify_tweet"
+
+	notifyTweet = "notify_tweet"
+)
+
+type UseCase struct {
+	notificationService   NotificationService
+	conversationPublisher ConversationPublisher
+	logger                Logger
+}
+
+func NewUseCase(notificationService NotificationService, conversationPublisher ConversationPublisher, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		notificationService:   notificationService,
+		conversationPublisher: conversationPublisher,
+		logger:                logger,
+	}
+}
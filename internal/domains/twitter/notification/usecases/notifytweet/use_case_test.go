@@ -0,0 +1,103 @@
+package notifytweet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifytweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/notifytweet/mocks"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+func byRecipient(recipientID string, notificationType dmnnotification.Type) interface{} {
+	return mock.MatchedBy(func(n dmnnotification.Notification) bool {
+		return n.RecipientID == recipientID && n.Type == notificationType && n.ActorID == "user-1" && n.TweetID == "twt-1"
+	})
+}
+
+func TestExec_ReplyAndMentions(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockPublisher := new(mocks.ConversationPublisher)
+	mockLogger := new(mocks.Logger)
+	uc := notifytweet.NewUseCase(mockService, mockPublisher, mockLogger)
+
+	tweet := dmntweet.Tweet{
+		ID:            "twt-1",
+		UserID:        "user-1",
+		CreatedAt:     "2026-10-19T12:00:00Z",
+		Mentions:      []string{"user-1", "user-2", "user-3"},
+		ReplyToID:     "twt-0",
+		ReplyToUserID: "user-2",
+	}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockPublisher.On("PublishReply", mock.Anything, tweet).Return(nil).Once()
+	mockService.On("Create", mock.Anything, byRecipient("user-2", dmnnotification.TypeReply)).Return(nil).Once()
+	mockService.On("Create", mock.Anything, byRecipient("user-3", dmnnotification.TypeMention)).Return(nil).Once()
+
+	err := uc.Exec(context.Background(), tweet)
+
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+	mockService.AssertNumberOfCalls(t, "Create", 2)
+	mockPublisher.AssertExpectations(t)
+}
+
+func TestExec_ReplyPublishErrorIsNotFatal(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockPublisher := new(mocks.ConversationPublisher)
+	mockLogger := new(mocks.Logger)
+	uc := notifytweet.NewUseCase(mockService, mockPublisher, mockLogger)
+
+	tweet := dmntweet.Tweet{ID: "twt-1", UserID: "user-1", ReplyToID: "twt-0", ReplyToUserID: "user-2"}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Once()
+	mockPublisher.On("PublishReply", mock.Anything, tweet).Return(errors.New("redis error")).Once()
+	mockService.On("Create", mock.Anything, byRecipient("user-2", dmnnotification.TypeReply)).Return(nil).Once()
+
+	err := uc.Exec(context.Background(), tweet)
+
+	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+	mockLogger.AssertExpectations(t)
+}
+
+func TestExec_NoRecipients(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockPublisher := new(mocks.ConversationPublisher)
+	mockLogger := new(mocks.Logger)
+	uc := notifytweet.NewUseCase(mockService, mockPublisher, mockLogger)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	err := uc.Exec(context.Background(), dmntweet.Tweet{ID: "twt-1", UserID: "user-1", Content: "Hola"})
+
+	assert.NoError(t, err)
+	mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockPublisher.AssertNotCalled(t, "PublishReply", mock.Anything, mock.Anything)
+}
+
+func TestExec_ContinuesAfterError(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockPublisher := new(mocks.ConversationPublisher)
+	mockLogger := new(mocks.Logger)
+	uc := notifytweet.NewUseCase(mockService, mockPublisher, mockLogger)
+
+	tweet := dmntweet.Tweet{ID: "twt-1", UserID: "user-1", Mentions: []string{"user-2", "user-3"}}
+	serviceErr := errors.New("dynamo error")
+
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("Create", mock.Anything, byRecipient("user-2", dmnnotification.TypeMention)).Return(serviceErr).Once()
+	mockService.On("Create", mock.Anything, byRecipient("user-3", dmnnotification.TypeMention)).Return(nil).Once()
+
+	err := uc.Exec(context.Background(), tweet)
+
+	assert.ErrorIs(t, err, serviceErr)
+	mockService.AssertExpectations(t)
+}
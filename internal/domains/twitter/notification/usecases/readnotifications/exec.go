@@ -0,0 +1,39 @@
+package readnotifications
+
+import (
+	"context"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// Exec marca como leídas las notificaciones indicadas; sin IDs marca todas.
+func (u UseCase) Exec(ctx context.Context, userID string, ids []string) (dmnnotification.ReadResult, error) {
+	ctx, span := tracing.Start(ctx, "readnotifications.Exec")
+	defer span.End()
+
+	if userID == "" {
+		err := apperrors.NewInvalidInputError("El parámetro user_id es obligatorio", dmnnotification.ErrRecipientRequired).
+			WithCode(apperrors.CodeNotificationUserRequired)
+		tracing.RecordError(span, err)
+		return dmnnotification.ReadResult{}, err
+	}
+
+	result, err := u.notificationService.MarkRead(ctx, userID, ids)
+	if err != nil {
+		u.logger.Error("Error al marcar notificaciones como leídas",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return dmnnotification.ReadResult{}, err
+	}
+
+	u.logger.Debug("Notificaciones marcadas como leídas",
+		zap.String("user_id", userID),
+		zap.Int("marked", result.Marked),
+		zap.Int64("unread", result.UnreadCount),
+	)
+	return result, nil
+}
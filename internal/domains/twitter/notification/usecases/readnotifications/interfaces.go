@@ -0,0 +1,18 @@
+package readnotifications
+
+import (
+	"context"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"go.uber.org/zap"
+)
+
+type NotificationService interface {
+	MarkRead(ctx context.Context, recipientID string, ids []string) (dmnnotification.ReadResult, error)
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type NotificationService struct {
+	mock.Mock
+}
+
+func (m *NotificationService) MarkRead(ctx context.Context, recipientID string, ids []string) (dmnnotification.ReadResult, error) {
+	args := m.Called(ctx, recipientID, ids)
+	return args.Get(0).(dmnnotification.ReadResult), args.Error(1)
+}
+
+type Logger struct {
+	mock.Mock
+}
+
+func (m *Logger) Debug(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Info(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Warn(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Error(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
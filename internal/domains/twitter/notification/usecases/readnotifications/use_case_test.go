@@ -0,0 +1,58 @@
+package readnotifications_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/readnotifications"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/notification/usecases/readnotifications/mocks"
+)
+
+func TestExec_Success(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := readnotifications.NewUseCase(mockService, mockLogger)
+
+	ids := []string{"1760875200000000000-abc"}
+	expected := dmnnotification.ReadResult{Marked: 1, UnreadCount: 2}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockService.On("MarkRead", mock.Anything, "user-1", ids).Return(expected, nil)
+
+	result, err := uc.Exec(context.Background(), "user-1", ids)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockService.AssertExpectations(t)
+}
+
+func TestExec_MissingUser(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := readnotifications.NewUseCase(mockService, mockLogger)
+
+	_, err := uc.Exec(context.Background(), "", nil)
+
+	assert.ErrorIs(t, err, dmnnotification.ErrRecipientRequired)
+	mockService.AssertNotCalled(t, "MarkRead")
+}
+
+func TestExec_ServiceError(t *testing.T) {
+	mockService := new(mocks.NotificationService)
+	mockLogger := new(mocks.Logger)
+	uc := readnotifications.NewUseCase(mockService, mockLogger)
+
+	serviceErr := errors.New("dynamo error")
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockService.On("MarkRead", mock.Anything, "user-1", []string(nil)).Return(dmnnotification.ReadResult{}, serviceErr)
+
+	_, err := uc.Exec(context.Background(), "user-1", nil)
+
+	assert.Equal(t, serviceErr, err)
+	mockService.AssertExpectations(t)
+}
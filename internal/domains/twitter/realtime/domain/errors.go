@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrInvalidMessage       = errors.New("invalid message")
+	ErrUnknownTopic         = errors.New("unknown topic")
+	ErrConversationRequired = errors.New("conversation id required")
+	ErrTooManySubscriptions = errors.New("too many subscriptions")
+)
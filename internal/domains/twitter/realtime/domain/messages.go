@@ -0,0 +1,31 @@
+package domain
+
+import "encoding/json"
+
+// Tipos de mensaje del protocolo del gateway. El cliente envía subscribe,
+// unsubscribe y ping; el servidor responde con el resto.
+const (
+	MessageSubscribe    = "subscribe"
+	MessageUnsubscribe  = "unsubscribe"
+	MessagePing         = "ping"
+	MessageSubscribed   = "subscribed"
+	MessageUnsubscribed = "unsubscribed"
+	MessagePong         = "pong"
+	MessageEvent        = "event"
+	MessageError        = "error"
+)
+
+type ClientMessage struct {
+	Type  string `json:"type"`
+	Topic Topic  `json:"topic,omitempty"`
+	ID    string `json:"id,omitempty"`
+}
+
+type ServerMessage struct {
+	Type    string          `json:"type"`
+	Topic   Topic           `json:"topic,omitempty"`
+	ID      string          `json:"id,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Code    string          `json:"code,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
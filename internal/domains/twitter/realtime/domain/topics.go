@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"strings"
+
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+)
+
+type Topic string
+
+const (
+	TopicTimeline      Topic = "timeline"
+	TopicNotifications Topic = "notifications"
+	TopicConversation  Topic = "conversation"
+)
+
+var Topics = []Topic{TopicTimeline, TopicNotifications, TopicConversation}
+
+// Las respuestas a un tweet se publican en el canal de conversación del tweet
+// respondido.
+const (
+	conversationChannelPrefix  = "conversation:stream:"
+	ConversationChannelPattern = conversationChannelPrefix + "*"
+)
+
+// Patterns son los patrones de Redis que cubren todos los tópicos.
+var Patterns = []string{
+	dmntimeline.StreamChannelPattern,
+	dmnnotification.StreamChannelPattern,
+	ConversationChannelPattern,
+}
+
+func ConversationChannel(tweetID string) string {
+	return conversationChannelPrefix + tweetID
+}
+
+// Channel devuelve el canal de Redis de una suscripción. El timeline y las
+// notificaciones son siempre los del usuario autenticado; id sólo aplica a las
+// conversaciones.
+func Channel(topic Topic, userID, id string) (string, error) {
+	switch topic {
+	case TopicTimeline:
+		return dmntimeline.StreamChannel(userID), nil
+	case TopicNotifications:
+		return dmnnotification.StreamChannel(userID), nil
+	case TopicConversation:
+		if id == "" {
+			return "", ErrConversationRequired
+		}
+		return ConversationChannel(id), nil
+	default:
+		return "", ErrUnknownTopic
+	}
+}
+
+// ParseChannel hace lo inverso de Channel. Para las conversaciones devuelve el
+// id del tweet.
+func ParseChannel(channel string) (Topic, string, bool) {
+	if _, ok := dmntimeline.UserIDFromStreamChannel(channel); ok {
+		return TopicTimeline, "", true
+	}
+	if _, ok := dmnnotification.RecipientIDFromStreamChannel(channel); ok {
+		return TopicNotifications, "", true
+	}
+	if id, ok := strings.CutPrefix(channel, conversationChannelPrefix); ok && id != "" {
+		return TopicConversation, id, true
+	}
+	return "", "", false
+}
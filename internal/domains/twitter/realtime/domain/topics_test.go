@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+)
+
+func TestChannel_RoundTrip(t *testing.T) {
+	tests := []struct {
+		topic  domain.Topic
+		id     string
+		wantID string
+	}{
+		{topic: domain.TopicTimeline, id: "ignored"},
+		{topic: domain.TopicNotifications},
+		{topic: domain.TopicConversation, id: "twt-1", wantID: "twt-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.topic), func(t *testing.T) {
+			channel, err := domain.Channel(tt.topic, "user-1", tt.id)
+			assert.NoError(t, err)
+
+			topic, id, ok := domain.ParseChannel(channel)
+			assert.True(t, ok)
+			assert.Equal(t, tt.topic, topic)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
+
+func TestChannel_Errors(t *testing.T) {
+	_, err := domain.Channel(domain.TopicConversation, "user-1", "")
+	assert.ErrorIs(t, err, domain.ErrConversationRequired)
+
+	_, err = domain.Channel("likes", "user-1", "")
+	assert.ErrorIs(t, err, domain.ErrUnknownTopic)
+
+	_, _, ok := domain.ParseChannel("timeline:user-1")
+	assert.False(t, ok)
+}
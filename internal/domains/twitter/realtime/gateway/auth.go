@@ -0,0 +1,83 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+)
+
+// Authenticator resuelve el usuario de una conexión antes del upgrade.
+type Authenticator interface {
+	Authenticate(r *http.Request) (string, error)
+}
+
+// TokenAuthenticator valida tokens firmados con HMAC-SHA256 con el formato
+// base64url(userID).expiraciónUnix.base64url(firma). Los navegadores no
+// pueden enviar headers al abrir un WebSocket, así que además de
+// "Authorization: Bearer" se acepta el parámetro token.
+type TokenAuthenticator struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewTokenAuthenticator(secret string) *TokenAuthenticator {
+	return &TokenAuthenticator{secret: []byte(secret), now: time.Now}
+}
+
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (string, error) {
+	token := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); header != "" {
+		token, _ = strings.CutPrefix(header, "Bearer ")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", dmnrealtime.ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(a.secret, parts[0]+"."+parts[1])) {
+		return "", dmnrealtime.ErrInvalidToken
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || a.now().Unix() >= expiresAt {
+		return "", dmnrealtime.ErrInvalidToken
+	}
+
+	userID, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil || len(userID) == 0 {
+		return "", dmnrealtime.ErrInvalidToken
+	}
+	return string(userID), nil
+}
+
+// SignToken genera un token para userID válido hasta expiresAt.
+func SignToken(secret, userID string, expiresAt time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(userID)) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(secret), payload))
+}
+
+func sign(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// QueryAuthenticator toma el usuario del parámetro user_id, igual que el
+// resto de la API. Sólo se usa en desarrollo, cuando no hay WS_AUTH_SECRET.
+type QueryAuthenticator struct{}
+
+func (QueryAuthenticator) Authenticate(r *http.Request) (string, error) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		return "", dmnrealtime.ErrInvalidToken
+	}
+	return userID, nil
+}
@@ -0,0 +1,46 @@
+package gateway_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/gateway"
+)
+
+func TestTokenAuthenticator(t *testing.T) {
+	auth := gateway.NewTokenAuthenticator("secret")
+	valid := gateway.SignToken("secret", "user-1", time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name    string
+		target  string
+		header  string
+		wantID  string
+		wantErr error
+	}{
+		{name: "query", target: "/ws?token=" + valid, wantID: "user-1"},
+		{name: "header", target: "/ws", header: "Bearer " + valid, wantID: "user-1"},
+		{name: "missing", target: "/ws", wantErr: dmnrealtime.ErrInvalidToken},
+		{name: "other secret", target: "/ws?token=" + gateway.SignToken("other", "user-1", time.Now().Add(time.Hour)), wantErr: dmnrealtime.ErrInvalidToken},
+		{name: "expired", target: "/ws?token=" + gateway.SignToken("secret", "user-1", time.Now().Add(-time.Minute)), wantErr: dmnrealtime.ErrInvalidToken},
+		{name: "tampered", target: "/ws?token=dXNlci0y" + valid[len("dXNlci0x"):], wantErr: dmnrealtime.ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			userID, err := auth.Authenticate(r)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantID, userID)
+		})
+	}
+}
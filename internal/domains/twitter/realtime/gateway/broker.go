@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"encoding/json"
+
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+	"go.uber.org/zap"
+)
+
+func (g *Gateway) register(s *session) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sessions[s] = struct{}{}
+}
+
+func (g *Gateway) unregister(s *session) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.sessions, s)
+	for channel := range s.channels {
+		g.removeLocked(s, channel)
+	}
+}
+
+func (g *Gateway) subscribe(s *session, channel string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := s.channels[channel]; ok {
+		return nil
+	}
+	if g.options.MaxSubscriptions > 0 && len(s.channels) >= g.options.MaxSubscriptions {
+		return dmnrealtime.ErrTooManySubscriptions
+	}
+
+	if g.channels[channel] == nil {
+		g.channels[channel] = make(map[*session]struct{})
+	}
+	g.channels[channel][s] = struct{}{}
+	s.channels[channel] = struct{}{}
+	return nil
+}
+
+func (g *Gateway) unsubscribe(s *session, channel string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.removeLocked(s, channel)
+}
+
+func (g *Gateway) removeLocked(s *session, channel string) {
+	delete(s.channels, channel)
+	sessions := g.channels[channel]
+	delete(sessions, s)
+	if len(sessions) == 0 {
+		delete(g.channels, channel)
+	}
+}
+
+// dispatch serializa el evento una sola vez y lo encola en cada sesión
+// suscrita. Nunca bloquea: la política de cada sesión decide qué hacer si
+// su cola está llena.
+func (g *Gateway) dispatch(channel string, payload []byte) {
+	topic, id, ok := dmnrealtime.ParseChannel(channel)
+	if !ok {
+		return
+	}
+
+	message, err := json.Marshal(dmnrealtime.ServerMessage{
+		Type:  dmnrealtime.MessageEvent,
+		Topic: topic,
+		ID:    id,
+		Data:  payload,
+	})
+	if err != nil {
+		g.logger.Warn("Evento en tiempo real inválido",
+			zap.String("channel", channel),
+			zap.Error(err))
+		return
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for s := range g.channels[channel] {
+		if s.enqueue(message) {
+			g.logger.Warn("Cliente WebSocket lento, se cierra la conexión",
+				zap.String("user_id", s.userID))
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/i18n"
+	"go.uber.org/zap"
+)
+
+const (
+	resubscribeDelay = time.Second
+	writeWait        = 10 * time.Second
+	maxMessageBytes  = 4096
+)
+
+// Policy define qué hacer cuando un cliente no consume sus mensajes a tiempo
+// y se llena su cola de salida.
+type Policy string
+
+const (
+	// PolicyDropOldest descarta el mensaje más viejo de la cola.
+	PolicyDropOldest Policy = "drop_oldest"
+	// PolicyDisconnect cierra la conexión; el cliente reconecta y resincroniza
+	// por REST o SSE.
+	PolicyDisconnect Policy = "disconnect"
+)
+
+type Options struct {
+	SendBuffer       int
+	Policy           Policy
+	PingInterval     time.Duration
+	MaxSubscriptions int
+	// AllowedOrigins son los orígenes (esquema://host[:puerto]) desde los que
+	// un navegador puede abrir la conexión, además del de la propia API. "*"
+	// acepta cualquiera.
+	AllowedOrigins []string
+}
+
+// Gateway atiende las conexiones WebSocket de esta instancia. Mantiene una
+// única suscripción a Redis para todos los tópicos y reparte cada mensaje
+// entre las sesiones suscritas a su canal.
+type Gateway struct {
+	subscriber    Subscriber
+	authenticator Authenticator
+	options       Options
+	logger        Logger
+	upgrader      websocket.Upgrader
+
+	mu       sync.RWMutex
+	channels map[string]map[*session]struct{}
+	sessions map[*session]struct{}
+}
+
+func New(subscriber Subscriber, authenticator Authenticator, options Options, log Logger) *Gateway {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+	if options.SendBuffer <= 0 {
+		options.SendBuffer = 1
+	}
+	if options.Policy != PolicyDisconnect {
+		options.Policy = PolicyDropOldest
+	}
+	if options.PingInterval <= 0 {
+		options.PingInterval = 30 * time.Second
+	}
+
+	return &Gateway{
+		subscriber:    subscriber,
+		authenticator: authenticator,
+		options:       options,
+		logger:        log,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: checkOrigin(options.AllowedOrigins),
+		},
+		channels: make(map[string]map[*session]struct{}),
+		sessions: make(map[*session]struct{}),
+	}
+}
+
+// Authenticate resuelve el usuario de la conexión antes del upgrade, para que
+// los errores se respondan como cualquier otro error HTTP.
+func (g *Gateway) Authenticate(r *http.Request) (string, error) {
+	userID, err := g.authenticator.Authenticate(r)
+	if err != nil {
+		return "", apperrors.NewUnauthorizedError("El token de la conexión no es válido o expiró", err).
+			WithCode(apperrors.CodeRealtimeTokenInvalid)
+	}
+	return userID, nil
+}
+
+// Serve hace el upgrade y atiende la conexión hasta que se cierre.
+func (g *Gateway) Serve(w http.ResponseWriter, r *http.Request, userID string) {
+	conn, err := g.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade ya respondió al cliente con el error.
+		g.logger.Warn("Error en el upgrade a WebSocket",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return
+	}
+
+	s := newSession(userID, i18n.FromAcceptLanguage(r.Header.Get("Accept-Language")), conn, g.options)
+	g.register(s)
+	g.logger.Debug("Conexión WebSocket abierta", zap.String("user_id", userID))
+
+	go s.writeLoop()
+	g.readLoop(s)
+
+	g.unregister(s)
+	s.close(websocket.CloseNormalClosure, "")
+	g.logger.Debug("Conexión WebSocket cerrada",
+		zap.String("user_id", userID),
+		zap.Int64("dropped", s.dropped.Load()))
+}
+
+// Run consume los canales de Redis hasta que se cancele ctx, volviendo a
+// suscribirse si la suscripción se corta. Al terminar cierra las sesiones.
+func (g *Gateway) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := g.subscriber.PSubscribe(ctx, dmnrealtime.Patterns...)
+		if err != nil {
+			g.logger.Warn("Error suscribiendo a los canales en tiempo real, reintentando", zap.Error(err))
+		} else {
+			for msg := range messages {
+				g.dispatch(msg.Channel, msg.Payload)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(resubscribeDelay):
+		}
+	}
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	for s := range g.sessions {
+		s.close(websocket.CloseGoingAway, "server shutdown")
+	}
+}
+
+func (g *Gateway) readLoop(s *session) {
+	s.conn.SetReadLimit(maxMessageBytes)
+	_ = s.conn.SetReadDeadline(time.Now().Add(2 * g.options.PingInterval))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(2 * g.options.PingInterval))
+	})
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				g.logger.Debug("Conexión WebSocket interrumpida",
+					zap.String("user_id", s.userID),
+					zap.Error(err))
+			}
+			return
+		}
+
+		var msg dmnrealtime.ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.replyError(dmnrealtime.ErrInvalidMessage, msg, g.options.MaxSubscriptions)
+			continue
+		}
+		g.handle(s, msg)
+	}
+}
+
+func (g *Gateway) handle(s *session, msg dmnrealtime.ClientMessage) {
+	switch msg.Type {
+	case dmnrealtime.MessagePing:
+		s.reply(dmnrealtime.ServerMessage{Type: dmnrealtime.MessagePong})
+	case dmnrealtime.MessageSubscribe, dmnrealtime.MessageUnsubscribe:
+		channel, err := dmnrealtime.Channel(msg.Topic, s.userID, msg.ID)
+		if err != nil {
+			s.replyError(err, msg, g.options.MaxSubscriptions)
+			return
+		}
+
+		if msg.Type == dmnrealtime.MessageUnsubscribe {
+			g.unsubscribe(s, channel)
+			s.reply(dmnrealtime.ServerMessage{Type: dmnrealtime.MessageUnsubscribed, Topic: msg.Topic, ID: msg.ID})
+			return
+		}
+
+		if err := g.subscribe(s, channel); err != nil {
+			s.replyError(err, msg, g.options.MaxSubscriptions)
+			return
+		}
+		s.reply(dmnrealtime.ServerMessage{Type: dmnrealtime.MessageSubscribed, Topic: msg.Topic, ID: msg.ID})
+	default:
+		s.replyError(dmnrealtime.ErrInvalidMessage, msg, g.options.MaxSubscriptions)
+	}
+}
+
+// checkOrigin acepta las conexiones sin Origin (clientes que no son
+// navegadores), las del mismo host que la API y las de los orígenes
+// permitidos. Así otro sitio no puede abrir una conexión con las credenciales
+// del usuario que lo visita.
+func checkOrigin(allowed []string) func(*http.Request) bool {
+	origins := make(map[string]struct{}, len(allowed))
+	for _, origin := range allowed {
+		origins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = struct{}{}
+	}
+	_, any := origins["*"]
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || any {
+			return true
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		if strings.EqualFold(parsed.Host, r.Host) {
+			return true
+		}
+		_, ok := origins[strings.ToLower(parsed.Scheme+"://"+parsed.Host)]
+		return ok
+	}
+}
@@ -0,0 +1,192 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	dmnnotification "github.com/juanmalvarez3/twit/internal/domains/twitter/notification/domain"
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/gateway"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+type fakeSubscriber struct {
+	messages chan redisadapter.Message
+}
+
+func (f *fakeSubscriber) PSubscribe(ctx context.Context, patterns ...string) (<-chan redisadapter.Message, error) {
+	return f.messages, nil
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...zap.Field) {}
+func (nopLogger) Info(string, ...zap.Field)  {}
+func (nopLogger) Warn(string, ...zap.Field)  {}
+func (nopLogger) Error(string, ...zap.Field) {}
+
+func newServer(t *testing.T, options gateway.Options) (*fakeSubscriber, *httptest.Server) {
+	sub := &fakeSubscriber{messages: make(chan redisadapter.Message)}
+	gw := gateway.New(sub, gateway.QueryAuthenticator{}, options, nopLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go gw.Run(ctx)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, err := gw.Authenticate(r)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		gw.Serve(w, r, userID)
+	}))
+	t.Cleanup(server.Close)
+	return sub, server
+}
+
+func dial(t *testing.T, server *httptest.Server, userID string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?user_id=" + userID
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg dmnrealtime.ClientMessage) {
+	require.NoError(t, conn.WriteJSON(msg))
+}
+
+func read(t *testing.T, conn *websocket.Conn) dmnrealtime.ServerMessage {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var msg dmnrealtime.ServerMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestGateway_Unauthorized(t *testing.T) {
+	_, server := newServer(t, gateway.Options{SendBuffer: 4})
+
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGateway_CheckOrigin(t *testing.T) {
+	_, server := newServer(t, gateway.Options{SendBuffer: 4, AllowedOrigins: []string{"https://app.example.com"}})
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "?user_id=user-1"
+
+	tests := []struct {
+		name   string
+		origin string
+		status int
+	}{
+		{name: "sin Origin", origin: "", status: http.StatusSwitchingProtocols},
+		{name: "mismo host", origin: server.URL, status: http.StatusSwitchingProtocols},
+		{name: "origen permitido", origin: "https://app.example.com", status: http.StatusSwitchingProtocols},
+		{name: "otro sitio", origin: "https://evil.example.com", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+			conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+			if conn != nil {
+				conn.Close()
+			}
+			if tt.status != http.StatusSwitchingProtocols {
+				assert.Error(t, err)
+			}
+			require.NotNil(t, resp)
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestGateway_FansInSubscribedTopics(t *testing.T) {
+	sub, server := newServer(t, gateway.Options{SendBuffer: 4})
+	conn := dial(t, server, "user-1")
+
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: dmnrealtime.TopicTimeline})
+	assert.Equal(t, dmnrealtime.MessageSubscribed, read(t, conn).Type)
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: dmnrealtime.TopicConversation, ID: "twt-0"})
+	assert.Equal(t, dmnrealtime.MessageSubscribed, read(t, conn).Type)
+
+	// Las notificaciones no se pidieron y el timeline de otro usuario no
+	// corresponde a esta conexión.
+	sub.messages <- redisadapter.Message{Channel: dmnnotification.StreamChannel("user-1"), Payload: []byte(`{"id":"n-1"}`)}
+	sub.messages <- redisadapter.Message{Channel: dmntimeline.StreamChannel("user-2"), Payload: []byte(`{"tweetId":"twt-2"}`)}
+	sub.messages <- redisadapter.Message{Channel: dmntimeline.StreamChannel("user-1"), Payload: []byte(`{"tweetId":"twt-1"}`)}
+	sub.messages <- redisadapter.Message{Channel: dmnrealtime.ConversationChannel("twt-0"), Payload: []byte(`{"id":"twt-3"}`)}
+
+	event := read(t, conn)
+	assert.Equal(t, dmnrealtime.MessageEvent, event.Type)
+	assert.Equal(t, dmnrealtime.TopicTimeline, event.Topic)
+	assert.JSONEq(t, `{"tweetId":"twt-1"}`, string(event.Data))
+
+	event = read(t, conn)
+	assert.Equal(t, dmnrealtime.TopicConversation, event.Topic)
+	assert.Equal(t, "twt-0", event.ID)
+	assert.JSONEq(t, `{"id":"twt-3"}`, string(event.Data))
+}
+
+func TestGateway_ProtocolErrors(t *testing.T) {
+	_, server := newServer(t, gateway.Options{SendBuffer: 4, MaxSubscriptions: 1})
+	conn := dial(t, server, "user-1")
+
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: "likes"})
+	assert.Equal(t, apperrors.CodeRealtimeTopicInvalid, read(t, conn).Code)
+
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: dmnrealtime.TopicConversation})
+	assert.Equal(t, apperrors.CodeRealtimeConversationRequired, read(t, conn).Code)
+
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: dmnrealtime.TopicTimeline})
+	assert.Equal(t, dmnrealtime.MessageSubscribed, read(t, conn).Type)
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: dmnrealtime.TopicNotifications})
+	assert.Equal(t, apperrors.CodeRealtimeTooManySubscriptions, read(t, conn).Code)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("no es json")))
+	assert.Equal(t, apperrors.CodeRealtimeMessageInvalid, read(t, conn).Code)
+
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessagePing})
+	assert.Equal(t, dmnrealtime.MessagePong, read(t, conn).Type)
+}
+
+func TestGateway_DisconnectsSlowConsumer(t *testing.T) {
+	sub, server := newServer(t, gateway.Options{SendBuffer: 1, Policy: gateway.PolicyDisconnect})
+	conn := dial(t, server, "user-1")
+
+	send(t, conn, dmnrealtime.ClientMessage{Type: dmnrealtime.MessageSubscribe, Topic: dmnrealtime.TopicNotifications})
+	assert.Equal(t, dmnrealtime.MessageSubscribed, read(t, conn).Type)
+
+	// Sin leer del socket, los buffers del sistema operativo absorben
+	// bastante, así que se publica hasta que la cola se llene.
+	payload, _ := json.Marshal(map[string]string{"id": strings.Repeat("x", 64*1024)})
+	for i := 0; i < 200; i++ {
+		sub.messages <- redisadapter.Message{Channel: dmnnotification.StreamChannel("user-1"), Payload: payload}
+	}
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err != nil {
+			assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), err.Error())
+			return
+		}
+	}
+}
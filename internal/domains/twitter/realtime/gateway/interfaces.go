@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"context"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"go.uber.org/zap"
+)
+
+type Subscriber interface {
+	PSubscribe(ctx context.Context, patterns ...string) (<-chan redisadapter.Message, error)
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/i18n"
+)
+
+// session es una conexión WebSocket. gorilla/websocket admite un solo
+// escritor, así que todo lo que se envía pasa por la cola send y lo escribe
+// writeLoop.
+type session struct {
+	userID       string
+	lang         string
+	conn         *websocket.Conn
+	send         chan []byte
+	policy       Policy
+	pingInterval time.Duration
+
+	// channels lo protege Gateway.mu.
+	channels map[string]struct{}
+	dropped  atomic.Int64
+
+	closeOnce   sync.Once
+	closed      chan struct{}
+	closeCode   int
+	closeReason string
+}
+
+func newSession(userID, lang string, conn *websocket.Conn, options Options) *session {
+	return &session{
+		userID:       userID,
+		lang:         lang,
+		conn:         conn,
+		send:         make(chan []byte, options.SendBuffer),
+		policy:       options.Policy,
+		pingInterval: options.PingInterval,
+		channels:     make(map[string]struct{}),
+		closed:       make(chan struct{}),
+	}
+}
+
+// enqueue agrega el mensaje sin bloquear. Devuelve true si la cola estaba
+// llena y, por la política PolicyDisconnect, se cerró la sesión.
+func (s *session) enqueue(message []byte) bool {
+	for {
+		select {
+		case <-s.closed:
+			return false
+		case s.send <- message:
+			return false
+		default:
+		}
+
+		if s.policy == PolicyDisconnect {
+			s.close(websocket.CloseTryAgainLater, "slow consumer")
+			return true
+		}
+		select {
+		case <-s.send:
+			s.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+func (s *session) reply(message dmnrealtime.ServerMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	s.enqueue(data)
+}
+
+func (s *session) replyError(err error, msg dmnrealtime.ClientMessage, maxSubscriptions int) {
+	code, params := errorCode(err, msg, maxSubscriptions)
+	message, _ := i18n.Message(s.lang, code, params)
+	s.reply(dmnrealtime.ServerMessage{
+		Type:    dmnrealtime.MessageError,
+		Topic:   msg.Topic,
+		ID:      msg.ID,
+		Code:    code,
+		Message: message,
+	})
+}
+
+func errorCode(err error, msg dmnrealtime.ClientMessage, maxSubscriptions int) (string, map[string]any) {
+	switch {
+	case errors.Is(err, dmnrealtime.ErrUnknownTopic):
+		allowed := make([]string, 0, len(dmnrealtime.Topics))
+		for _, topic := range dmnrealtime.Topics {
+			allowed = append(allowed, string(topic))
+		}
+		return apperrors.CodeRealtimeTopicInvalid, map[string]any{"topic": msg.Topic, "allowed": strings.Join(allowed, ", ")}
+	case errors.Is(err, dmnrealtime.ErrConversationRequired):
+		return apperrors.CodeRealtimeConversationRequired, nil
+	case errors.Is(err, dmnrealtime.ErrTooManySubscriptions):
+		return apperrors.CodeRealtimeTooManySubscriptions, map[string]any{"max": maxSubscriptions}
+	default:
+		return apperrors.CodeRealtimeMessageInvalid, nil
+	}
+}
+
+func (s *session) close(code int, reason string) {
+	s.closeOnce.Do(func() {
+		s.closeCode = code
+		s.closeReason = reason
+		close(s.closed)
+	})
+}
+
+func (s *session) writeLoop() {
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+	defer s.conn.Close()
+
+	for {
+		select {
+		case <-s.closed:
+			_ = s.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(s.closeCode, s.closeReason),
+				time.Now().Add(writeWait))
+			return
+		case message := <-s.send:
+			_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := s.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				s.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				s.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+
+	dmnrealtime "github.com/juanmalvarez3/twit/internal/domains/twitter/realtime/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type RedisInterface interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+// Publisher publica en Redis los eventos en tiempo real que no pertenecen a
+// un dominio con almacenamiento propio, como las respuestas de una conversación.
+type Publisher struct {
+	redisClient RedisInterface
+	logger      logger.LoggerInterface
+}
+
+func New(redisClient RedisInterface, log logger.LoggerInterface) *Publisher {
+	return &Publisher{
+		redisClient: redisClient,
+		logger:      log,
+	}
+}
+
+// PublishReply publica la respuesta en la conversación del tweet respondido.
+func (p *Publisher) PublishReply(ctx context.Context, tweet dmntweet.Tweet) error {
+	if tweet.ReplyToID == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(tweet)
+	if err != nil {
+		p.logger.Error("Error serializando respuesta para publicar",
+			zap.String("tweet_id", tweet.ID),
+			zap.Error(err))
+		return err
+	}
+	return p.redisClient.Publish(ctx, dmnrealtime.ConversationChannel(tweet.ReplyToID), payload)
+}
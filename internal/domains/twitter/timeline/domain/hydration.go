@@ -0,0 +1,43 @@
+package domain
+
+import (
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+// Modos de almacenamiento de las entradas de timeline. En StorageContent cada
+// entrada guarda una copia del contenido del tweet; en StorageIDs guarda sólo
+// tweet, autor y fecha, y el contenido se hidrata al leer.
+const (
+	StorageContent = "content"
+	StorageIDs     = "ids"
+)
+
+// TweetIDs devuelve los IDs de tweet de las entradas, en orden.
+func TweetIDs(entries []TimelineEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.TweetID)
+	}
+	return ids
+}
+
+// Hydrate completa las entradas con el contenido actual de sus tweets y
+// descarta las entradas cuyo tweet ya no existe.
+func Hydrate(entries []TimelineEntry, tweets []dmntweet.Tweet) []TimelineEntry {
+	byID := make(map[string]dmntweet.Tweet, len(tweets))
+	for _, tweet := range tweets {
+		byID[tweet.ID] = tweet
+	}
+
+	hydrated := make([]TimelineEntry, 0, len(entries))
+	for _, entry := range entries {
+		tweet, ok := byID[entry.TweetID]
+		if !ok {
+			continue
+		}
+		entry.AuthorID = tweet.UserID
+		entry.Content = tweet.Content
+		hydrated = append(hydrated, entry)
+	}
+	return hydrated
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Canales de Redis por los que el worker de update-timeline avisa a las
+// instancias de la API de cada nueva entrada, para el streaming por SSE.
+const (
+	streamChannelPrefix  = "timeline:stream:"
+	StreamChannelPattern = streamChannelPrefix + "*"
+)
+
+func StreamChannel(userID string) string {
+	return streamChannelPrefix + userID
+}
+
+// UserIDFromStreamChannel devuelve el usuario de un canal de StreamChannel.
+func UserIDFromStreamChannel(channel string) (string, bool) {
+	userID, ok := strings.CutPrefix(channel, streamChannelPrefix)
+	return userID, ok && userID != ""
+}
+
+// EventID identifica la entrada en el stream. Empieza con el timestamp en
+// nanosegundos con ancho fijo, así que comparar IDs como strings equivale a
+// compararlos cronológicamente; el cliente lo reenvía en Last-Event-ID.
+func (e TimelineEntry) EventID() string {
+	return fmt.Sprintf("%019d-%s", e.CreatedAt.UnixNano(), e.TweetID)
+}
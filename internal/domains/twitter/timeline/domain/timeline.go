@@ -10,6 +10,10 @@ import (
 type Timeline struct {
 	UserID  string          `json:"user_id"`
 	Entries []TimelineEntry `json:"entries"`
+	// CacheVersion es la versión del caché vigente cuando el timeline se leyó
+	// de la base. El repositorio la guarda junto con el timeline cacheado y lo
+	// ignora si otra escritura la cambió.
+	CacheVersion string `json:"cache_version,omitempty"`
 }
 
 type TimelineEntry struct {
@@ -0,0 +1,101 @@
+package publisher
+
+import (
+	"context"
+	"time"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"go.uber.org/zap"
+)
+
+const prefixDedup = "timeline_publish:"
+
+// DedupStore guarda las marcas de publicación reciente. En Redis, SetNX con
+// expiración.
+type DedupStore interface {
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Del(ctx context.Context, keys ...string) error
+}
+
+type Logger interface {
+	Warn(msg string, fields ...zap.Field)
+}
+
+// dedup deja pasar una publicación por usuario y tipo dentro de la ventana.
+// Si el store falla se publica igual: un mensaje de más es preferible a uno
+// perdido.
+type dedup struct {
+	store  DedupStore
+	window time.Duration
+	logger Logger
+}
+
+func (d dedup) acquire(ctx context.Context, kind, userID string) (string, bool) {
+	key := prefixDedup + kind + ":" + userID
+	acquired, err := d.store.SetNX(ctx, key, []byte("1"), d.window)
+	if err != nil {
+		d.logger.Warn("Error verificando publicación duplicada, se publica igual",
+			zap.String("kind", kind),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return "", true
+	}
+	return key, acquired
+}
+
+// release borra la marca cuando la publicación falló, para que el próximo
+// miss pueda reintentarla.
+func (d dedup) release(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	if err := d.store.Del(ctx, key); err != nil {
+		d.logger.Warn("Error liberando marca de publicación", zap.String("key", key), zap.Error(err))
+	}
+}
+
+// DedupTimelinePublisher publica a lo sumo un pedido de populate-cache por
+// usuario dentro de la ventana.
+type DedupTimelinePublisher struct {
+	next TimelinePublisher
+	dedup
+}
+
+func NewDedupTimelinePublisher(next TimelinePublisher, store DedupStore, window time.Duration, logger Logger) *DedupTimelinePublisher {
+	return &DedupTimelinePublisher{next: next, dedup: dedup{store: store, window: window, logger: logger}}
+}
+
+func (p *DedupTimelinePublisher) Publish(ctx context.Context, timeline dmntimeline.Timeline) error {
+	key, ok := p.acquire(ctx, "populate", timeline.UserID)
+	if !ok {
+		return nil
+	}
+	if err := p.next.Publish(ctx, timeline); err != nil {
+		p.release(ctx, key)
+		return err
+	}
+	return nil
+}
+
+// DedupRebuildPublisher publica a lo sumo un pedido de rebuild-timeline por
+// usuario dentro de la ventana.
+type DedupRebuildPublisher struct {
+	next RebuildPublisher
+	dedup
+}
+
+func NewDedupRebuildPublisher(next RebuildPublisher, store DedupStore, window time.Duration, logger Logger) *DedupRebuildPublisher {
+	return &DedupRebuildPublisher{next: next, dedup: dedup{store: store, window: window, logger: logger}}
+}
+
+func (p *DedupRebuildPublisher) Publish(ctx context.Context, userID string) error {
+	key, ok := p.acquire(ctx, "rebuild", userID)
+	if !ok {
+		return nil
+	}
+	if err := p.next.Publish(ctx, userID); err != nil {
+		p.release(ctx, key)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package publisher_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/publisher"
+)
+
+type fakeStore struct {
+	mu   sync.Mutex
+	keys map[string]bool
+	err  error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{keys: map[string]bool{}}
+}
+
+func (s *fakeStore) SetNX(_ context.Context, key string, _ []byte, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if s.keys[key] {
+		return false, nil
+	}
+	s.keys[key] = true
+	return true, nil
+}
+
+func (s *fakeStore) Del(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.keys, key)
+	}
+	return nil
+}
+
+type countingTimelinePublisher struct {
+	calls int
+	err   error
+}
+
+func (p *countingTimelinePublisher) Publish(context.Context, dmntimeline.Timeline) error {
+	p.calls++
+	return p.err
+}
+
+type countingRebuildPublisher struct {
+	calls int
+}
+
+func (p *countingRebuildPublisher) Publish(context.Context, string) error {
+	p.calls++
+	return nil
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Warn(string, ...zap.Field) {}
+
+func TestDedupTimelinePublisher_PublishesOncePerUser(t *testing.T) {
+	next := &countingTimelinePublisher{}
+	p := publisher.NewDedupTimelinePublisher(next, newFakeStore(), time.Minute, nopLogger{})
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, p.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-1"}))
+	}
+	assert.NoError(t, p.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-2"}))
+
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestDedupTimelinePublisher_FailedPublishCanBeRetried(t *testing.T) {
+	next := &countingTimelinePublisher{err: errors.New("sqs error")}
+	p := publisher.NewDedupTimelinePublisher(next, newFakeStore(), time.Minute, nopLogger{})
+
+	assert.Error(t, p.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-1"}))
+
+	next.err = nil
+	assert.NoError(t, p.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-1"}))
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestDedupTimelinePublisher_StoreErrorPublishesAnyway(t *testing.T) {
+	next := &countingTimelinePublisher{}
+	store := newFakeStore()
+	store.err = errors.New("redis error")
+	p := publisher.NewDedupTimelinePublisher(next, store, time.Minute, nopLogger{})
+
+	assert.NoError(t, p.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-1"}))
+	assert.NoError(t, p.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-1"}))
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestDedupRebuildPublisher_IndependentFromPopulate(t *testing.T) {
+	store := newFakeStore()
+	populate := &countingTimelinePublisher{}
+	rebuild := &countingRebuildPublisher{}
+	populatePublisher := publisher.NewDedupTimelinePublisher(populate, store, time.Minute, nopLogger{})
+	rebuildPublisher := publisher.NewDedupRebuildPublisher(rebuild, store, time.Minute, nopLogger{})
+
+	assert.NoError(t, populatePublisher.Publish(context.Background(), dmntimeline.Timeline{UserID: "user-1"}))
+	assert.NoError(t, rebuildPublisher.Publish(context.Background(), "user-1"))
+	assert.NoError(t, rebuildPublisher.Publish(context.Background(), "user-1"))
+
+	assert.Equal(t, 1, populate.calls)
+	assert.Equal(t, 1, rebuild.calls)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"go.uber.org/zap"
+)
+
+// BulkInsert escribe las entradas en el timeline del usuario con
+// BatchWriteItem. Las que ya existen se sobrescriben, así que repetir una
+// reconstrucción no duplica entradas.
+func (r *TimelineRepository) BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	requests := make([]types.WriteRequest, 0, len(entries))
+	for _, entry := range entries {
+		item, err := r.marshalEntry(userID, entry)
+		if err != nil {
+			r.logger.Error("Error serializando entrada de timeline", zap.Error(err))
+			return err
+		}
+		requests = append(requests, types.WriteRequest{PutRequest: &types.PutRequest{Item: item}})
+	}
+
+	if written, err := r.batchWrite(ctx, requests); err != nil {
+		r.logger.Error("Error insertando entradas de timeline en DynamoDB",
+			zap.String("user_id", userID),
+			zap.Int("written", written),
+			zap.Int("entries_count", len(entries)),
+			zap.Error(err))
+		return err
+	}
+
+	r.discardCache(ctx, userID)
+	return nil
+}
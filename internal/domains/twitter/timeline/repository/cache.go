@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"math/rand/v2"
+	"time"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+)
+
+// CacheOptions configura el caché de timelines en Redis. Con Enabled en false
+// las lecturas van siempre a DynamoDB y las escrituras al caché no hacen nada.
+type CacheOptions struct {
+	Enabled bool
+	TTL     time.Duration
+	// JitterPercent varía el TTL de cada clave hasta ese porcentaje, en más o en
+	// menos, para que las claves cacheadas juntas no venzan juntas.
+	JitterPercent int
+	// SlidingExpiration renueva el TTL en cada lectura, así el timeline de un
+	// usuario activo no vence mientras lo sigue leyendo.
+	SlidingExpiration bool
+	// LockTTL es cuánto dura la marca de carga en curso tras un miss. Las
+	// demás instancias esperan hasta LockWait a que el caché se complete antes
+	// de leer DynamoDB por su cuenta.
+	LockTTL  time.Duration
+	LockWait time.Duration
+	// Local es el caché en memoria del proceso que se consulta antes que Redis.
+	// Sus entradas duran poco y se descartan cuando llega una invalidación.
+	Local *localcache.LRU[dmntimeline.Timeline]
+	// Invalidator avisa a todas las instancias, incluida esta, que descarten
+	// de memoria el timeline que cambió.
+	Invalidator *localcache.Invalidator
+}
+
+// versionTTL es lo que dura la versión del caché de un usuario desde su última
+// escritura. Supera el TTL más largo de una entrada: si la versión venciera
+// antes que un timeline guardado con una versión vieja, ese timeline volvería
+// a ser válido.
+func (o CacheOptions) versionTTL() time.Duration {
+	return 2 * (o.TTL + o.TTL*time.Duration(max(o.JitterPercent, 0))/100)
+}
+
+func (o CacheOptions) ttl() time.Duration {
+	if o.JitterPercent <= 0 || o.TTL <= 0 {
+		return o.TTL
+	}
+
+	spread := int64(o.TTL) * int64(o.JitterPercent) / 100
+	if spread == 0 {
+		return o.TTL
+	}
+	return o.TTL + time.Duration(rand.Int64N(2*spread+1)-spread)
+}
@@ -1,6 +1,23 @@
 package repository
 
+import "time"
+
 const (
 	prefixDBId  = "twt-"
 	prefixCache = "timeline:"
+	// prefixCacheVersion guarda la versión del caché de cada usuario, que sube
+	// con cada escritura a su timeline.
+	prefixCacheVersion = "timeline_version:"
+
+	// prefixLoadLock marca que una instancia está leyendo el timeline de
+	// DynamoDB tras un miss de caché.
+	prefixLoadLock = "timeline_lock:"
+
+	// keyTrimPending es el set de usuarios con entradas nuevas desde el último
+	// recorte de su timeline.
+	keyTrimPending = "timeline_trim:pending"
 )
+
+// cacheWaitInterval es cada cuánto se vuelve a consultar el caché mientras
+// otra instancia lo está cargando.
+const cacheWaitInterval = 50 * time.Millisecond
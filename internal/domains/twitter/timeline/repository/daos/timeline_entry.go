@@ -6,18 +6,18 @@ import (
 )
 
 type TimelineEntryDAO struct {
-	UserID    string     `dynamodbav:"user_id" redis:"user_id"`
-	SK        string     `dynamodbav:"SK" redis:"SK"`
-	TweetID   string     `dynamodbav:"tweet_id" redis:"tweet_id"`
-	AuthorID  string     `dynamodbav:"author_id" redis:"author_id"`
-	Content   string     `dynamodbav:"content" redis:"content"`
-	CreatedAt time.Time  `dynamodbav:"created_at" redis:"created_at"`
-	TTL       *time.Time `dynamodbav:"ttl,omitempty" redis:"ttl,omitempty"`
+	UserID    string    `dynamodbav:"user_id" redis:"user_id"`
+	SK        string    `dynamodbav:"SK" redis:"SK"`
+	TweetID   string    `dynamodbav:"tweet_id" redis:"tweet_id"`
+	AuthorID  string    `dynamodbav:"author_id" redis:"author_id"`
+	Content   string    `dynamodbav:"content,omitempty" redis:"content,omitempty"`
+	CreatedAt time.Time `dynamodbav:"created_at" redis:"created_at"`
+	// TTL es un epoch en segundos, el formato que usa el TTL de DynamoDB.
+	TTL *int64 `dynamodbav:"ttl,omitempty" redis:"ttl,omitempty"`
 }
 
 func ToTimelineEntryDAO(userID string, entry dmntimeline.TimelineEntry) TimelineEntryDAO {
-	content := entry.Content
-	if content == "" || userID == "" || entry.TweetID == "" || entry.CreatedAt.IsZero() {
+	if userID == "" || entry.TweetID == "" || entry.CreatedAt.IsZero() {
 		return TimelineEntryDAO{}
 	}
 
@@ -26,9 +26,9 @@ func ToTimelineEntryDAO(userID string, entry dmntimeline.TimelineEntry) Timeline
 		SK:        entry.CreatedAt.Format(time.RFC3339) + "#" + entry.TweetID,
 		TweetID:   entry.TweetID,
 		AuthorID:  entry.AuthorID,
-		Content:   content,
+		Content:   entry.Content,
 		CreatedAt: entry.CreatedAt,
-		TTL:       entry.TTL,
+		TTL:       toEpoch(entry.TTL),
 	}
 }
 
@@ -38,6 +38,22 @@ func ToDomainTimelineEntry(dao TimelineEntryDAO) dmntimeline.TimelineEntry {
 		AuthorID:  dao.AuthorID,
 		Content:   dao.Content,
 		CreatedAt: dao.CreatedAt,
-		TTL:       dao.TTL,
+		TTL:       fromEpoch(dao.TTL),
 	}
 }
+
+func toEpoch(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	epoch := t.Unix()
+	return &epoch
+}
+
+func fromEpoch(epoch *int64) *time.Time {
+	if epoch == nil {
+		return nil
+	}
+	t := time.Unix(*epoch, 0).UTC()
+	return &t
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -10,108 +11,169 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
 	"go.uber.org/zap"
 )
 
+type loadResult struct {
+	timeline dmntimeline.Timeline
+	cacheHit bool
+}
+
+// Get devuelve el timeline desde el caché o, ante un miss, desde DynamoDB.
+// Las lecturas concurrentes del mismo usuario en la instancia comparten una
+// sola carga, y entre instancias la primera deja una marca en Redis para que
+// las demás esperen el caché en vez de ir todas a DynamoDB.
 func (r *TimelineRepository) Get(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, bool, error) {
-	cacheKey := prefixCache + userID
-	cacheData, err := r.redisClient.Get(ctx, cacheKey)
-
-	if err == nil && len(cacheData) > 0 {
-		var timeline dmntimeline.Timeline
-		err = json.Unmarshal(cacheData, &timeline)
-		if err == nil && len(timeline.Entries) > 0 {
-			r.logger.Debug("Timeline obtenida desde caché",
-				zap.String("user_id", userID),
-				zap.Int("entries_count", len(timeline.Entries)))
+	var version string
+	if r.cache.Enabled {
+		if timeline, ok := r.getLocal(userID); ok {
 			return timeline, true, nil
 		}
+		timeline, current, ok := r.getCached(ctx, userID)
+		if ok {
+			r.setLocal(userID, timeline)
+			return timeline, true, nil
+		}
+		version = current
+	}
 
+	// La carga compartida no se cancela si se va el request que la inició.
+	loadCtx := context.WithoutCancel(ctx)
+	result, err, shared := r.loads.Do(fmt.Sprintf("%s:%d", userID, limit), func() (any, error) {
+		return r.load(loadCtx, userID, limit, version)
+	})
+	if err != nil {
+		return dmntimeline.Timeline{}, false, err
+	}
+	if shared {
+		r.logger.Debug("Lectura de timeline compartida con otra en curso",
+			zap.String("user_id", userID))
+	}
+
+	loaded := result.(loadResult)
+	if r.cache.Enabled {
+		r.setLocal(userID, loaded.timeline)
+	}
+	return loaded.timeline, loaded.cacheHit, nil
+}
+
+// load lee el timeline de DynamoDB y lo marca con version, la versión del
+// caché que se leyó antes de empezar. Si una escritura descarta el caché
+// mientras tanto, la carga asíncrona de este timeline queda con una versión
+// vieja.
+func (r *TimelineRepository) load(ctx context.Context, userID string, limit int, version string) (loadResult, error) {
+	if r.cache.Enabled && r.cache.LockTTL > 0 {
+		acquired, err := r.redisClient.SetNX(ctx, prefixLoadLock+userID, []byte("1"), r.cache.LockTTL)
 		if err != nil {
-			r.logger.Warn("Error deserializando timeline desde caché",
+			r.logger.Warn("Error marcando carga de timeline, se lee de DynamoDB",
 				zap.String("user_id", userID),
 				zap.Error(err))
 		}
+		// La marca no se libera: vence sola y mientras tanto evita que otras
+		// instancias repitan la lectura antes de que se pueble el caché.
+		if err == nil && !acquired {
+			if timeline, ok := r.waitForCache(ctx, userID); ok {
+				return loadResult{timeline: timeline, cacheHit: true}, nil
+			}
+		}
 	}
-	r.logger.Debug("Consultando timeline en DynamoDB",
-		zap.String("user_id", userID),
-		zap.String("table_name", r.tableName))
-
-	keyEx := expression.Key("user_id").Equal(expression.Value(userID))
 
-	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).Build()
+	timeline, err := r.GetFromDB(ctx, userID, limit)
 	if err != nil {
-		r.logger.Error("Error construyendo expresión para DynamoDB",
-			zap.String("user_id", userID),
-			zap.Error(err))
-		return dmntimeline.Timeline{}, false, err
+		return loadResult{}, err
 	}
+	timeline.CacheVersion = version
+	return loadResult{timeline: timeline}, nil
+}
 
-	queryInput := &dynamodb.QueryInput{
-		TableName:                 aws.String(r.tableName),
-		KeyConditionExpression:    expr.KeyCondition(),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		Limit:                     aws.Int32(int32(limit)),
-		ScanIndexForward:          aws.Bool(false),
-	}
+// waitForCache consulta el caché hasta LockWait mientras otra instancia carga
+// el timeline.
+func (r *TimelineRepository) waitForCache(ctx context.Context, userID string) (dmntimeline.Timeline, bool) {
+	deadline := time.Now().Add(r.cache.LockWait)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return dmntimeline.Timeline{}, false
+		case <-time.After(cacheWaitInterval):
+		}
 
-	result, err := r.dynamoDBClient.Query(ctx, queryInput)
-	if err != nil {
-		r.logger.Error("Error consultando timeline en DynamoDB",
-			zap.String("user_id", userID),
-			zap.String("table_name", r.tableName),
-			zap.Error(err))
-		return dmntimeline.Timeline{}, false, err
+		if timeline, _, ok := r.readCache(ctx, userID); ok {
+			metrics.ObserveCache(true)
+			return timeline, true
+		}
 	}
 
-	if len(result.Items) == 0 {
-		r.logger.Debug("No se encontraron entradas para la timeline",
-			zap.String("user_id", userID))
-		return dmntimeline.Timeline{
-			UserID:  userID,
-			Entries: []dmntimeline.TimelineEntry{},
-		}, false, nil
+	r.logger.Debug("El caché no se completó a tiempo, se lee de DynamoDB",
+		zap.String("user_id", userID))
+	return dmntimeline.Timeline{}, false
+}
+
+func (r *TimelineRepository) getLocal(userID string) (dmntimeline.Timeline, bool) {
+	if r.cache.Local == nil {
+		return dmntimeline.Timeline{}, false
 	}
+	timeline, ok := r.cache.Local.Get(userID)
+	metrics.ObserveCacheTier(metrics.CacheTimeline, metrics.CacheTierL1, ok)
+	return timeline, ok
+}
 
-	entries := make([]dmntimeline.TimelineEntry, 0, len(result.Items))
-	for _, item := range result.Items {
-		var entry dmntimeline.TimelineEntry
+func (r *TimelineRepository) setLocal(userID string, timeline dmntimeline.Timeline) {
+	if r.cache.Local == nil || len(timeline.Entries) == 0 {
+		return
+	}
+	r.cache.Local.Set(userID, timeline)
+}
 
-		tweetID, _ := item["tweet_id"].(*types.AttributeValueMemberS)
-		authorID, _ := item["author_id"].(*types.AttributeValueMemberS)
-		content, _ := item["content"].(*types.AttributeValueMemberS)
-		createdAtStr, _ := item["created_at"].(*types.AttributeValueMemberS)
+// getCached devuelve el timeline cacheado, si lo hay, y la versión vigente del
+// caché para marcar lo que se lea de DynamoDB.
+func (r *TimelineRepository) getCached(ctx context.Context, userID string) (dmntimeline.Timeline, string, bool) {
+	timeline, version, ok := r.readCache(ctx, userID)
+	metrics.ObserveCache(ok)
+	if !ok {
+		return dmntimeline.Timeline{}, version, false
+	}
 
-		createdAt := time.Now()
-		if createdAtStr != nil {
-			parsedTime, err := time.Parse(time.RFC3339, createdAtStr.Value)
-			if err == nil {
-				createdAt = parsedTime
-			}
-		}
+	r.logger.Debug("Timeline obtenida desde caché",
+		zap.String("user_id", userID),
+		zap.Int("entries_count", len(timeline.Entries)))
 
-		if tweetID != nil && authorID != nil {
-			entry = dmntimeline.TimelineEntry{
-				TweetID:   tweetID.Value,
-				AuthorID:  authorID.Value,
-				Content:   content.Value,
-				CreatedAt: createdAt,
-			}
-			entries = append(entries, entry)
+	if r.cache.SlidingExpiration {
+		if err := r.redisClient.Expire(ctx, prefixCache+userID, r.cache.ttl()); err != nil {
+			r.logger.Warn("Error renovando expiración de timeline en caché",
+				zap.String("user_id", userID),
+				zap.Error(err))
 		}
 	}
+	return timeline, version, true
+}
 
-	timeline := dmntimeline.Timeline{
-		UserID:  userID,
-		Entries: entries,
+// readCache lee el timeline cacheado junto con la versión vigente del caché.
+// Un timeline guardado con otra versión se ignora: lo cargó una lectura que
+// empezó antes de la última escritura.
+func (r *TimelineRepository) readCache(ctx context.Context, userID string) (dmntimeline.Timeline, string, bool) {
+	values, err := r.redisClient.MGet(ctx, prefixCache+userID, prefixCacheVersion+userID)
+	if err != nil {
+		return dmntimeline.Timeline{}, "", false
+	}
+	cacheData, version := values[0], string(values[1])
+	if len(cacheData) == 0 {
+		return dmntimeline.Timeline{}, version, false
 	}
 
-	r.logger.Debug("Timeline obtenida exitosamente desde DynamoDB",
-		zap.String("user_id", userID),
-		zap.Int("entries_count", len(entries)))
-
-	return timeline, false, nil
+	var timeline dmntimeline.Timeline
+	if err := json.Unmarshal(cacheData, &timeline); err != nil {
+		r.logger.Warn("Error deserializando timeline desde caché",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return dmntimeline.Timeline{}, version, false
+	}
+	if timeline.CacheVersion != version {
+		r.logger.Debug("Timeline en caché con una versión vieja, se descarta",
+			zap.String("user_id", userID))
+		return dmntimeline.Timeline{}, version, false
+	}
+	return timeline, version, len(timeline.Entries) > 0
 }
 
 func (r *TimelineRepository) GetFromDB(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, error) {
@@ -177,9 +239,12 @@ func (r *TimelineRepository) GetFromDB(ctx context.Context, userID string, limit
 			entry = dmntimeline.TimelineEntry{
 				TweetID:   tweetID.Value,
 				AuthorID:  authorID.Value,
-				Content:   content.Value,
 				CreatedAt: createdAt,
 			}
+			// En modo StorageIDs las entradas no guardan contenido.
+			if content != nil {
+				entry.Content = content.Value
+			}
 			entries = append(entries, entry)
 		}
 	}
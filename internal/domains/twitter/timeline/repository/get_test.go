@@ -0,0 +1,222 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+)
+
+// fakeDynamo guarda las entradas escritas con PutItem y las devuelve todas en
+// cada Query; los tests usan un solo usuario.
+type fakeDynamo struct {
+	repository.DynamoDBClientInterface
+	mu   sync.Mutex
+	puts []*dynamodb.PutItemInput
+}
+
+func (f *fakeDynamo) PutItem(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.puts = append(f.puts, params)
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeDynamo) Query(_ context.Context, _ *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	output := &dynamodb.QueryOutput{}
+	for _, put := range f.puts {
+		output.Items = append(output.Items, put.Item)
+	}
+	return output, nil
+}
+
+// fakeRedis es un Redis en memoria sin expiración, suficiente para seguir qué
+// queda en el caché.
+type fakeRedis struct {
+	repository.RedisClientInterface
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: make(map[string][]byte)}
+}
+
+func (f *fakeRedis) Get(_ context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.values[key], nil
+}
+
+func (f *fakeRedis) MGet(_ context.Context, keys ...string) ([][]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = f.values[key]
+	}
+	return values, nil
+}
+
+func (f *fakeRedis) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+	return nil
+}
+
+func (f *fakeRedis) Expire(context.Context, string, time.Duration) error {
+	return nil
+}
+
+func (f *fakeRedis) Del(_ context.Context, keys ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return nil
+}
+
+func (f *fakeRedis) Increment(_ context.Context, key string, _ time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n, _ := strconv.Atoi(string(f.values[key]))
+	f.values[key] = []byte(strconv.Itoa(n + 1))
+	return nil
+}
+
+func newRepository(t *testing.T, redis repository.RedisClientInterface, dynamo repository.DynamoDBClientInterface) *repository.TimelineRepository {
+	t.Helper()
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+
+	return repository.NewTimelineRepository(dynamo, redis, "timelines", true, 0, repository.CacheOptions{
+		Enabled:           true,
+		TTL:               time.Minute,
+		SlidingExpiration: true,
+	}, log)
+}
+
+func entry(tweetID string, createdAt time.Time) dmntimeline.TimelineEntry {
+	return dmntimeline.TimelineEntry{TweetID: tweetID, AuthorID: "user-2", CreatedAt: createdAt}
+}
+
+// fill hace lo mismo que el worker populate-cache con el timeline que se leyó
+// de DynamoDB.
+func fill(t *testing.T, repo *repository.TimelineRepository, timeline dmntimeline.Timeline) {
+	t.Helper()
+	payload, err := json.Marshal(timeline)
+	require.NoError(t, err)
+	require.NoError(t, repo.SetCache(context.Background(), "timeline:"+timeline.UserID, payload))
+}
+
+func TestGet_FillsCacheFromLoad(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepository(t, newFakeRedis(), &fakeDynamo{})
+	require.NoError(t, repo.Update(ctx, entry("twt-1", time.Now()), "user-1"))
+
+	loaded, hit, err := repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.False(t, hit)
+	fill(t, repo, loaded)
+
+	timeline, hit, err := repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Len(t, timeline.Entries, 1)
+}
+
+// Una carga que leyó DynamoDB antes de una escritura no deja su timeline en el
+// caché aunque el worker lo guarde después de que la escritura lo descartó.
+func TestGet_IgnoresFillStartedBeforeUpdate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := newRepository(t, newFakeRedis(), &fakeDynamo{})
+	require.NoError(t, repo.Update(ctx, entry("twt-1", now.Add(-time.Minute)), "user-1"))
+
+	loaded, hit, err := repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	require.False(t, hit)
+	require.Len(t, loaded.Entries, 1)
+
+	require.NoError(t, repo.Update(ctx, entry("twt-2", now), "user-1"))
+	fill(t, repo, loaded)
+
+	timeline, hit, err := repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.False(t, hit)
+	assert.Len(t, timeline.Entries, 2)
+
+	// La carga nueva sí queda en el caché.
+	fill(t, repo, timeline)
+	timeline, hit, err = repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Len(t, timeline.Entries, 2)
+}
+
+func cacheHits(tier string) float64 {
+	return testutil.ToFloat64(metrics.CacheRequests.WithLabelValues(metrics.CacheTimeline, tier, metrics.CacheHit))
+}
+
+// Sin cliente de DynamoDB: todas las lecturas del test se resuelven en L1 o
+// L2.
+func TestGet_FallsBackFromL1ToL2(t *testing.T) {
+	ctx := context.Background()
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+
+	redis := newFakeRedis()
+	local := localcache.New[dmntimeline.Timeline](10, 50*time.Millisecond)
+	repo := repository.NewTimelineRepository(nil, redis, "timelines", true, 0, repository.CacheOptions{
+		Enabled: true,
+		TTL:     time.Minute,
+		Local:   local,
+	}, log)
+
+	cached := dmntimeline.Timeline{UserID: "user-1", Entries: []dmntimeline.TimelineEntry{{TweetID: "twt-1", AuthorID: "user-2"}}}
+	fill(t, repo, cached)
+
+	l1, l2 := cacheHits(metrics.CacheTierL1), cacheHits(metrics.CacheTierL2)
+
+	// L1 vacío: se lee de Redis y se guarda en memoria.
+	timeline, hit, err := repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, "twt-1", timeline.Entries[0].TweetID)
+	assert.Equal(t, l2+1, cacheHits(metrics.CacheTierL2))
+	assert.Equal(t, 1, local.Len())
+
+	// Con la copia en memoria Redis ya no se consulta.
+	require.NoError(t, redis.Del(ctx, "timeline:user-1"))
+	timeline, hit, err = repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, "twt-1", timeline.Entries[0].TweetID)
+	assert.Equal(t, l1+1, cacheHits(metrics.CacheTierL1))
+
+	// Vencida la copia en memoria se vuelve a Redis.
+	fill(t, repo, cached)
+	time.Sleep(60 * time.Millisecond)
+	_, hit, err = repo.Get(ctx, "user-1", 20)
+	require.NoError(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, l2+2, cacheHits(metrics.CacheTierL2))
+	assert.Equal(t, l1+1, cacheHits(metrics.CacheTierL1))
+}
@@ -18,8 +18,15 @@ type DynamoDBClientInterface interface {
 
 type RedisClientInterface interface {
 	Get(ctx context.Context, key string) ([]byte, error)
+	MGet(ctx context.Context, keys ...string) ([][]byte, error)
 	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error)
 	Del(ctx context.Context, keys ...string) error
+	Increment(ctx context.Context, key string, ttl time.Duration) error
+	Publish(ctx context.Context, channel string, payload []byte) error
+	AddMembers(ctx context.Context, key string, members ...string) error
+	PopMembers(ctx context.Context, key string, count int64) ([]string, error)
 }
 
 type Repository interface {
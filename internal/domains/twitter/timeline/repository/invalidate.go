@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+	"go.uber.org/zap"
+)
+
+// invalidateLocal avisa a las instancias del API que descarten el timeline de
+// sus cachés en memoria. Si el aviso se pierde, la entrada vence por TTL.
+func (r *TimelineRepository) invalidateLocal(ctx context.Context, userID string) {
+	if r.cache.Invalidator == nil {
+		return
+	}
+	if err := r.cache.Invalidator.Publish(ctx, localcache.KindTimeline, userID); err != nil {
+		r.logger.Warn("Error publicando invalidación de timeline",
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+}
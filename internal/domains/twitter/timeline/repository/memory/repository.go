@@ -0,0 +1,174 @@
+// Package memory guarda los timelines en memoria con las reglas de la tabla de
+// DynamoDB: una entrada por tweet, ordenadas de la más nueva a la más vieja,
+// con el contenido y la antigüedad máxima según la configuración. Como el
+// mapa ya es la fuente de verdad, no hay caché delante.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+)
+
+// Publisher es el pub/sub por el que se avisan las entradas nuevas: Redis o
+// su reemplazo en memoria.
+type Publisher interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+type Repository struct {
+	publisher    Publisher
+	storeContent bool
+	maxAge       time.Duration
+
+	mu sync.RWMutex
+	// timelines es usuario -> clave de orden -> entrada.
+	timelines    map[string]map[string]dmntimeline.TimelineEntry
+	pendingTrims map[string]struct{}
+}
+
+func NewRepository(publisher Publisher, storeContent bool, maxAge time.Duration) *Repository {
+	return &Repository{
+		publisher:    publisher,
+		storeContent: storeContent,
+		maxAge:       maxAge,
+		timelines:    make(map[string]map[string]dmntimeline.TimelineEntry),
+		pendingTrims: make(map[string]struct{}),
+	}
+}
+
+func (r *Repository) Update(_ context.Context, entry dmntimeline.TimelineEntry, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.put(userID, entry)
+	return nil
+}
+
+// BulkInsert sobrescribe las entradas que ya existen, así que repetir una
+// reconstrucción no duplica entradas.
+func (r *Repository) BulkInsert(_ context.Context, userID string, entries []dmntimeline.TimelineEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range entries {
+		r.put(userID, entry)
+	}
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, bool, error) {
+	timeline, err := r.GetFromDB(ctx, userID, limit)
+	return timeline, false, err
+}
+
+// GetFromDB devuelve las limit entradas más nuevas que no vencieron.
+func (r *Repository) GetFromDB(_ context.Context, userID string, limit int) (dmntimeline.Timeline, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	entries := make([]dmntimeline.TimelineEntry, 0, limit)
+	for _, key := range r.sortedKeys(userID) {
+		if len(entries) == limit {
+			break
+		}
+		entry := r.timelines[userID][key]
+		if entry.TTL != nil && !now.Before(*entry.TTL) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return dmntimeline.Timeline{UserID: userID, Entries: entries}, nil
+}
+
+func (r *Repository) SetCache(context.Context, string, []byte) error {
+	return nil
+}
+
+func (r *Repository) Publish(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return r.publisher.Publish(ctx, dmntimeline.StreamChannel(userID), payload)
+}
+
+func (r *Repository) MarkForTrim(_ context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pendingTrims[userID] = struct{}{}
+	return nil
+}
+
+func (r *Repository) PendingTrims(_ context.Context, count int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var users []string
+	for userID := range r.pendingTrims {
+		if len(users) == count {
+			break
+		}
+		users = append(users, userID)
+		delete(r.pendingTrims, userID)
+	}
+	return users, nil
+}
+
+// Trim borra las entradas que exceden las maxEntries más nuevas y devuelve
+// cuántas borró.
+func (r *Repository) Trim(_ context.Context, userID string, maxEntries int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys := r.sortedKeys(userID)
+	if len(keys) <= maxEntries {
+		return 0, nil
+	}
+	for _, key := range keys[maxEntries:] {
+		delete(r.timelines[userID], key)
+	}
+	return len(keys) - maxEntries, nil
+}
+
+func (r *Repository) put(userID string, entry dmntimeline.TimelineEntry) {
+	if userID == "" || entry.TweetID == "" || entry.CreatedAt.IsZero() {
+		return
+	}
+	if !r.storeContent {
+		entry.Content = ""
+	}
+	if entry.TTL == nil && r.maxAge > 0 {
+		expiresAt := entry.CreatedAt.Add(r.maxAge)
+		entry.TTL = &expiresAt
+	}
+
+	timeline, ok := r.timelines[userID]
+	if !ok {
+		timeline = make(map[string]dmntimeline.TimelineEntry)
+		r.timelines[userID] = timeline
+	}
+	timeline[sortKey(entry)] = entry
+}
+
+// sortedKeys devuelve las claves del timeline de la entrada más nueva a la
+// más vieja.
+func (r *Repository) sortedKeys(userID string) []string {
+	keys := make([]string, 0, len(r.timelines[userID]))
+	for key := range r.timelines[userID] {
+		keys = append(keys, key)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	return keys
+}
+
+// sortKey replica la clave de orden de la tabla: fecha y tweet.
+func sortKey(entry dmntimeline.TimelineEntry) string {
+	return entry.CreatedAt.UTC().Format(time.RFC3339) + "#" + entry.TweetID
+}
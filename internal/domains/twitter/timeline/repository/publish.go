@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"go.uber.org/zap"
+)
+
+// Publish avisa por Redis pub/sub a las instancias de la API que el timeline
+// del usuario tiene una nueva entrada.
+func (r *TimelineRepository) Publish(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		r.logger.Error("Error serializando entrada de timeline para publicar", zap.Error(err))
+		return err
+	}
+	return r.redisClient.Publish(ctx, dmntimeline.StreamChannel(userID), payload)
+}
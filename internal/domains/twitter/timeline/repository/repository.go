@@ -1,13 +1,20 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/juanmalvarez3/twit/pkg/logger"
+	"golang.org/x/sync/singleflight"
 )
 
 type TimelineRepository struct {
 	dynamoDBClient DynamoDBClientInterface
 	redisClient    RedisClientInterface
 	tableName      string
+	storeContent   bool
+	maxAge         time.Duration
+	cache          CacheOptions
+	loads          *singleflight.Group
 	logger         *logger.Logger
 }
 
@@ -15,6 +22,9 @@ func NewTimelineRepository(
 	dynamoDBClient DynamoDBClientInterface,
 	redisClient RedisClientInterface,
 	tableName string,
+	storeContent bool,
+	maxAge time.Duration,
+	cache CacheOptions,
 	logger *logger.Logger) *TimelineRepository {
 
 	if logger == nil {
@@ -27,6 +37,10 @@ func NewTimelineRepository(
 		dynamoDBClient: dynamoDBClient,
 		redisClient:    redisClient,
 		tableName:      tableName,
+		storeContent:   storeContent,
+		maxAge:         maxAge,
+		cache:          cache,
+		loads:          &singleflight.Group{},
 		logger:         namedLogger,
 	}
 }
@@ -2,16 +2,18 @@ package repository
 
 import (
 	"context"
-	"time"
-
 	"go.uber.org/zap"
 )
 
 func (r *TimelineRepository) SetCache(ctx context.Context, key string, value []byte) error {
+	if !r.cache.Enabled {
+		return nil
+	}
+
 	r.logger.Debug("Guardando datos en caché",
 		zap.String("key", key))
 
-	ttl := 1 * time.Minute
+	ttl := r.cache.ttl()
 
 	err := r.redisClient.Set(ctx, key, value, ttl)
 	if err != nil {
@@ -0,0 +1,229 @@
+// Package sqlstore guarda los timelines en una base relacional con las reglas
+// de la tabla de DynamoDB: una fila por tweet con clave de orden fecha#tweet,
+// el contenido y la antigüedad máxima según la configuración. No hay caché
+// delante; el pub/sub de entradas nuevas sigue siendo el de la caché.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+// Publisher es el pub/sub por el que se avisan las entradas nuevas: Redis o
+// su reemplazo en memoria.
+type Publisher interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+type Repository struct {
+	db           *sqldb.DB
+	publisher    Publisher
+	storeContent bool
+	maxAge       time.Duration
+}
+
+func NewRepository(db *sqldb.DB, publisher Publisher, storeContent bool, maxAge time.Duration) *Repository {
+	return &Repository{
+		db:           db,
+		publisher:    publisher,
+		storeContent: storeContent,
+		maxAge:       maxAge,
+	}
+}
+
+func (r *Repository) Update(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error {
+	return r.db.InTx(ctx, func(tx *sqldb.Tx) error {
+		return r.put(ctx, tx, userID, entry)
+	})
+}
+
+// BulkInsert sobrescribe las entradas que ya existen, así que repetir una
+// reconstrucción no duplica entradas.
+func (r *Repository) BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.InTx(ctx, func(tx *sqldb.Tx) error {
+		for _, entry := range entries {
+			if err := r.put(ctx, tx, userID, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, bool, error) {
+	timeline, err := r.GetFromDB(ctx, userID, limit)
+	return timeline, false, err
+}
+
+// GetFromDB devuelve las limit entradas más nuevas que no vencieron. Las
+// vencidas quedan en la tabla hasta el próximo Trim.
+func (r *Repository) GetFromDB(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT tweet_id, author_id, content, created_at, expires_at
+		FROM timeline_entries
+		WHERE user_id = ? AND (expires_at IS NULL OR expires_at > ?)
+		ORDER BY sort_key DESC LIMIT ?`, userID, time.Now().Unix(), limit)
+	if err != nil {
+		return dmntimeline.Timeline{}, err
+	}
+	defer rows.Close()
+
+	entries := make([]dmntimeline.TimelineEntry, 0, limit)
+	for rows.Next() {
+		var (
+			entry     dmntimeline.TimelineEntry
+			createdAt string
+			expiresAt sql.NullInt64
+		)
+		if err := rows.Scan(&entry.TweetID, &entry.AuthorID, &entry.Content, &createdAt, &expiresAt); err != nil {
+			return dmntimeline.Timeline{}, err
+		}
+		if entry.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return dmntimeline.Timeline{}, err
+		}
+		if expiresAt.Valid {
+			ttl := time.Unix(expiresAt.Int64, 0).UTC()
+			entry.TTL = &ttl
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return dmntimeline.Timeline{}, err
+	}
+	return dmntimeline.Timeline{UserID: userID, Entries: entries}, nil
+}
+
+func (r *Repository) SetCache(context.Context, string, []byte) error {
+	return nil
+}
+
+func (r *Repository) Publish(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return r.publisher.Publish(ctx, dmntimeline.StreamChannel(userID), payload)
+}
+
+func (r *Repository) MarkForTrim(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO timeline_pending_trims (user_id) VALUES (?) ON CONFLICT DO NOTHING", userID)
+	return err
+}
+
+// PendingTrims saca hasta count usuarios anotados para recorte.
+func (r *Repository) PendingTrims(ctx context.Context, count int) ([]string, error) {
+	var users []string
+	err := r.db.InTx(ctx, func(tx *sqldb.Tx) error {
+		rows, err := tx.QueryContext(ctx, "SELECT user_id FROM timeline_pending_trims ORDER BY user_id LIMIT ?", count)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var userID string
+			if err := rows.Scan(&userID); err != nil {
+				return err
+			}
+			users = append(users, userID)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+
+		for _, userID := range users {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM timeline_pending_trims WHERE user_id = ?", userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Trim borra las entradas que exceden las maxEntries más nuevas y devuelve
+// cuántas borró. Como la base no tiene TTL, también borra las vencidas.
+func (r *Repository) Trim(ctx context.Context, userID string, maxEntries int) (int, error) {
+	var deleted int64
+	err := r.db.InTx(ctx, func(tx *sqldb.Tx) error {
+		expired, err := tx.ExecContext(ctx, "DELETE FROM timeline_entries WHERE user_id = ? AND expires_at <= ?",
+			userID, time.Now().Unix())
+		if err != nil {
+			return err
+		}
+		if deleted, err = expired.RowsAffected(); err != nil {
+			return err
+		}
+
+		// La primera clave que queda afuera: se borra esa y todas las más
+		// viejas.
+		var boundary string
+		err = tx.QueryRowContext(ctx, `SELECT sort_key FROM timeline_entries WHERE user_id = ?
+			ORDER BY sort_key DESC LIMIT 1 OFFSET ?`, userID, maxEntries).Scan(&boundary)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		beyond, err := tx.ExecContext(ctx, "DELETE FROM timeline_entries WHERE user_id = ? AND sort_key <= ?", userID, boundary)
+		if err != nil {
+			return err
+		}
+		count, err := beyond.RowsAffected()
+		deleted += count
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
+
+func (r *Repository) put(ctx context.Context, tx *sqldb.Tx, userID string, entry dmntimeline.TimelineEntry) error {
+	if userID == "" || entry.TweetID == "" || entry.CreatedAt.IsZero() {
+		return nil
+	}
+	if !r.storeContent {
+		entry.Content = ""
+	}
+	if entry.TTL == nil && r.maxAge > 0 {
+		expiresAt := entry.CreatedAt.Add(r.maxAge)
+		entry.TTL = &expiresAt
+	}
+	var expiresAt sql.NullInt64
+	if entry.TTL != nil {
+		expiresAt = sql.NullInt64{Int64: entry.TTL.Unix(), Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO timeline_entries
+		(user_id, sort_key, tweet_id, author_id, content, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id, sort_key) DO UPDATE SET
+			tweet_id = excluded.tweet_id,
+			author_id = excluded.author_id,
+			content = excluded.content,
+			created_at = excluded.created_at,
+			expires_at = excluded.expires_at`,
+		userID, sortKey(entry), entry.TweetID, entry.AuthorID, entry.Content,
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano), expiresAt)
+	return err
+}
+
+// sortKey replica la clave de orden de la tabla: fecha y tweet.
+func sortKey(entry dmntimeline.TimelineEntry) string {
+	return entry.CreatedAt.UTC().Format(time.RFC3339) + "#" + entry.TweetID
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxBatchWriteItems es el límite de operaciones por BatchWriteItem.
+	maxBatchWriteItems    = 25
+	maxBatchWriteAttempts = 3
+)
+
+// MarkForTrim anota al usuario para que el job de recorte revise su timeline.
+func (r *TimelineRepository) MarkForTrim(ctx context.Context, userID string) error {
+	return r.redisClient.AddMembers(ctx, keyTrimPending, userID)
+}
+
+// PendingTrims saca hasta count usuarios anotados para recorte.
+func (r *TimelineRepository) PendingTrims(ctx context.Context, count int) ([]string, error) {
+	return r.redisClient.PopMembers(ctx, keyTrimPending, int64(count))
+}
+
+// Trim borra las entradas del timeline que exceden las maxEntries más nuevas y
+// descarta su caché. Devuelve la cantidad de entradas borradas.
+func (r *TimelineRepository) Trim(ctx context.Context, userID string, maxEntries int) (int, error) {
+	keys, err := r.keysBeyond(ctx, userID, maxEntries)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	requests := make([]types.WriteRequest, len(keys))
+	for i, key := range keys {
+		requests[i] = types.WriteRequest{DeleteRequest: &types.DeleteRequest{Key: key}}
+	}
+	if written, err := r.batchWrite(ctx, requests); err != nil {
+		r.logger.Error("Error borrando entradas de timeline",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return written, err
+	}
+
+	r.discardCache(ctx, userID)
+
+	r.logger.Debug("Timeline recortado",
+		zap.String("user_id", userID),
+		zap.Int("deleted", len(keys)))
+
+	return len(keys), nil
+}
+
+// keysBeyond recorre el timeline del más nuevo al más viejo y devuelve las
+// claves de las entradas que quedan fuera de las maxEntries primeras.
+func (r *TimelineRepository) keysBeyond(ctx context.Context, userID string, maxEntries int) ([]map[string]types.AttributeValue, error) {
+	keyEx := expression.Key("user_id").Equal(expression.Value(userID))
+	projection := expression.NamesList(expression.Name("user_id"), expression.Name("SK"))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyEx).WithProjection(projection).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		keys     []map[string]types.AttributeValue
+		seen     int
+		startKey map[string]types.AttributeValue
+	)
+	for {
+		result, err := r.dynamoDBClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(r.tableName),
+			KeyConditionExpression:    expr.KeyCondition(),
+			ProjectionExpression:      expr.Projection(),
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			ScanIndexForward:          aws.Bool(false),
+			ExclusiveStartKey:         startKey,
+		})
+		if err != nil {
+			r.logger.Error("Error consultando timeline para recortar",
+				zap.String("user_id", userID),
+				zap.Error(err))
+			return nil, err
+		}
+
+		for _, item := range result.Items {
+			seen++
+			if seen > maxEntries {
+				keys = append(keys, item)
+			}
+		}
+
+		if len(result.LastEvaluatedKey) == 0 {
+			return keys, nil
+		}
+		startKey = result.LastEvaluatedKey
+	}
+}
+
+// batchWrite aplica requests en lotes de maxBatchWriteItems y devuelve cuántas
+// se completaron antes del primer lote fallido.
+func (r *TimelineRepository) batchWrite(ctx context.Context, requests []types.WriteRequest) (int, error) {
+	for start := 0; start < len(requests); start += maxBatchWriteItems {
+		end := min(start+maxBatchWriteItems, len(requests))
+		if err := r.writeChunk(ctx, requests[start:end]); err != nil {
+			return start, err
+		}
+	}
+	return len(requests), nil
+}
+
+func (r *TimelineRepository) writeChunk(ctx context.Context, requests []types.WriteRequest) error {
+	requestItems := map[string][]types.WriteRequest{r.tableName: requests}
+	for attempt := 1; len(requestItems) > 0; attempt++ {
+		if attempt > maxBatchWriteAttempts {
+			return fmt.Errorf("quedaron %d escrituras sin procesar tras %d intentos", len(requestItems[r.tableName]), maxBatchWriteAttempts)
+		}
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt*50) * time.Millisecond):
+			}
+		}
+
+		result, err := r.dynamoDBClient.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: requestItems,
+		})
+		if err != nil {
+			return err
+		}
+		requestItems = result.UnprocessedItems
+	}
+	return nil
+}
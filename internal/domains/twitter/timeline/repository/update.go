@@ -5,14 +5,14 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/repository/daos"
 	"go.uber.org/zap"
 )
 
 func (r *TimelineRepository) Update(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error {
-	da := daos.ToTimelineEntryDAO(userID, entry)
-	item, err := attributevalue.MarshalMap(da)
+	item, err := r.marshalEntry(userID, entry)
 	if err != nil {
 		r.logger.Error("Error serializando entrada de timeline", zap.Error(err))
 		return err
@@ -27,5 +27,42 @@ func (r *TimelineRepository) Update(ctx context.Context, entry dmntimeline.Timel
 		r.logger.Error("Error al insertar entrada en DynamoDB", zap.Error(err))
 		return err
 	}
+
+	r.discardCache(ctx, userID)
 	return nil
 }
+
+// marshalEntry arma el ítem de DynamoDB de una entrada según el modo de
+// almacenamiento y la antigüedad máxima configurados.
+func (r *TimelineRepository) marshalEntry(userID string, entry dmntimeline.TimelineEntry) (map[string]types.AttributeValue, error) {
+	if !r.storeContent {
+		entry.Content = ""
+	}
+	if entry.TTL == nil && r.maxAge > 0 {
+		expiresAt := entry.CreatedAt.Add(r.maxAge)
+		entry.TTL = &expiresAt
+	}
+	return attributevalue.MarshalMap(daos.ToTimelineEntryDAO(userID, entry))
+}
+
+// discardCache descarta el timeline cacheado en vez de actualizarlo, para no
+// pisar una carga concurrente; la próxima lectura lo vuelve a poblar. Antes de
+// borrarlo sube la versión del caché: una carga que leyó DynamoDB antes de
+// esta escritura guarda su timeline con la versión vieja y las lecturas lo
+// ignoran, aunque llegue después del borrado.
+func (r *TimelineRepository) discardCache(ctx context.Context, userID string) {
+	if !r.cache.Enabled {
+		return
+	}
+	if err := r.redisClient.Increment(ctx, prefixCacheVersion+userID, r.cache.versionTTL()); err != nil {
+		r.logger.Warn("Error actualizando versión de timeline en caché",
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+	if err := r.redisClient.Del(ctx, prefixCache+userID); err != nil {
+		r.logger.Warn("Error descartando timeline en caché",
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+	r.invalidateLocal(ctx, userID)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"go.uber.org/zap"
+)
+
+// BulkInsert guarda de una vez las entradas de un timeline reconstruido.
+func (s Service) BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error {
+	if err := s.timelineRepo.BulkInsert(ctx, userID, entries); err != nil {
+		s.logger.Error("Error al guardar timeline reconstruido",
+			zap.String("action", actionBulkInsert),
+			zap.String("user_id", userID),
+			zap.Int("entries_count", len(entries)),
+			zap.Error(err))
+		return err
+	}
+
+	if err := s.timelineRepo.MarkForTrim(ctx, userID); err != nil {
+		s.logger.Warn("Error anotando timeline para recorte",
+			zap.String("action", actionBulkInsert),
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+
+	s.logger.Debug("Timeline reconstruido guardado",
+		zap.String("action", actionBulkInsert),
+		zap.String("user_id", userID),
+		zap.Int("entries_count", len(entries)))
+
+	return nil
+}
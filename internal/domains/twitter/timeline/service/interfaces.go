@@ -9,9 +9,14 @@ import (
 
 type Repository interface {
 	Update(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error
+	BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error
 	Get(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, bool, error)
 	GetFromDB(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, error)
 	SetCache(ctx context.Context, key string, value []byte) error
+	Publish(ctx context.Context, entry dmntimeline.TimelineEntry, userID string) error
+	MarkForTrim(ctx context.Context, userID string) error
+	PendingTrims(ctx context.Context, count int) ([]string, error)
+	Trim(ctx context.Context, userID string, maxEntries int) (int, error)
 }
 
 type Publisher interface {
@@ -9,6 +9,9 @@ const (
 
 	actionUpdate = "update"
 	actionGet    = "get"
+	actionTrim   = "trim"
+
+	actionBulkInsert = "bulk_insert"
 )
 
 type action string
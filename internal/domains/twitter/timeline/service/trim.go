@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// PendingTrims devuelve hasta count usuarios cuyo timeline recibió entradas
+// desde el último recorte.
+func (s Service) PendingTrims(ctx context.Context, count int) ([]string, error) {
+	userIDs, err := s.timelineRepo.PendingTrims(ctx, count)
+	if err != nil {
+		s.logger.Error("Error obteniendo timelines pendientes de recorte",
+			zap.String("action", actionTrim),
+			zap.Error(err))
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// Trim deja el timeline del usuario en sus maxEntries entradas más nuevas.
+func (s Service) Trim(ctx context.Context, userID string, maxEntries int) (int, error) {
+	deleted, err := s.timelineRepo.Trim(ctx, userID, maxEntries)
+	if err != nil {
+		s.logger.Error("Error al recortar timeline",
+			zap.String("action", actionTrim),
+			zap.String("user_id", userID),
+			zap.Error(err))
+		return deleted, err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("Timeline recortado",
+			zap.String("action", actionTrim),
+			zap.String("user_id", userID),
+			zap.Int("deleted", deleted))
+	}
+	return deleted, nil
+}
+
+// MarkForTrim vuelve a anotar al usuario para el próximo recorte.
+func (s Service) MarkForTrim(ctx context.Context, userID string) error {
+	return s.timelineRepo.MarkForTrim(ctx, userID)
+}
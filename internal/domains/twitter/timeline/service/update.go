@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	"go.uber.org/zap"
@@ -13,7 +12,6 @@ func (s Service) Update(ctx context.Context, entry dmntimeline.TimelineEntry, us
 		zap.String("action", actionUpdate),
 		zap.String("user_id", userID))
 
-	fmt.Println("Obteniendo timeline del repositorio con userID:", userID)
 	err := s.timelineRepo.Update(ctx, entry, userID)
 	if err != nil {
 		s.logger.Error("Error al actualizar timeline",
@@ -23,6 +21,25 @@ func (s Service) Update(ctx context.Context, entry dmntimeline.TimelineEntry, us
 		return err
 	}
 
+	// El aviso a los streams abiertos es best-effort: un cliente que pierda el
+	// mensaje lo recupera al reconectar con Last-Event-ID.
+	if err := s.timelineRepo.Publish(ctx, entry, userID); err != nil {
+		s.logger.Warn("Error publicando entrada de timeline para streaming",
+			zap.String("action", actionUpdate),
+			zap.String("user_id", userID),
+			zap.String("tweet_id", entry.TweetID),
+			zap.Error(err))
+	}
+
+	// Si el usuario no queda anotado, se anota en su próxima entrada; hasta
+	// entonces el TTL de DynamoDB sigue acotando el timeline por antigüedad.
+	if err := s.timelineRepo.MarkForTrim(ctx, userID); err != nil {
+		s.logger.Warn("Error anotando timeline para recorte",
+			zap.String("action", actionUpdate),
+			zap.String("user_id", userID),
+			zap.Error(err))
+	}
+
 	s.logger.Debug("Timeline actualizado exitosamente",
 		zap.String("action", actionUpdate),
 		zap.String("user_id", userID))
@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"go.uber.org/zap"
+)
+
+const resubscribeDelay = time.Second
+
+type Subscriber interface {
+	PSubscribe(ctx context.Context, patterns ...string) (<-chan redisadapter.Message, error)
+}
+
+type Logger interface {
+	Warn(msg string, fields ...zap.Field)
+}
+
+// Hub reparte las entradas publicadas por los workers entre las conexiones
+// de streaming abiertas en esta instancia. Mantiene una única suscripción a
+// Redis sin importar cuántos clientes haya conectados.
+type Hub struct {
+	subscriber  Subscriber
+	bufferSize  int
+	logger      Logger
+	mu          sync.Mutex
+	subscribers map[string]map[chan dmntimeline.TimelineEntry]struct{}
+}
+
+func NewHub(subscriber Subscriber, bufferSize int, log Logger) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	return &Hub{
+		subscriber:  subscriber,
+		bufferSize:  bufferSize,
+		logger:      log,
+		subscribers: make(map[string]map[chan dmntimeline.TimelineEntry]struct{}),
+	}
+}
+
+// Run consume el canal de Redis hasta que se cancele ctx, volviendo a
+// suscribirse si la suscripción se corta.
+func (h *Hub) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := h.subscriber.PSubscribe(ctx, dmntimeline.StreamChannelPattern)
+		if err != nil {
+			h.logger.Warn("Error suscribiendo al stream de timelines, reintentando", zap.Error(err))
+		} else {
+			for msg := range messages {
+				h.dispatch(msg)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(resubscribeDelay):
+		}
+	}
+	h.closeAll()
+}
+
+// Subscribe registra un cliente para el timeline de userID. El canal se
+// cierra al llamar a la función devuelta o si el cliente no consume a tiempo;
+// en ese caso debe reconectar con Last-Event-ID para recuperar lo perdido.
+func (h *Hub) Subscribe(userID string) (<-chan dmntimeline.TimelineEntry, func()) {
+	ch := make(chan dmntimeline.TimelineEntry, h.bufferSize)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan dmntimeline.TimelineEntry]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.remove(userID, ch)
+		})
+	}
+}
+
+func (h *Hub) dispatch(msg redisadapter.Message) {
+	userID, ok := dmntimeline.UserIDFromStreamChannel(msg.Channel)
+	if !ok {
+		return
+	}
+
+	var entry dmntimeline.TimelineEntry
+	if err := json.Unmarshal(msg.Payload, &entry); err != nil {
+		h.logger.Warn("Entrada de timeline inválida en el stream",
+			zap.String("channel", msg.Channel),
+			zap.Error(err))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- entry:
+		default:
+			h.logger.Warn("Cliente de stream lento, se cierra la conexión",
+				zap.String("user_id", userID))
+			h.remove(userID, ch)
+		}
+	}
+}
+
+// remove debe llamarse con h.mu tomado. Es idempotente porque un canal puede
+// haberse quitado ya por lento antes de que el cliente cancele.
+func (h *Hub) remove(userID string, ch chan dmntimeline.TimelineEntry) {
+	channels := h.subscribers[userID]
+	if _, ok := channels[ch]; !ok {
+		return
+	}
+	delete(channels, ch)
+	close(ch)
+	if len(channels) == 0 {
+		delete(h.subscribers, userID)
+	}
+}
+
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for userID, channels := range h.subscribers {
+		for ch := range channels {
+			h.remove(userID, ch)
+		}
+	}
+}
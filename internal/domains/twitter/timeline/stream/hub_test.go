@@ -0,0 +1,100 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/stream"
+)
+
+type fakeSubscriber struct {
+	messages chan redisadapter.Message
+}
+
+func (f *fakeSubscriber) PSubscribe(ctx context.Context, patterns ...string) (<-chan redisadapter.Message, error) {
+	return f.messages, nil
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Warn(string, ...zap.Field) {}
+
+func publish(t *testing.T, sub *fakeSubscriber, userID string, entry dmntimeline.TimelineEntry) {
+	payload, err := json.Marshal(entry)
+	require.NoError(t, err)
+	sub.messages <- redisadapter.Message{Channel: dmntimeline.StreamChannel(userID), Payload: payload}
+}
+
+func receive(t *testing.T, ch <-chan dmntimeline.TimelineEntry) (dmntimeline.TimelineEntry, bool) {
+	select {
+	case entry, ok := <-ch:
+		return entry, ok
+	case <-time.After(time.Second):
+		t.Fatal("timeout esperando entrada del hub")
+		return dmntimeline.TimelineEntry{}, false
+	}
+}
+
+func TestHub_DispatchesToUserSubscribers(t *testing.T) {
+	sub := &fakeSubscriber{messages: make(chan redisadapter.Message)}
+	hub := stream.NewHub(sub, 4, nopLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+
+	mine, cancelMine := hub.Subscribe("user-1")
+	defer cancelMine()
+	other, cancelOther := hub.Subscribe("user-2")
+	defer cancelOther()
+
+	publish(t, sub, "user-1", dmntimeline.TimelineEntry{TweetID: "tweet-1"})
+
+	entry, ok := receive(t, mine)
+	assert.True(t, ok)
+	assert.Equal(t, "tweet-1", entry.TweetID)
+	assert.Empty(t, other)
+}
+
+func TestHub_ClosesSlowSubscriber(t *testing.T) {
+	sub := &fakeSubscriber{messages: make(chan redisadapter.Message)}
+	hub := stream.NewHub(sub, 1, nopLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go hub.Run(ctx)
+
+	ch, unsubscribe := hub.Subscribe("user-1")
+	defer unsubscribe()
+
+	publish(t, sub, "user-1", dmntimeline.TimelineEntry{TweetID: "tweet-1"})
+	publish(t, sub, "user-1", dmntimeline.TimelineEntry{TweetID: "tweet-2"})
+	// El tercer mensaje garantiza que el segundo ya se despachó.
+	publish(t, sub, "user-1", dmntimeline.TimelineEntry{TweetID: "tweet-3"})
+
+	entry, ok := receive(t, ch)
+	assert.True(t, ok)
+	assert.Equal(t, "tweet-1", entry.TweetID)
+
+	_, ok = receive(t, ch)
+	assert.False(t, ok)
+}
+
+func TestHub_UnsubscribeClosesChannel(t *testing.T) {
+	hub := stream.NewHub(&fakeSubscriber{messages: make(chan redisadapter.Message)}, 1, nopLogger{})
+
+	ch, unsubscribe := hub.Subscribe("user-1")
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := receive(t, ch)
+	assert.False(t, ok)
+}
@@ -1,20 +1,36 @@
 package fallbacktimeline
 
 import (
+	"container/heap"
 	"context"
+	"errors"
 	"fmt"
+
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	dmnoptions "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
+var errNoAuthorsAvailable = errors.New("no se pudo leer ningún seguido")
+
+// Exec trae la primera página de tweets de cada seguido en paralelo y las
+// mezcla de la más nueva a la más vieja hasta completar maxEntries, pidiendo
+// más páginas sólo de los seguidos que lo necesitan. Un seguido cuya lectura
+// falla se omite; la reconstrucción falla sólo si fallan todos.
 func (u *UseCase) Exec(ctx context.Context, userID string) error {
+	ctx, span := tracing.Start(ctx, "fallbacktimeline.Exec")
+	defer span.End()
+
 	logger := zap.L().With(zap.String("userId", userID))
 	logger.Info("Iniciando reconstrucción de timeline")
 
 	following, err := u.followsService.GetAllFollowing(ctx, userID)
 	if err != nil {
 		logger.Error("Error obteniendo seguidos", zap.Error(err))
+		tracing.RecordError(span, err)
 		return fmt.Errorf("error obteniendo seguidos: %w", err)
 	}
 
@@ -23,40 +39,104 @@ func (u *UseCase) Exec(ctx context.Context, userID string) error {
 		return nil
 	}
 
-	var allTimelineEntries []dmntimeline.TimelineEntry
+	feeds, failed := u.firstPages(ctx, following, logger)
+	if failed == len(following) {
+		tracing.RecordError(span, errNoAuthorsAvailable)
+		return fmt.Errorf("error obteniendo tweets de seguidos: %w", errNoAuthorsAvailable)
+	}
+
+	entries := u.merge(ctx, feeds, logger)
+	if len(entries) == 0 {
+		logger.Info("No se encontraron tweets para reconstruir timeline")
+		return nil
+	}
+
+	if err := u.timelineService.BulkInsert(ctx, userID, entries); err != nil {
+		logger.Error("Error guardando timeline reconstruido", zap.Error(err))
+		tracing.RecordError(span, err)
+		return fmt.Errorf("error guardando timeline reconstruido: %w", err)
+	}
+
+	logger.Info("Timeline reconstruido exitosamente",
+		zap.Int("entriesCount", len(entries)),
+		zap.Int("followingCount", len(following)),
+		zap.Int("failedAuthors", failed))
+	return nil
+}
 
-	for _, follow := range following {
-		tweets, _, err := u.tweetService.Search(ctx, dmnoptions.SearchOptions{
-			Filters:    dmnoptions.SearchFilters{UserID: &follow},
-			Pagination: dmnoptions.SearchPagination{Limit: 10, Offset: 0},
+// firstPages lee la primera página de cada seguido con a lo sumo concurrency
+// lecturas en curso. Devuelve los feeds con tweets y cuántas lecturas fallaron.
+func (u *UseCase) firstPages(ctx context.Context, following []string, logger *zap.Logger) ([]*authorFeed, int) {
+	feeds := make([]*authorFeed, len(following))
+	failures := make([]bool, len(following))
+
+	var group errgroup.Group
+	group.SetLimit(u.concurrency)
+	for i, authorID := range following {
+		group.Go(func() error {
+			tweets, cursor, err := u.search(ctx, authorID, "")
+			if err != nil {
+				logger.Warn("Error obteniendo tweets del usuario seguido, se omite",
+					zap.Error(err),
+					zap.String("followedId", authorID))
+				failures[i] = true
+				return nil
+			}
+			if len(tweets) > 0 {
+				feeds[i] = newAuthorFeed(authorID, tweets, cursor)
+			}
+			return nil
 		})
-		if err != nil {
-			logger.Error("Error obteniendo tweets del usuario seguido",
-				zap.Error(err),
-				zap.String("followedId", follow))
-			return fmt.Errorf("error obteniendo tweets para usuario %s: %w", follow, err)
-		}
+	}
+	_ = group.Wait()
 
-		for _, tweet := range tweets {
-			timelineEntry := dmntimeline.NewTimelineEntryFromTweet(tweet)
-			allTimelineEntries = append(allTimelineEntries, timelineEntry)
+	result := make([]*authorFeed, 0, len(feeds))
+	failed := 0
+	for i, feed := range feeds {
+		if failures[i] {
+			failed++
+		}
+		if feed != nil {
+			result = append(result, feed)
 		}
 	}
+	return result, failed
+}
 
-	if len(allTimelineEntries) == 0 {
-		logger.Info("No se encontraron tweets para reconstruir timeline")
-		return nil
-	}
+// merge hace un merge de k vías de los feeds y corta al llegar a maxEntries.
+func (u *UseCase) merge(ctx context.Context, feeds []*authorFeed, logger *zap.Logger) []dmntimeline.TimelineEntry {
+	h := feedHeap(feeds)
+	heap.Init(&h)
+
+	entries := make([]dmntimeline.TimelineEntry, 0, u.maxEntries)
+	for h.Len() > 0 && len(entries) < u.maxEntries {
+		feed := h[0]
+		entries = append(entries, feed.head())
+		feed.entries = feed.entries[1:]
 
-	dmntimeline.SortEntriesByTime(allTimelineEntries)
+		if len(feed.entries) == 0 && feed.cursor != "" {
+			tweets, cursor, err := u.search(ctx, feed.authorID, feed.cursor)
+			if err != nil {
+				logger.Warn("Error obteniendo más tweets del usuario seguido, se omite el resto",
+					zap.Error(err),
+					zap.String("followedId", feed.authorID))
+			} else {
+				feed.setPage(tweets, cursor)
+			}
+		}
 
-	if u.publisher != nil {
-		if err := u.publisher.Publish(ctx, userID, allTimelineEntries); err != nil {
-			logger.Error("Error publicando timeline reconstruido", zap.Error(err))
-			return fmt.Errorf("error publicando timeline reconstruido: %w", err)
+		if len(feed.entries) == 0 {
+			heap.Pop(&h)
+			continue
 		}
-		logger.Info("Timeline reconstruido exitosamente", zap.Int("entriesCount", len(allTimelineEntries)))
+		heap.Fix(&h, 0)
 	}
+	return entries
+}
 
-	return nil
+func (u *UseCase) search(ctx context.Context, authorID, cursor string) ([]dmntweet.Tweet, string, error) {
+	return u.tweetService.Search(ctx, dmnoptions.SearchOptions{
+		Filters:    dmnoptions.SearchFilters{UserID: &authorID},
+		Pagination: dmnoptions.SearchPagination{Limit: u.pageSize, Cursor: cursor},
+	})
 }
@@ -15,14 +15,6 @@ type TweetService interface {
 	Search(ctx context.Context, opts dmnoptions.SearchOptions) ([]dmntweet.Tweet, string, error)
 }
 
-type Publisher interface {
-	Publish(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error
-}
-
 type TimelineService interface {
 	BulkInsert(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error
 }
-
-type CacheService interface {
-	SetTimeline(ctx context.Context, timeline dmntimeline.Timeline) error
-}
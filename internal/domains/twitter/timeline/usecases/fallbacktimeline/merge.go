@@ -0,0 +1,61 @@
+package fallbacktimeline
+
+import (
+	"container/heap"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+// authorFeed es la página en curso de tweets de un seguido, del más nuevo al
+// más viejo, y el cursor para pedir la siguiente.
+type authorFeed struct {
+	authorID string
+	entries  []dmntimeline.TimelineEntry
+	cursor   string
+}
+
+func newAuthorFeed(authorID string, tweets []dmntweet.Tweet, cursor string) *authorFeed {
+	feed := &authorFeed{authorID: authorID}
+	feed.setPage(tweets, cursor)
+	return feed
+}
+
+func (f *authorFeed) setPage(tweets []dmntweet.Tweet, cursor string) {
+	f.entries = make([]dmntimeline.TimelineEntry, 0, len(tweets))
+	for _, tweet := range tweets {
+		f.entries = append(f.entries, dmntimeline.NewTimelineEntryFromTweet(tweet))
+	}
+	f.cursor = cursor
+}
+
+func (f *authorFeed) head() dmntimeline.TimelineEntry {
+	return f.entries[0]
+}
+
+// feedHeap ordena los feeds por su entrada más nueva pendiente, así la cima
+// es siempre la próxima entrada del timeline.
+type feedHeap []*authorFeed
+
+func (h feedHeap) Len() int { return len(h) }
+
+func (h feedHeap) Less(i, j int) bool {
+	a, b := h[i].head(), h[j].head()
+	if a.CreatedAt.Equal(b.CreatedAt) {
+		return a.TweetID > b.TweetID
+	}
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+func (h feedHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *feedHeap) Push(x any) { *h = append(*h, x.(*authorFeed)) }
+
+func (h *feedHeap) Pop() any {
+	old := *h
+	feed := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return feed
+}
+
+var _ heap.Interface = (*feedHeap)(nil)
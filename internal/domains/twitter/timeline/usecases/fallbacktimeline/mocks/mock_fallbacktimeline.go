@@ -26,15 +26,6 @@ func (m *TweetService) Search(ctx context.Context, opts dmnoptions.SearchOptions
 	return args.Get(0).([]dmntweet.Tweet), args.String(1), args.Error(2)
 }
 
-type Publisher struct {
-	mock.Mock
-}
-
-func (m *Publisher) Publish(ctx context.Context, userID string, entries []dmntimeline.TimelineEntry) error {
-	args := m.Called(ctx, userID, entries)
-	return args.Error(0)
-}
-
 type TimelineService struct {
 	mock.Mock
 }
@@ -43,12 +34,3 @@ func (m *TimelineService) BulkInsert(ctx context.Context, userID string, entries
 	args := m.Called(ctx, userID, entries)
 	return args.Error(0)
 }
-
-type CacheService struct {
-	mock.Mock
-}
-
-func (m *CacheService) SetTimeline(ctx context.Context, timeline dmntimeline.Timeline) error {
-	args := m.Called(ctx, timeline)
-	return args.Error(0)
-}
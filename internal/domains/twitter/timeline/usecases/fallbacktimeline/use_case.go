@@ -1,13 +1,26 @@
 package fallbacktimeline
 
+// UseCase reconstruye el timeline de un usuario a partir de los tweets de sus
+// seguidos y lo guarda directamente en la tabla de timelines.
 type UseCase struct {
-	followsService FollowerService
-	tweetService   TweetService
-	publisher      Publisher
+	followsService  FollowerService
+	tweetService    TweetService
+	timelineService TimelineService
+	// concurrency acota cuántos seguidos se consultan a la vez, pageSize es la
+	// página de tweets pedida por seguido y maxEntries el tamaño del timeline
+	// reconstruido.
+	concurrency int
+	pageSize    int
+	maxEntries  int
 }
 
-func New(followsService FollowerService, tweetService TweetService, publisher Publisher) UseCase {
+func New(followsService FollowerService, tweetService TweetService, timelineService TimelineService, concurrency, pageSize, maxEntries int) UseCase {
 	return UseCase{
-		followsService: followsService,
-		tweetService:   tweetService, publisher: publisher}
+		followsService:  followsService,
+		tweetService:    tweetService,
+		timelineService: timelineService,
+		concurrency:     max(concurrency, 1),
+		pageSize:        max(pageSize, 1),
+		maxEntries:      max(maxEntries, 1),
+	}
 }
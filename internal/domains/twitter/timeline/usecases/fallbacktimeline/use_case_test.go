@@ -16,12 +16,26 @@ import (
 	dmnoptions "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
 )
 
+func searchFor(userID, cursor string) interface{} {
+	return mock.MatchedBy(func(opts dmnoptions.SearchOptions) bool {
+		return opts.Filters.UserID != nil && *opts.Filters.UserID == userID && opts.Pagination.Cursor == cursor
+	})
+}
+
+func tweetIDs(entries []dmntimeline.TimelineEntry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.TweetID
+	}
+	return ids
+}
+
 func TestExec_Success(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	following := []string{"user-2", "user-3"}
@@ -45,16 +59,10 @@ func TestExec_Success(t *testing.T) {
 	}
 
 	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return(following, nil)
+	mockTweetService.On("Search", mock.Anything, searchFor("user-2", "")).Return(tweetsUser2, "", nil)
+	mockTweetService.On("Search", mock.Anything, searchFor("user-3", "")).Return(tweetsUser3, "", nil)
 
-	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts dmnoptions.SearchOptions) bool {
-		return opts.Filters.UserID != nil && *opts.Filters.UserID == "user-2"
-	})).Return(tweetsUser2, "", nil)
-
-	mockTweetService.On("Search", mock.Anything, mock.MatchedBy(func(opts dmnoptions.SearchOptions) bool {
-		return opts.Filters.UserID != nil && *opts.Filters.UserID == "user-3"
-	})).Return(tweetsUser3, "", nil)
-
-	mockPublisher.On("Publish", mock.Anything, userID, mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+	mockTimelineService.On("BulkInsert", mock.Anything, userID, mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
 		if len(entries) != 2 {
 			return false
 		}
@@ -72,15 +80,51 @@ func TestExec_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
+	mockTimelineService.AssertExpectations(t)
+}
+
+func TestExec_MergesPagesUntilMaxEntries(t *testing.T) {
+	mockFollowerService := new(mocks.FollowerService)
+	mockTweetService := new(mocks.TweetService)
+	mockTimelineService := new(mocks.TimelineService)
+
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 2, 2, 4)
+
+	userID := "user-1"
+	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return([]string{"user-2", "user-3"}, nil)
+
+	mockTweetService.On("Search", mock.Anything, searchFor("user-2", "")).Return([]dmntweet.Tweet{
+		{ID: "a-5", UserID: "user-2", CreatedAt: "2025-06-10T05:00:00Z"},
+		{ID: "a-4", UserID: "user-2", CreatedAt: "2025-06-10T04:00:00Z"},
+	}, "cursor-a", nil)
+	mockTweetService.On("Search", mock.Anything, searchFor("user-2", "cursor-a")).Return([]dmntweet.Tweet{
+		{ID: "a-2", UserID: "user-2", CreatedAt: "2025-06-10T02:00:00Z"},
+		{ID: "a-1", UserID: "user-2", CreatedAt: "2025-06-10T01:00:00Z"},
+	}, "cursor-a2", nil)
+	mockTweetService.On("Search", mock.Anything, searchFor("user-3", "")).Return([]dmntweet.Tweet{
+		{ID: "b-3", UserID: "user-3", CreatedAt: "2025-06-10T03:00:00Z"},
+		{ID: "b-0", UserID: "user-3", CreatedAt: "2025-06-10T00:00:00Z"},
+	}, "cursor-b", nil)
+
+	mockTimelineService.On("BulkInsert", mock.Anything, userID, mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+		return assert.ObjectsAreEqual([]string{"a-5", "a-4", "b-3", "a-2"}, tweetIDs(entries))
+	})).Return(nil)
+
+	err := uc.Exec(context.Background(), userID)
+
+	assert.NoError(t, err)
+	mockTweetService.AssertExpectations(t)
+	mockTweetService.AssertNotCalled(t, "Search", mock.Anything, searchFor("user-3", "cursor-b"))
+	mockTweetService.AssertNotCalled(t, "Search", mock.Anything, searchFor("user-2", "cursor-a2"))
+	mockTimelineService.AssertExpectations(t)
 }
 
 func TestExec_NoFollowing(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	var emptyFollowing []string
@@ -92,15 +136,15 @@ func TestExec_NoFollowing(t *testing.T) {
 	assert.NoError(t, err)
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertNotCalled(t, "Search")
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
 func TestExec_GetFollowingError(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	followingErr := errors.New("error getting following")
@@ -114,15 +158,15 @@ func TestExec_GetFollowingError(t *testing.T) {
 	assert.Contains(t, err.Error(), "error obteniendo seguidos")
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertNotCalled(t, "Search")
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
-func TestExec_SearchError(t *testing.T) {
+func TestExec_SearchErrorForEveryAuthor(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	following := []string{"user-2"}
@@ -138,68 +182,63 @@ func TestExec_SearchError(t *testing.T) {
 	assert.Contains(t, err.Error(), "error obteniendo tweets")
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
-func TestExec_NoTweets(t *testing.T) {
+func TestExec_SearchErrorForOneAuthorIsSkipped(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
-	following := []string{"user-2"}
 	var emptyTweets []dmntweet.Tweet
 
-	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return(following, nil)
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(emptyTweets, "", nil)
+	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return([]string{"user-2", "user-3"}, nil)
+	mockTweetService.On("Search", mock.Anything, searchFor("user-2", "")).Return(emptyTweets, "", errors.New("throttled"))
+	mockTweetService.On("Search", mock.Anything, searchFor("user-3", "")).Return([]dmntweet.Tweet{
+		{ID: "twt-2", UserID: "user-3", CreatedAt: "2025-06-10T23:00:00Z"},
+	}, "", nil)
+	mockTimelineService.On("BulkInsert", mock.Anything, userID, mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+		return assert.ObjectsAreEqual([]string{"twt-2"}, tweetIDs(entries))
+	})).Return(nil)
 
 	err := uc.Exec(context.Background(), userID)
 
 	assert.NoError(t, err)
-	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertNotCalled(t, "Publish")
+	mockTimelineService.AssertExpectations(t)
 }
 
-func TestExec_PublishError(t *testing.T) {
+func TestExec_NoTweets(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	following := []string{"user-2"}
-	tweets := []dmntweet.Tweet{
-		{
-			ID:        "twt-1",
-			UserID:    "user-2",
-			Content:   "Hello from user 2",
-			CreatedAt: "2025-06-10T22:00:00Z",
-		},
-	}
-	publishErr := errors.New("error publishing timeline")
+	var emptyTweets []dmntweet.Tweet
 
 	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return(following, nil)
-	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(tweets, "", nil)
-	mockPublisher.On("Publish", mock.Anything, userID, mock.Anything).Return(publishErr)
+	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(emptyTweets, "", nil)
 
 	err := uc.Exec(context.Background(), userID)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "error publicando timeline reconstruido")
+	assert.NoError(t, err)
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
+	mockTimelineService.AssertNotCalled(t, "BulkInsert")
 }
 
-func TestExec_NoPublisher(t *testing.T) {
+func TestExec_BulkInsertError(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, nil)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	following := []string{"user-2"}
@@ -211,23 +250,27 @@ func TestExec_NoPublisher(t *testing.T) {
 			CreatedAt: "2025-06-10T22:00:00Z",
 		},
 	}
+	insertErr := errors.New("error writing timeline")
 
 	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return(following, nil)
 	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(tweets, "", nil)
+	mockTimelineService.On("BulkInsert", mock.Anything, userID, mock.Anything).Return(insertErr)
 
 	err := uc.Exec(context.Background(), userID)
 
-	assert.NoError(t, err)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error guardando timeline reconstruido")
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertExpectations(t)
+	mockTimelineService.AssertExpectations(t)
 }
 
 func TestExec_InvalidTweetDate(t *testing.T) {
 	mockFollowerService := new(mocks.FollowerService)
 	mockTweetService := new(mocks.TweetService)
-	mockPublisher := new(mocks.Publisher)
+	mockTimelineService := new(mocks.TimelineService)
 
-	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockPublisher)
+	uc := fallbacktimeline.New(mockFollowerService, mockTweetService, mockTimelineService, 4, 10, 100)
 
 	userID := "user-1"
 	following := []string{"user-2"}
@@ -244,7 +287,7 @@ func TestExec_InvalidTweetDate(t *testing.T) {
 	mockFollowerService.On("GetAllFollowing", mock.Anything, userID).Return(following, nil)
 	mockTweetService.On("Search", mock.Anything, mock.Anything).Return(tweets, "", nil)
 
-	mockPublisher.On("Publish", mock.Anything, userID, mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
+	mockTimelineService.On("BulkInsert", mock.Anything, userID, mock.MatchedBy(func(entries []dmntimeline.TimelineEntry) bool {
 		if len(entries) != 1 {
 			return false
 		}
@@ -259,5 +302,5 @@ func TestExec_InvalidTweetDate(t *testing.T) {
 	assert.NoError(t, err)
 	mockFollowerService.AssertExpectations(t)
 	mockTweetService.AssertExpectations(t)
-	mockPublisher.AssertExpectations(t)
+	mockTimelineService.AssertExpectations(t)
 }
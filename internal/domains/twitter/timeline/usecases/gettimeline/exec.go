@@ -3,16 +3,22 @@ package gettimeline
 import (
 	"context"
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 )
 
 func (u *UseCase) Exec(ctx context.Context, userID string) (dmntimeline.Timeline, error) {
+	ctx, span := tracing.Start(ctx, "gettimeline.Exec")
+	defer span.End()
+
 	timeline, cacheHit, err := u.timelineService.Get(ctx, userID, 30)
 	if err != nil {
 		u.logger.Error("Error obteniendo timeline",
 			zap.String("user_id", userID),
 			zap.Error(err),
 		)
+		tracing.RecordError(span, err)
 		return dmntimeline.Timeline{}, err
 	}
 
@@ -27,10 +33,11 @@ func (u *UseCase) Exec(ctx context.Context, userID string) (dmntimeline.Timeline
 				zap.Error(err),
 			)
 		}
-		return dmntimeline.Timeline{}, dmntimeline.ErrEmptyTimeline
+		return dmntimeline.Timeline{}, apperrors.NewNotFoundError("El timeline está vacío, se solicitó su reconstrucción", dmntimeline.ErrEmptyTimeline).
+			WithCode(apperrors.CodeTimelineEmpty)
 	}
 
-	if !cacheHit {
+	if !cacheHit && u.publisher != nil {
 		u.logger.Debug("Timeline no encontrado en caché, publicando para lazy caching",
 			zap.String("user_id", userID),
 			zap.Int("entries_count", len(timeline.Entries)),
@@ -42,12 +49,28 @@ func (u *UseCase) Exec(ctx context.Context, userID string) (dmntimeline.Timeline
 				zap.Error(err),
 			)
 		}
-	} else {
+	} else if cacheHit {
 		u.logger.Debug("Timeline encontrado en caché",
 			zap.String("user_id", userID),
 			zap.Int("entries_count", len(timeline.Entries)),
 		)
 	}
 
+	// La caché guarda las entradas tal como están en la tabla, así que la
+	// hidratación se hace en cada lectura y un tweet borrado desaparece del
+	// timeline en cuanto vence su copia en la caché de tweets.
+	if u.tweetService != nil {
+		tweets, err := u.tweetService.GetMany(ctx, dmntimeline.TweetIDs(timeline.Entries))
+		if err != nil {
+			u.logger.Error("Error hidratando timeline",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+			tracing.RecordError(span, err)
+			return dmntimeline.Timeline{}, err
+		}
+		timeline.Entries = dmntimeline.Hydrate(timeline.Entries, tweets)
+	}
+
 	return timeline, nil
 }
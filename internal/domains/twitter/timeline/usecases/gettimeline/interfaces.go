@@ -3,6 +3,7 @@ package gettimeline
 import (
 	"context"
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"go.uber.org/zap"
 )
 
@@ -10,6 +11,11 @@ type TimelineService interface {
 	Get(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, bool, error)
 }
 
+// TweetService hidrata las entradas cuando el timeline guarda sólo IDs.
+type TweetService interface {
+	GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error)
+}
+
 type Publisher interface {
 	Publish(ctx context.Context, timeline dmntimeline.Timeline) error
 }
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 )
@@ -53,3 +54,15 @@ func (m *Logger) Warn(msg string, fields ...zap.Field) {
 func (m *Logger) Error(msg string, fields ...zap.Field) {
 	m.Called(msg, fields)
 }
+
+type TweetService struct {
+	mock.Mock
+}
+
+func (m *TweetService) GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dmntweet.Tweet), args.Error(1)
+}
@@ -2,8 +2,13 @@ package gettimeline
 
 const componentName = "gettimeline_usecase"
 
+// UseCase obtiene el timeline de un usuario. Con tweetService nil las entradas
+// se devuelven tal como están guardadas; si no, se hidratan con el contenido
+// actual de cada tweet. Con publisher nil (caché deshabilitado) no se pide
+// poblar el caché tras leer de DynamoDB.
 type UseCase struct {
 	timelineService   TimelineService
+	tweetService      TweetService
 	publisher         Publisher
 	fallbackPublisher FallbackRebuildTimelinePublisherService
 	logger            Logger
@@ -11,6 +16,7 @@ type UseCase struct {
 
 func New(
 	service TimelineService,
+	tweetService TweetService,
 	publisher Publisher,
 	fallbackPublisher FallbackRebuildTimelinePublisherService,
 	logger Logger,
@@ -21,6 +27,7 @@ func New(
 
 	return UseCase{
 		timelineService:   service,
+		tweetService:      tweetService,
 		publisher:         publisher,
 		fallbackPublisher: fallbackPublisher,
 		logger:            logger,
@@ -12,6 +12,7 @@ import (
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/gettimeline"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/gettimeline/mocks"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 )
 
 func TestExec_Success_WithCacheHit(t *testing.T) {
@@ -22,7 +23,7 @@ func TestExec_Success_WithCacheHit(t *testing.T) {
 
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 
-	uc := gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, mockLogger)
+	uc := gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, mockLogger)
 
 	userID := "user-1"
 	now := time.Now().UTC()
@@ -65,7 +66,7 @@ func TestExec_Success_WithoutCacheHit(t *testing.T) {
 
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 
-	uc := gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, mockLogger)
+	uc := gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, mockLogger)
 
 	userID := "user-1"
 	now := time.Now().UTC()
@@ -103,7 +104,7 @@ func TestExec_EmptyTimeline(t *testing.T) {
 
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 
-	uc := gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, mockLogger)
+	uc := gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, mockLogger)
 
 	userID := "user-1"
 	emptyTimeline := dmntimeline.Timeline{
@@ -118,7 +119,7 @@ func TestExec_EmptyTimeline(t *testing.T) {
 	result, err := uc.Exec(context.Background(), userID)
 
 	assert.Error(t, err)
-	assert.Equal(t, dmntimeline.ErrEmptyTimeline, err)
+	assert.ErrorIs(t, err, dmntimeline.ErrEmptyTimeline)
 	assert.Equal(t, dmntimeline.Timeline{}, result)
 	mockTimelineService.AssertExpectations(t)
 	mockFallbackPublisher.AssertExpectations(t)
@@ -133,7 +134,7 @@ func TestExec_ServiceError(t *testing.T) {
 
 	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
 
-	uc := gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, mockLogger)
+	uc := gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, mockLogger)
 
 	userID := "user-1"
 	expectedErr := errors.New("error obteniendo timeline")
@@ -160,7 +161,7 @@ func TestExec_FallbackPublisherError(t *testing.T) {
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 
-	uc := gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, mockLogger)
+	uc := gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, mockLogger)
 
 	userID := "user-1"
 	emptyTimeline := dmntimeline.Timeline{
@@ -176,7 +177,7 @@ func TestExec_FallbackPublisherError(t *testing.T) {
 	result, err := uc.Exec(context.Background(), userID)
 
 	assert.Error(t, err)
-	assert.Equal(t, dmntimeline.ErrEmptyTimeline, err)
+	assert.ErrorIs(t, err, dmntimeline.ErrEmptyTimeline)
 	assert.Equal(t, dmntimeline.Timeline{}, result)
 	mockTimelineService.AssertExpectations(t)
 	mockFallbackPublisher.AssertExpectations(t)
@@ -192,7 +193,7 @@ func TestExec_PublisherError(t *testing.T) {
 	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
 	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
 
-	uc := gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, mockLogger)
+	uc := gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, mockLogger)
 
 	userID := "user-1"
 	now := time.Now().UTC()
@@ -223,12 +224,105 @@ func TestExec_PublisherError(t *testing.T) {
 	mockFallbackPublisher.AssertNotCalled(t, "Publish")
 }
 
+func TestExec_CacheDisabled_DoesNotPublish(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockFallbackPublisher := new(mocks.FallbackRebuildTimelinePublisherService)
+	mockLogger := new(mocks.Logger)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	uc := gettimeline.New(mockTimelineService, nil, nil, mockFallbackPublisher, mockLogger)
+
+	userID := "user-1"
+	timeline := dmntimeline.Timeline{
+		UserID: userID,
+		Entries: []dmntimeline.TimelineEntry{
+			{TweetID: "tweet-1", AuthorID: "author-1", Content: "Hello world!", CreatedAt: time.Now().UTC()},
+		},
+	}
+
+	mockTimelineService.On("Get", mock.Anything, userID, 30).Return(timeline, false, nil)
+
+	result, err := uc.Exec(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, timeline, result)
+	mockTimelineService.AssertExpectations(t)
+}
+
+func TestExec_HydratesEntriesAndDropsMissingTweets(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockTweetService := new(mocks.TweetService)
+	mockPublisher := new(mocks.Publisher)
+	mockFallbackPublisher := new(mocks.FallbackRebuildTimelinePublisherService)
+	mockLogger := new(mocks.Logger)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	uc := gettimeline.New(mockTimelineService, mockTweetService, mockPublisher, mockFallbackPublisher, mockLogger)
+
+	userID := "user-1"
+	now := time.Now().UTC()
+	timeline := dmntimeline.Timeline{
+		UserID: userID,
+		Entries: []dmntimeline.TimelineEntry{
+			{TweetID: "tweet-1", AuthorID: "author-1", CreatedAt: now},
+			{TweetID: "tweet-2", AuthorID: "author-2", CreatedAt: now.Add(-1 * time.Hour)},
+		},
+	}
+
+	mockTimelineService.On("Get", mock.Anything, userID, 30).Return(timeline, true, nil)
+	mockTweetService.On("GetMany", mock.Anything, []string{"tweet-1", "tweet-2"}).Return([]dmntweet.Tweet{
+		{ID: "tweet-1", UserID: "author-1", Content: "Hello world!"},
+	}, nil)
+
+	result, err := uc.Exec(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dmntimeline.TimelineEntry{
+		{TweetID: "tweet-1", AuthorID: "author-1", Content: "Hello world!", CreatedAt: now},
+	}, result.Entries)
+	mockTimelineService.AssertExpectations(t)
+	mockTweetService.AssertExpectations(t)
+}
+
+func TestExec_HydrationError(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockTweetService := new(mocks.TweetService)
+	mockPublisher := new(mocks.Publisher)
+	mockFallbackPublisher := new(mocks.FallbackRebuildTimelinePublisherService)
+	mockLogger := new(mocks.Logger)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	uc := gettimeline.New(mockTimelineService, mockTweetService, mockPublisher, mockFallbackPublisher, mockLogger)
+
+	userID := "user-1"
+	timeline := dmntimeline.Timeline{
+		UserID: userID,
+		Entries: []dmntimeline.TimelineEntry{
+			{TweetID: "tweet-1", AuthorID: "author-1", CreatedAt: time.Now().UTC()},
+		},
+	}
+	expectedErr := errors.New("error obteniendo tweets")
+
+	mockTimelineService.On("Get", mock.Anything, userID, 30).Return(timeline, true, nil)
+	mockTweetService.On("GetMany", mock.Anything, []string{"tweet-1"}).Return(nil, expectedErr)
+
+	result, err := uc.Exec(context.Background(), userID)
+
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, dmntimeline.Timeline{}, result)
+	mockTweetService.AssertExpectations(t)
+}
+
 func TestNew_WithNilLogger(t *testing.T) {
 	mockTimelineService := new(mocks.TimelineService)
 	mockPublisher := new(mocks.Publisher)
 	mockFallbackPublisher := new(mocks.FallbackRebuildTimelinePublisherService)
 
 	assert.Panics(t, func() {
-		gettimeline.New(mockTimelineService, mockPublisher, mockFallbackPublisher, nil)
+		gettimeline.New(mockTimelineService, nil, mockPublisher, mockFallbackPublisher, nil)
 	}, "Se espera un pánico cuando el logger es nil")
 }
@@ -3,10 +3,15 @@ package orchestratefanout
 import (
 	"context"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 )
 
 func (u *UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet) error {
+	ctx, span := tracing.Start(ctx, "orchestratefanout.Exec")
+	defer span.End()
+
 	u.logger.Info("Iniciando distribución de tweet a timelines de seguidores",
 		zap.String("tweet_id", tweet.ID),
 		zap.String("user_id", tweet.UserID))
@@ -17,6 +22,7 @@ func (u *UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet) error {
 			zap.String("tweet_id", tweet.ID),
 			zap.String("user_id", tweet.UserID),
 			zap.Error(err))
+		tracing.RecordError(span, err)
 		return err
 	}
 
@@ -24,6 +30,7 @@ func (u *UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet) error {
 		zap.String("tweet_id", tweet.ID),
 		zap.String("user_id", tweet.UserID),
 		zap.Int("followers_count", len(followers)))
+	metrics.FanoutSize.Observe(float64(len(followers)))
 
 	if len(followers) == 0 {
 		u.logger.Debug("No hay seguidores para distribuir el tweet",
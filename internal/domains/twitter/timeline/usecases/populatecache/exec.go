@@ -3,10 +3,14 @@ package populatecache
 import (
 	"context"
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 )
 
 func (uc UseCase) Exec(ctx context.Context, timeline dmntimeline.Timeline) error {
+	ctx, span := tracing.Start(ctx, "populatecache.Exec")
+	defer span.End()
+
 	uc.logger.Info("Iniciando populación de cache",
 		zap.String("timeline_id", timeline.UserID),
 		zap.Int("entries_count", len(timeline.Entries)),
@@ -18,6 +22,7 @@ func (uc UseCase) Exec(ctx context.Context, timeline dmntimeline.Timeline) error
 			zap.String("timeline_id", timeline.UserID),
 			zap.Error(err),
 		)
+		tracing.RecordError(span, err)
 		return err
 	}
 
@@ -27,6 +32,7 @@ func (uc UseCase) Exec(ctx context.Context, timeline dmntimeline.Timeline) error
 			zap.String("timeline_id", timeline.UserID),
 			zap.Error(err),
 		)
+		tracing.RecordError(span, err)
 		return err
 	}
 
@@ -0,0 +1,67 @@
+package streamtimeline
+
+import (
+	"context"
+	"slices"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+func (u *UseCase) Exec(ctx context.Context, userID, lastEventID string) (Stream, error) {
+	ctx, span := tracing.Start(ctx, "streamtimeline.Exec")
+	defer span.End()
+
+	// La suscripción va antes de leer el backlog para no perder entradas
+	// escritas entre ambos pasos; los duplicados los descarta el handler.
+	live, closeStream := u.hub.Subscribe(userID)
+	stream := Stream{Live: live, Close: closeStream}
+
+	if lastEventID == "" {
+		return stream, nil
+	}
+
+	timeline, err := u.timelineService.GetFromDB(ctx, userID, u.resumeLimit)
+	if err != nil {
+		closeStream()
+		u.logger.Error("Error obteniendo entradas para reanudar el stream",
+			zap.String("user_id", userID),
+			zap.String("last_event_id", lastEventID),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return Stream{}, err
+	}
+
+	for _, entry := range timeline.Entries {
+		if entry.EventID() > lastEventID {
+			stream.Backlog = append(stream.Backlog, entry)
+		}
+	}
+
+	if u.tweetService != nil && len(stream.Backlog) > 0 {
+		tweets, err := u.tweetService.GetMany(ctx, dmntimeline.TweetIDs(stream.Backlog))
+		if err != nil {
+			closeStream()
+			u.logger.Error("Error hidratando entradas para reanudar el stream",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+			tracing.RecordError(span, err)
+			return Stream{}, err
+		}
+		stream.Backlog = dmntimeline.Hydrate(stream.Backlog, tweets)
+	}
+	slices.SortFunc(stream.Backlog, func(a, b dmntimeline.TimelineEntry) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	u.logger.Debug("Stream de timeline reanudado",
+		zap.String("user_id", userID),
+		zap.String("last_event_id", lastEventID),
+		zap.Int("backlog_count", len(stream.Backlog)),
+	)
+
+	return stream, nil
+}
@@ -0,0 +1,29 @@
+package streamtimeline
+
+import (
+	"context"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"go.uber.org/zap"
+)
+
+type TimelineService interface {
+	GetFromDB(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, error)
+}
+
+// TweetService hidrata el backlog cuando el timeline guarda sólo IDs.
+type TweetService interface {
+	GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error)
+}
+
+type Hub interface {
+	Subscribe(userID string) (<-chan dmntimeline.TimelineEntry, func())
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
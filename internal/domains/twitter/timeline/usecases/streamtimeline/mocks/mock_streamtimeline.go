@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type TimelineService struct {
+	mock.Mock
+}
+
+func (m *TimelineService) GetFromDB(ctx context.Context, userID string, limit int) (dmntimeline.Timeline, error) {
+	args := m.Called(ctx, userID, limit)
+	return args.Get(0).(dmntimeline.Timeline), args.Error(1)
+}
+
+type TweetService struct {
+	mock.Mock
+}
+
+func (m *TweetService) GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dmntweet.Tweet), args.Error(1)
+}
+
+type Hub struct {
+	mock.Mock
+}
+
+func (m *Hub) Subscribe(userID string) (<-chan dmntimeline.TimelineEntry, func()) {
+	args := m.Called(userID)
+	return args.Get(0).(<-chan dmntimeline.TimelineEntry), args.Get(1).(func())
+}
+
+type Logger struct {
+	mock.Mock
+}
+
+func (m *Logger) Debug(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Info(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Warn(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Error(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
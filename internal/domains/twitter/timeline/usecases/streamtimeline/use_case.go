@@ -0,0 +1,36 @@
+package streamtimeline
+
+import (
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+)
+
+type UseCase struct {
+	timelineService TimelineService
+	tweetService    TweetService
+	hub             Hub
+	resumeLimit     int
+	logger          Logger
+}
+
+// Stream es una conexión abierta al timeline de un usuario: primero las
+// entradas perdidas desde Last-Event-ID, en orden cronológico, y después las
+// nuevas a medida que llegan. Close libera la suscripción.
+type Stream struct {
+	Backlog []dmntimeline.TimelineEntry
+	Live    <-chan dmntimeline.TimelineEntry
+	Close   func()
+}
+
+func New(service TimelineService, tweetService TweetService, hub Hub, resumeLimit int, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		timelineService: service,
+		tweetService:    tweetService,
+		hub:             hub,
+		resumeLimit:     resumeLimit,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,128 @@
+package streamtimeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/streamtimeline"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/streamtimeline/mocks"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+func subscription(closed *bool) (<-chan dmntimeline.TimelineEntry, func()) {
+	ch := make(chan dmntimeline.TimelineEntry)
+	return ch, func() { *closed = true }
+}
+
+func TestExec_WithoutLastEventID(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockHub := new(mocks.Hub)
+	mockLogger := new(mocks.Logger)
+
+	var closed bool
+	live, closeFn := subscription(&closed)
+	mockHub.On("Subscribe", "user-1").Return(live, closeFn)
+
+	uc := streamtimeline.New(mockTimelineService, nil, mockHub, 100, mockLogger)
+
+	stream, err := uc.Exec(context.Background(), "user-1", "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, stream.Backlog)
+	assert.Equal(t, live, stream.Live)
+	stream.Close()
+	assert.True(t, closed)
+	mockTimelineService.AssertNotCalled(t, "GetFromDB")
+}
+
+func TestExec_ResumesFromLastEventID(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockHub := new(mocks.Hub)
+	mockLogger := new(mocks.Logger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	var closed bool
+	live, closeFn := subscription(&closed)
+	mockHub.On("Subscribe", "user-1").Return(live, closeFn)
+
+	now := time.Now().UTC()
+	seen := dmntimeline.TimelineEntry{TweetID: "tweet-1", CreatedAt: now.Add(-3 * time.Minute)}
+	missed1 := dmntimeline.TimelineEntry{TweetID: "tweet-2", CreatedAt: now.Add(-2 * time.Minute)}
+	missed2 := dmntimeline.TimelineEntry{TweetID: "tweet-3", CreatedAt: now.Add(-time.Minute)}
+
+	mockTimelineService.On("GetFromDB", mock.Anything, "user-1", 100).Return(dmntimeline.Timeline{
+		UserID:  "user-1",
+		Entries: []dmntimeline.TimelineEntry{missed2, missed1, seen},
+	}, nil)
+
+	uc := streamtimeline.New(mockTimelineService, nil, mockHub, 100, mockLogger)
+
+	stream, err := uc.Exec(context.Background(), "user-1", seen.EventID())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dmntimeline.TimelineEntry{missed1, missed2}, stream.Backlog)
+	assert.False(t, closed)
+	mockTimelineService.AssertExpectations(t)
+}
+
+func TestExec_ResumeHydratesBacklog(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockTweetService := new(mocks.TweetService)
+	mockHub := new(mocks.Hub)
+	mockLogger := new(mocks.Logger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	var closed bool
+	live, closeFn := subscription(&closed)
+	mockHub.On("Subscribe", "user-1").Return(live, closeFn)
+
+	now := time.Now().UTC()
+	seen := dmntimeline.TimelineEntry{TweetID: "tweet-1", CreatedAt: now.Add(-3 * time.Minute)}
+	deleted := dmntimeline.TimelineEntry{TweetID: "tweet-2", AuthorID: "author-2", CreatedAt: now.Add(-2 * time.Minute)}
+	missed := dmntimeline.TimelineEntry{TweetID: "tweet-3", AuthorID: "author-3", CreatedAt: now.Add(-time.Minute)}
+
+	mockTimelineService.On("GetFromDB", mock.Anything, "user-1", 100).Return(dmntimeline.Timeline{
+		UserID:  "user-1",
+		Entries: []dmntimeline.TimelineEntry{missed, deleted, seen},
+	}, nil)
+	mockTweetService.On("GetMany", mock.Anything, []string{"tweet-3", "tweet-2"}).Return([]dmntweet.Tweet{
+		{ID: "tweet-3", UserID: "author-3", Content: "Hola"},
+	}, nil)
+
+	uc := streamtimeline.New(mockTimelineService, mockTweetService, mockHub, 100, mockLogger)
+
+	stream, err := uc.Exec(context.Background(), "user-1", seen.EventID())
+
+	hydrated := missed
+	hydrated.Content = "Hola"
+	assert.NoError(t, err)
+	assert.Equal(t, []dmntimeline.TimelineEntry{hydrated}, stream.Backlog)
+	mockTweetService.AssertExpectations(t)
+}
+
+func TestExec_ResumeError(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockHub := new(mocks.Hub)
+	mockLogger := new(mocks.Logger)
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	var closed bool
+	live, closeFn := subscription(&closed)
+	mockHub.On("Subscribe", "user-1").Return(live, closeFn)
+
+	expectedErr := errors.New("dynamodb error")
+	mockTimelineService.On("GetFromDB", mock.Anything, "user-1", 100).Return(dmntimeline.Timeline{}, expectedErr)
+
+	uc := streamtimeline.New(mockTimelineService, nil, mockHub, 100, mockLogger)
+
+	_, err := uc.Exec(context.Background(), "user-1", "0000000000000000000-tweet-0")
+
+	assert.ErrorIs(t, err, expectedErr)
+	assert.True(t, closed)
+}
@@ -0,0 +1,47 @@
+package trimtimelines
+
+import (
+	"context"
+
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// Exec procesa un lote de hasta batchSize timelines pendientes y devuelve
+// cuántos tomó. Un timeline que no se pudo recortar vuelve a quedar pendiente.
+func (u *UseCase) Exec(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "trimtimelines.Exec")
+	defer span.End()
+
+	userIDs, err := u.timelineService.PendingTrims(ctx, u.batchSize)
+	if err != nil {
+		u.logger.Error("Error obteniendo timelines pendientes de recorte", zap.Error(err))
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+
+	deleted := 0
+	for _, userID := range userIDs {
+		n, err := u.timelineService.Trim(ctx, userID, u.maxEntries)
+		deleted += n
+		if err == nil {
+			continue
+		}
+
+		u.logger.Warn("Error recortando timeline, se reintenta en la próxima pasada",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		tracing.RecordError(span, err)
+		if err := u.timelineService.MarkForTrim(ctx, userID); err != nil {
+			u.logger.Error("Error volviendo a anotar timeline para recorte",
+				zap.String("user_id", userID),
+				zap.Error(err))
+		}
+	}
+
+	u.logger.Debug("Lote de recorte de timelines procesado",
+		zap.Int("timelines", len(userIDs)),
+		zap.Int("deleted", deleted))
+
+	return len(userIDs), nil
+}
@@ -0,0 +1,20 @@
+package trimtimelines
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type TimelineService interface {
+	PendingTrims(ctx context.Context, count int) ([]string, error)
+	Trim(ctx context.Context, userID string, maxEntries int) (int, error)
+	MarkForTrim(ctx context.Context, userID string) error
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type TimelineService struct {
+	mock.Mock
+}
+
+func (m *TimelineService) PendingTrims(ctx context.Context, count int) ([]string, error) {
+	args := m.Called(ctx, count)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *TimelineService) Trim(ctx context.Context, userID string, maxEntries int) (int, error) {
+	args := m.Called(ctx, userID, maxEntries)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *TimelineService) MarkForTrim(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type Logger struct {
+	mock.Mock
+}
+
+func (m *Logger) Debug(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Info(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Warn(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Error(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
@@ -0,0 +1,23 @@
+package trimtimelines
+
+// UseCase recorta los timelines que recibieron entradas desde la última
+// pasada para que ninguno supere maxEntries.
+type UseCase struct {
+	timelineService TimelineService
+	maxEntries      int
+	batchSize       int
+	logger          Logger
+}
+
+func New(timelineService TimelineService, maxEntries, batchSize int, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		timelineService: timelineService,
+		maxEntries:      maxEntries,
+		batchSize:       batchSize,
+		logger:          logger,
+	}
+}
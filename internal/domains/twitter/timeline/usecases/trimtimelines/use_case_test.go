@@ -0,0 +1,75 @@
+package trimtimelines_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/trimtimelines"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/usecases/trimtimelines/mocks"
+)
+
+func TestExec_TrimsPendingTimelines(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockLogger := new(mocks.Logger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+
+	mockTimelineService.On("PendingTrims", mock.Anything, 10).Return([]string{"user-1", "user-2"}, nil)
+	mockTimelineService.On("Trim", mock.Anything, "user-1", 800).Return(3, nil)
+	mockTimelineService.On("Trim", mock.Anything, "user-2", 800).Return(0, nil)
+
+	uc := trimtimelines.New(mockTimelineService, 800, 10, mockLogger)
+
+	processed, err := uc.Exec(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	mockTimelineService.AssertExpectations(t)
+	mockTimelineService.AssertNotCalled(t, "MarkForTrim", mock.Anything, mock.Anything)
+}
+
+func TestExec_FailedTrimIsMarkedAgain(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockLogger := new(mocks.Logger)
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warn", mock.Anything, mock.Anything).Maybe()
+
+	mockTimelineService.On("PendingTrims", mock.Anything, 10).Return([]string{"user-1", "user-2"}, nil)
+	mockTimelineService.On("Trim", mock.Anything, "user-1", 800).Return(0, errors.New("dynamodb error"))
+	mockTimelineService.On("Trim", mock.Anything, "user-2", 800).Return(5, nil)
+	mockTimelineService.On("MarkForTrim", mock.Anything, "user-1").Return(nil)
+
+	uc := trimtimelines.New(mockTimelineService, 800, 10, mockLogger)
+
+	processed, err := uc.Exec(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processed)
+	mockTimelineService.AssertExpectations(t)
+}
+
+func TestExec_PendingTrimsError(t *testing.T) {
+	mockTimelineService := new(mocks.TimelineService)
+	mockLogger := new(mocks.Logger)
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	expectedErr := errors.New("redis error")
+	mockTimelineService.On("PendingTrims", mock.Anything, 10).Return(nil, expectedErr)
+
+	uc := trimtimelines.New(mockTimelineService, 800, 10, mockLogger)
+
+	processed, err := uc.Exec(context.Background())
+
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Zero(t, processed)
+	mockTimelineService.AssertNotCalled(t, "Trim", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNew_WithNilLogger(t *testing.T) {
+	assert.Panics(t, func() {
+		trimtimelines.New(new(mocks.TimelineService), 800, 10, nil)
+	})
+}
@@ -5,10 +5,14 @@ import (
 
 	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 )
 
 func (u *UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet, userID string) error {
+	ctx, span := tracing.Start(ctx, "updatetimeline.Exec")
+	defer span.End()
+
 	u.logger.Debug("Tweet recibido para actualizar timeline",
 		zap.String("tweet_id", tweet.ID),
 		zap.String("user_id", tweet.UserID),
@@ -23,5 +27,10 @@ func (u *UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet, userID string)
 		zap.String("content", entry.Content),
 		zap.Time("created_at", entry.CreatedAt))
 
-	return u.timelineService.Update(ctx, entry, userID)
+	if err := u.timelineService.Update(ctx, entry, userID); err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,7 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidWindow = errors.New("trend: invalid window")
+)
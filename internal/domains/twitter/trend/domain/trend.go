@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+type Trend struct {
+	Tag     string  `json:"tag"`
+	Count   int64   `json:"count"`
+	Authors int64   `json:"authors"`
+	Score   float64 `json:"score"`
+}
+
+type Trends struct {
+	Window string  `json:"window"`
+	Trends []Trend `json:"trends"`
+}
+
+// TagStats reúne lo necesario para puntuar un hashtag en una ventana:
+// Recent es la suma de menciones en la ventana ponderada por antigüedad
+// (DecayWeight) y Baseline el promedio histórico de menciones por ventana.
+type TagStats struct {
+	Tag      string
+	Count    int64
+	Recent   float64
+	Baseline float64
+	Authors  int64
+}
+
+// Score mide cuánto se aparta la actividad reciente del histórico. Dividir
+// por la raíz del baseline evita que los tags siempre populares dominen y
+// hace comparables tags con volúmenes muy distintos.
+func (s TagStats) Score() float64 {
+	return (s.Recent - s.Baseline) / math.Sqrt(s.Baseline+1)
+}
+
+// DecayWeight devuelve el peso de un bucket según su antigüedad: 1 para un
+// bucket actual y la mitad por cada halfLife transcurrido.
+func DecayWeight(age, halfLife time.Duration) float64 {
+	if age <= 0 || halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
+
+// Rank descarta los tags con menos de minAuthors autores distintos o sin
+// crecimiento sobre el baseline y devuelve los limit mejores por score.
+func Rank(stats []TagStats, minAuthors int64, limit int) []Trend {
+	trends := make([]Trend, 0, len(stats))
+	for _, s := range stats {
+		if s.Authors < minAuthors {
+			continue
+		}
+		score := s.Score()
+		if score <= 0 {
+			continue
+		}
+		trends = append(trends, Trend{
+			Tag:     s.Tag,
+			Count:   s.Count,
+			Authors: s.Authors,
+			Score:   math.Round(score*100) / 100,
+		})
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool {
+		if trends[i].Score == trends[j].Score {
+			return trends[i].Tag < trends[j].Tag
+		}
+		return trends[i].Score > trends[j].Score
+	})
+
+	if limit > 0 && len(trends) > limit {
+		trends = trends[:limit]
+	}
+	return trends
+}
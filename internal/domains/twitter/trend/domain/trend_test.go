@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+)
+
+func TestDecayWeight(t *testing.T) {
+	assert.Equal(t, 1.0, dmntrend.DecayWeight(0, 30*time.Minute))
+	assert.Equal(t, 0.5, dmntrend.DecayWeight(30*time.Minute, 30*time.Minute))
+	assert.Equal(t, 0.25, dmntrend.DecayWeight(time.Hour, 30*time.Minute))
+}
+
+func TestRank_FiltersByAuthorsAndBaseline(t *testing.T) {
+	stats := []dmntrend.TagStats{
+		{Tag: "spike", Count: 50, Recent: 40, Baseline: 2, Authors: 30},
+		{Tag: "spam", Count: 500, Recent: 400, Baseline: 0, Authors: 1},
+		{Tag: "siempre", Count: 100, Recent: 90, Baseline: 100, Authors: 80},
+		{Tag: "nuevo", Count: 8, Recent: 8, Baseline: 0, Authors: 5},
+	}
+
+	trends := dmntrend.Rank(stats, 3, 10)
+
+	assert.Len(t, trends, 2)
+	assert.Equal(t, "spike", trends[0].Tag)
+	assert.Equal(t, "nuevo", trends[1].Tag)
+}
+
+func TestRank_Limit(t *testing.T) {
+	stats := []dmntrend.TagStats{
+		{Tag: "a", Count: 10, Recent: 10, Authors: 5},
+		{Tag: "b", Count: 20, Recent: 20, Authors: 5},
+		{Tag: "c", Count: 30, Recent: 30, Authors: 5},
+	}
+
+	trends := dmntrend.Rank(stats, 3, 2)
+
+	assert.Equal(t, []string{"c", "b"}, []string{trends[0].Tag, trends[1].Tag})
+}
+
+func TestParseWindow(t *testing.T) {
+	window, err := dmntrend.ParseWindow("")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, window)
+
+	_, err = dmntrend.ParseWindow("2d")
+	assert.ErrorIs(t, err, dmntrend.ErrInvalidWindow)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+const DefaultWindow = "1h"
+
+var windows = map[string]time.Duration{
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+}
+
+func ParseWindow(window string) (time.Duration, error) {
+	if window == "" {
+		window = DefaultWindow
+	}
+
+	duration, ok := windows[window]
+	if !ok {
+		return 0, apperrors.NewInvalidInputError(fmt.Sprintf("La ventana %s no es válida", window), ErrInvalidWindow).
+			WithCode(apperrors.CodeTrendWindowInvalid).
+			WithDetails(map[string]any{
+				"window":  window,
+				"allowed": "15m, 1h, 6h, 24h",
+			})
+	}
+	return duration, nil
+}
@@ -0,0 +1,10 @@
+package repository
+
+const (
+	prefixBucket  = "trends:bucket:"
+	prefixAuthors = "trends:authors:"
+	prefixResult  = "trends:result:"
+	// prefixSeen marca cada par tweet y tag ya contado, para que una
+	// reentrega del mismo evento no sume dos veces.
+	prefixSeen = "trends:seen:"
+)
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+type RedisClientInterface interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error)
+	Del(ctx context.Context, keys ...string) error
+	IncrementCounter(ctx context.Context, key, field string, ttl time.Duration) error
+	Counters(ctx context.Context, keys ...string) ([]map[string]string, error)
+	AddUnique(ctx context.Context, key string, ttl time.Duration, members ...string) error
+	CountUnique(ctx context.Context, keys ...string) (int64, error)
+}
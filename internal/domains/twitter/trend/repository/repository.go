@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// TrendRepository guarda en Redis un hash por bucket de tiempo con las
+// menciones de cada tag y un HyperLogLog por bucket y tag con sus autores.
+type TrendRepository struct {
+	redisClient RedisClientInterface
+	logger      logger.LoggerInterface
+}
+
+func NewTrendRepository(redisClient RedisClientInterface, log logger.LoggerInterface) *TrendRepository {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	return &TrendRepository{
+		redisClient: redisClient,
+		logger:      log.Named("trend_repository"),
+	}
+}
+
+// Record suma una mención de tag al bucket. El autor se agrega primero porque
+// repetirlo no cambia el conteo: si falla el incremento, reintentar no suma
+// dos veces.
+func (r *TrendRepository) Record(ctx context.Context, tag, authorID string, bucket time.Time, ttl time.Duration) error {
+	if err := r.redisClient.AddUnique(ctx, authorsKey(bucket, tag), ttl, authorID); err != nil {
+		return err
+	}
+	return r.redisClient.IncrementCounter(ctx, bucketKey(bucket), tag, ttl)
+}
+
+// MarkRecorded marca el tag del tweet como contado por ttl. Devuelve false si
+// ya estaba marcado, es decir si el evento es una reentrega.
+func (r *TrendRepository) MarkRecorded(ctx context.Context, tweetID, tag string, ttl time.Duration) (bool, error) {
+	return r.redisClient.SetNX(ctx, seenKey(tweetID, tag), []byte("1"), ttl)
+}
+
+// UnmarkRecorded quita la marca de un tag que no se pudo contar, para que la
+// reentrega lo cuente.
+func (r *TrendRepository) UnmarkRecorded(ctx context.Context, tweetID, tag string) error {
+	return r.redisClient.Del(ctx, seenKey(tweetID, tag))
+}
+
+// Counts devuelve las menciones por tag de cada bucket, en el mismo orden.
+func (r *TrendRepository) Counts(ctx context.Context, buckets []time.Time) ([]map[string]int64, error) {
+	keys := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		keys[i] = bucketKey(bucket)
+	}
+
+	raw, err := r.redisClient.Counters(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]map[string]int64, len(raw))
+	for i, fields := range raw {
+		counts[i] = make(map[string]int64, len(fields))
+		for tag, value := range fields {
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				r.logger.Warn("Contador de tendencia inválido",
+					zap.String("key", keys[i]),
+					zap.String("tag", tag),
+					zap.String("value", value))
+				continue
+			}
+			counts[i][tag] = count
+		}
+	}
+	return counts, nil
+}
+
+func (r *TrendRepository) Authors(ctx context.Context, tag string, buckets []time.Time) (int64, error) {
+	keys := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		keys[i] = authorsKey(bucket, tag)
+	}
+	return r.redisClient.CountUnique(ctx, keys...)
+}
+
+func (r *TrendRepository) GetCached(ctx context.Context, window string) (dmntrend.Trends, bool, error) {
+	data, err := r.redisClient.Get(ctx, prefixResult+window)
+	if err != nil || data == nil {
+		return dmntrend.Trends{}, false, err
+	}
+
+	var trends dmntrend.Trends
+	if err := json.Unmarshal(data, &trends); err != nil {
+		r.logger.Warn("Tendencias en caché inválidas",
+			zap.String("window", window),
+			zap.Error(err))
+		return dmntrend.Trends{}, false, nil
+	}
+	return trends, true, nil
+}
+
+func (r *TrendRepository) SetCached(ctx context.Context, trends dmntrend.Trends, ttl time.Duration) error {
+	data, err := json.Marshal(trends)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, prefixResult+trends.Window, data, ttl)
+}
+
+func bucketKey(bucket time.Time) string {
+	return prefixBucket + strconv.FormatInt(bucket.Unix(), 10)
+}
+
+func authorsKey(bucket time.Time, tag string) string {
+	return prefixAuthors + strconv.FormatInt(bucket.Unix(), 10) + ":" + tag
+}
+
+func seenKey(tweetID, tag string) string {
+	return prefixSeen + tweetID + ":" + tag
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+	"go.uber.org/zap"
+)
+
+// Get calcula las tendencias de la ventana comparando las menciones recientes,
+// con decaimiento exponencial de vida media window/2, contra el promedio por
+// ventana de las horas previas configuradas como baseline.
+func (s Service) Get(ctx context.Context, window string, limit int) (dmntrend.Trends, error) {
+	windowSize, err := dmntrend.ParseWindow(window)
+	if err != nil {
+		return dmntrend.Trends{}, err
+	}
+	if window == "" {
+		window = dmntrend.DefaultWindow
+	}
+	if limit <= 0 || limit > s.limit {
+		limit = s.limit
+	}
+
+	cached, ok, err := s.repository.GetCached(ctx, window)
+	if err != nil {
+		s.logger.Warn("Error leyendo tendencias de caché",
+			zap.String("window", window),
+			zap.Error(err),
+			zap.String("action", actionGet),
+		)
+	}
+	if ok {
+		return truncate(cached, limit), nil
+	}
+
+	now := s.now()
+	windowStart := now.Add(-windowSize)
+	recentBuckets := s.buckets(windowStart, now)
+	baselineBuckets := s.buckets(windowStart.Add(-s.baseline), windowStart.Truncate(s.bucketSize))
+
+	counts, err := s.repository.Counts(ctx, append(append([]time.Time{}, recentBuckets...), baselineBuckets...))
+	if err != nil {
+		s.logger.Error("Error obteniendo contadores de tendencias",
+			zap.String("window", window),
+			zap.Error(err),
+			zap.String("action", actionGet),
+		)
+		return dmntrend.Trends{}, err
+	}
+
+	stats := make(map[string]*dmntrend.TagStats)
+	for i, bucket := range recentBuckets {
+		weight := dmntrend.DecayWeight(now.Sub(bucket.Add(s.bucketSize)), windowSize/2)
+		for tag, count := range counts[i] {
+			stat, ok := stats[tag]
+			if !ok {
+				stat = &dmntrend.TagStats{Tag: tag}
+				stats[tag] = stat
+			}
+			stat.Count += count
+			stat.Recent += float64(count) * weight
+		}
+	}
+
+	baselineWindows := float64(s.baseline) / float64(windowSize)
+	for _, bucketCounts := range counts[len(recentBuckets):] {
+		for tag, count := range bucketCounts {
+			if stat, ok := stats[tag]; ok {
+				stat.Baseline += float64(count) / baselineWindows
+			}
+		}
+	}
+
+	candidates := make([]dmntrend.TagStats, 0, len(stats))
+	for tag, stat := range stats {
+		// Un tag no puede tener más autores que menciones, así que no hace
+		// falta consultar Redis para los que no llegan al mínimo.
+		if stat.Count < s.minAuthors || stat.Score() <= 0 {
+			continue
+		}
+		authors, err := s.repository.Authors(ctx, tag, recentBuckets)
+		if err != nil {
+			s.logger.Error("Error obteniendo autores de tendencia",
+				zap.String("tag", tag),
+				zap.Error(err),
+				zap.String("action", actionGet),
+			)
+			return dmntrend.Trends{}, err
+		}
+		stat.Authors = authors
+		candidates = append(candidates, *stat)
+	}
+
+	trends := dmntrend.Trends{
+		Window: window,
+		Trends: dmntrend.Rank(candidates, s.minAuthors, s.limit),
+	}
+
+	if err := s.repository.SetCached(ctx, trends, s.cacheTTL); err != nil {
+		s.logger.Warn("Error guardando tendencias en caché",
+			zap.String("window", window),
+			zap.Error(err),
+			zap.String("action", actionGet),
+		)
+	}
+
+	s.logger.Debug("Tendencias calculadas",
+		zap.String("window", window),
+		zap.Int("candidates", len(candidates)),
+		zap.Int("trends", len(trends.Trends)),
+		zap.String("action", actionGet),
+	)
+
+	return truncate(trends, limit), nil
+}
+
+func truncate(trends dmntrend.Trends, limit int) dmntrend.Trends {
+	if len(trends.Trends) > limit {
+		trends.Trends = trends.Trends[:limit]
+	}
+	return trends
+}
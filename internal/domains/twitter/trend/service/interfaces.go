@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+)
+
+type Repository interface {
+	MarkRecorded(ctx context.Context, tweetID, tag string, ttl time.Duration) (bool, error)
+	UnmarkRecorded(ctx context.Context, tweetID, tag string) error
+	Record(ctx context.Context, tag, authorID string, bucket time.Time, ttl time.Duration) error
+	Counts(ctx context.Context, buckets []time.Time) ([]map[string]int64, error)
+	Authors(ctx context.Context, tag string, buckets []time.Time) (int64, error)
+	GetCached(ctx context.Context, window string) (dmntrend.Trends, bool, error)
+	SetCached(ctx context.Context, trends dmntrend.Trends, ttl time.Duration) error
+}
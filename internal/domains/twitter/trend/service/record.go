@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"go.uber.org/zap"
+)
+
+// Record suma las menciones de los hashtags del tweet en el bucket actual. La
+// cola entrega al menos una vez, así que cada tag del tweet se marca antes de
+// contarlo: una reentrega sólo suma los tags que no llegaron a contarse.
+func (s Service) Record(ctx context.Context, tweet dmntweet.Tweet) error {
+	hashtags := tweet.Hashtags
+	if len(hashtags) == 0 {
+		hashtags = dmntweet.ExtractHashtags(tweet.Content)
+	}
+	if len(hashtags) == 0 {
+		return nil
+	}
+
+	bucket := s.now().Truncate(s.bucketSize)
+	ttl := s.baseline + 24*time.Hour + s.bucketSize
+
+	for _, tag := range hashtags {
+		first, err := s.repository.MarkRecorded(ctx, tweet.ID, tag, ttl)
+		if err != nil {
+			s.logger.Error("Error marcando hashtag del tweet para tendencias",
+				zap.String("tag", tag),
+				zap.String("tweet_id", tweet.ID),
+				zap.Error(err),
+				zap.String("action", actionRecord),
+			)
+			return err
+		}
+		if !first {
+			s.logger.Debug("Hashtag ya contado para tendencias, se ignora la reentrega",
+				zap.String("tag", tag),
+				zap.String("tweet_id", tweet.ID),
+				zap.String("action", actionRecord),
+			)
+			continue
+		}
+
+		if err := s.repository.Record(ctx, tag, tweet.UserID, bucket, ttl); err != nil {
+			s.logger.Error("Error registrando mención de hashtag",
+				zap.String("tag", tag),
+				zap.String("tweet_id", tweet.ID),
+				zap.Error(err),
+				zap.String("action", actionRecord),
+			)
+			if unmarkErr := s.repository.UnmarkRecorded(ctx, tweet.ID, tag); unmarkErr != nil {
+				s.logger.Warn("Error quitando la marca del hashtag, la reentrega no lo contará",
+					zap.String("tag", tag),
+					zap.String("tweet_id", tweet.ID),
+					zap.Error(unmarkErr),
+					zap.String("action", actionRecord),
+				)
+			}
+			return err
+		}
+	}
+
+	s.logger.Debug("Hashtags registrados para tendencias",
+		zap.String("tweet_id", tweet.ID),
+		zap.Strings("tags", hashtags),
+		zap.String("action", actionRecord),
+	)
+	return nil
+}
@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/memory"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/repository"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/service"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+// failingCache falla una vez el incremento del tag indicado, como si Redis se
+// cortara a mitad del tweet.
+type failingCache struct {
+	*memory.Cache
+	failTag string
+}
+
+func (c *failingCache) IncrementCounter(ctx context.Context, key, field string, ttl time.Duration) error {
+	if field == c.failTag {
+		c.failTag = ""
+		return errors.New("redis no disponible")
+	}
+	return c.Cache.IncrementCounter(ctx, key, field, ttl)
+}
+
+func newService(t *testing.T, cache repository.RedisClientInterface) (service.Service, *repository.TrendRepository) {
+	t.Helper()
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+
+	repo := repository.NewTrendRepository(cache, log)
+	return service.New(repo, config.TrendsConfig{BucketSeconds: 3600, BaselineHours: 24, MinAuthors: 1, Limit: 10}, log), repo
+}
+
+// counts suma las menciones por tag de los buckets que abarcó el test, por si
+// la hora cambió en el medio.
+func counts(t *testing.T, repo *repository.TrendRepository, since time.Time) map[string]int64 {
+	t.Helper()
+
+	buckets := []time.Time{since}
+	if now := time.Now().Truncate(time.Hour); !now.Equal(since) {
+		buckets = append(buckets, now)
+	}
+	bucketCounts, err := repo.Counts(context.Background(), buckets)
+	require.NoError(t, err)
+
+	total := make(map[string]int64)
+	for _, bucket := range bucketCounts {
+		for tag, count := range bucket {
+			total[tag] += count
+		}
+	}
+	return total
+}
+
+func TestRecord_IgnoresRedeliveredTweet(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newService(t, memory.NewCache())
+
+	bucket := time.Now().Truncate(time.Hour)
+	tweet := dmntweet.Tweet{ID: "twt-1", UserID: "usr-1", Content: "hola #golang"}
+	require.NoError(t, svc.Record(ctx, tweet))
+	require.NoError(t, svc.Record(ctx, tweet))
+	require.NoError(t, svc.Record(ctx, dmntweet.Tweet{ID: "twt-2", UserID: "usr-2", Content: "#golang"}))
+
+	assert.Equal(t, int64(2), counts(t, repo, bucket)["golang"], "la reentrega de twt-1 no suma")
+}
+
+// Si falla el segundo tag, la reentrega cuenta sólo ese: el primero ya quedó
+// sumado y marcado.
+func TestRecord_RedeliveryAfterPartialFailureCountsEachTagOnce(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newService(t, &failingCache{Cache: memory.NewCache(), failTag: "rust"})
+
+	bucket := time.Now().Truncate(time.Hour)
+	tweet := dmntweet.Tweet{ID: "twt-1", UserID: "usr-1", Content: "#golang y #rust"}
+	require.Error(t, svc.Record(ctx, tweet))
+	assert.Equal(t, map[string]int64{"golang": 1}, counts(t, repo, bucket))
+
+	require.NoError(t, svc.Record(ctx, tweet))
+	require.NoError(t, svc.Record(ctx, tweet))
+	assert.Equal(t, map[string]int64{"golang": 1, "rust": 1}, counts(t, repo, bucket))
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"time"
+
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+const (
+	target = "trend_service"
+
+	actionRecord = "record"
+	actionGet    = "get"
+)
+
+type Service struct {
+	repository Repository
+	bucketSize time.Duration
+	baseline   time.Duration
+	minAuthors int64
+	limit      int
+	cacheTTL   time.Duration
+	logger     logger.LoggerInterface
+	now        func() time.Time
+}
+
+func New(repository Repository, cfg config.TrendsConfig, log logger.LoggerInterface) Service {
+	if log == nil {
+		panic("logger cannot be nil")
+	}
+
+	return Service{
+		repository: repository,
+		bucketSize: time.Duration(cfg.BucketSeconds) * time.Second,
+		baseline:   time.Duration(cfg.BaselineHours) * time.Hour,
+		minAuthors: int64(cfg.MinAuthors),
+		limit:      cfg.Limit,
+		cacheTTL:   time.Duration(cfg.CacheSeconds) * time.Second,
+		logger:     log.Named(target),
+		now:        time.Now,
+	}
+}
+
+// buckets devuelve el inicio de cada bucket que se solapa con [from, to), del
+// más nuevo al más viejo.
+func (s Service) buckets(from, to time.Time) []time.Time {
+	var buckets []time.Time
+	first := from.Truncate(s.bucketSize)
+	for bucket := to.Add(-time.Nanosecond).Truncate(s.bucketSize); !bucket.Before(first); bucket = bucket.Add(-s.bucketSize) {
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
@@ -0,0 +1,30 @@
+package gettrends
+
+import (
+	"context"
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+func (u UseCase) Exec(ctx context.Context, window string, limit int) (dmntrend.Trends, error) {
+	ctx, span := tracing.Start(ctx, "gettrends.Exec")
+	defer span.End()
+
+	u.logger.Debug("Obteniendo tendencias",
+		zap.String("window", window),
+		zap.Int("limit", limit),
+	)
+
+	trends, err := u.trendService.Get(ctx, window, limit)
+	if err != nil {
+		u.logger.Error("Error al obtener tendencias",
+			zap.String("window", window),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return dmntrend.Trends{}, err
+	}
+
+	return trends, nil
+}
@@ -0,0 +1,18 @@
+package gettrends
+
+import (
+	"context"
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+	"go.uber.org/zap"
+)
+
+type TrendService interface {
+	Get(ctx context.Context, window string, limit int) (dmntrend.Trends, error)
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
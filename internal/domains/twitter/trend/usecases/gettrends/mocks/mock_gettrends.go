@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type TrendService struct {
+	mock.Mock
+}
+
+func (m *TrendService) Get(ctx context.Context, window string, limit int) (dmntrend.Trends, error) {
+	args := m.Called(ctx, window, limit)
+	return args.Get(0).(dmntrend.Trends), args.Error(1)
+}
+
+type Logger struct {
+	mock.Mock
+}
+
+func (m *Logger) Debug(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Info(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Warn(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Error(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
@@ -0,0 +1,23 @@
+package gettrends
+
+const (
+	target = "use_case_get_trends"
+
+	getTrends = "get_trends"
+)
+
+type UseCase struct {
+	trendService TrendService
+	logger       Logger
+}
+
+func NewUseCase(trendService TrendService, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		trendService: trendService,
+		logger:       logger,
+	}
+}
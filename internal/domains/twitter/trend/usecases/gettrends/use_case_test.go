@@ -0,0 +1,48 @@
+package gettrends_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmntrend "github.com/juanmalvarez3/twit/internal/domains/twitter/trend/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/gettrends"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/gettrends/mocks"
+)
+
+func TestExec_Success(t *testing.T) {
+	mockTrendService := new(mocks.TrendService)
+	mockLogger := new(mocks.Logger)
+	uc := gettrends.NewUseCase(mockTrendService, mockLogger)
+
+	expected := dmntrend.Trends{
+		Window: "1h",
+		Trends: []dmntrend.Trend{{Tag: "golang", Count: 12, Authors: 9, Score: 3.5}},
+	}
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockTrendService.On("Get", mock.Anything, "1h", 10).Return(expected, nil)
+
+	trends, err := uc.Exec(context.Background(), "1h", 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, trends)
+	mockTrendService.AssertExpectations(t)
+}
+
+func TestExec_InvalidWindow(t *testing.T) {
+	mockTrendService := new(mocks.TrendService)
+	mockLogger := new(mocks.Logger)
+	uc := gettrends.NewUseCase(mockTrendService, mockLogger)
+
+	_, windowErr := dmntrend.ParseWindow("2d")
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockTrendService.On("Get", mock.Anything, "2d", 0).Return(dmntrend.Trends{}, windowErr)
+
+	_, err := uc.Exec(context.Background(), "2d", 0)
+
+	assert.ErrorIs(t, err, dmntrend.ErrInvalidWindow)
+	mockTrendService.AssertExpectations(t)
+}
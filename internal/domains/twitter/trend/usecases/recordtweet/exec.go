@@ -0,0 +1,24 @@
+package recordtweet
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+func (u UseCase) Exec(ctx context.Context, tweet dmntweet.Tweet) error {
+	ctx, span := tracing.Start(ctx, "recordtweet.Exec")
+	defer span.End()
+
+	if err := u.trendService.Record(ctx, tweet); err != nil {
+		u.logger.Error("Error registrando tweet en tendencias",
+			zap.String("tweet_id", tweet.ID),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package recordtweet
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"go.uber.org/zap"
+)
+
+type TrendService interface {
+	Record(ctx context.Context, tweet dmntweet.Tweet) error
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
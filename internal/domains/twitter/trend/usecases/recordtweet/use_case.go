@@ -0,0 +1,23 @@
+package recordtweet
+
+const (
+	target = "use_case_record_tweet"
+
+	recordTweet = "record_tweet"
+)
+
+type UseCase struct {
+	trendService TrendService
+	logger       Logger
+}
+
+func NewUseCase(trendService TrendService, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		trendService: trendService,
+		logger:       logger,
+	}
+}
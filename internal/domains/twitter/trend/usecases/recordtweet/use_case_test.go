@@ -0,0 +1,44 @@
+package recordtweet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/recordtweet"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/trend/usecases/recordtweet/mocks"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+func TestExec_Success(t *testing.T) {
+	mockTrendService := new(mocks.TrendService)
+	mockLogger := new(mocks.Logger)
+	uc := recordtweet.NewUseCase(mockTrendService, mockLogger)
+
+	tweet := dmntweet.Tweet{ID: "twt-1", UserID: "usr-1", Content: "Hola #golang", Hashtags: []string{"golang"}}
+	mockTrendService.On("Record", mock.Anything, tweet).Return(nil)
+
+	err := uc.Exec(context.Background(), tweet)
+
+	assert.NoError(t, err)
+	mockTrendService.AssertExpectations(t)
+}
+
+func TestExec_ServiceError(t *testing.T) {
+	mockTrendService := new(mocks.TrendService)
+	mockLogger := new(mocks.Logger)
+	uc := recordtweet.NewUseCase(mockTrendService, mockLogger)
+
+	tweet := dmntweet.Tweet{ID: "twt-1", UserID: "usr-1", Content: "Hola #golang"}
+	serviceErr := errors.New("redis error")
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockTrendService.On("Record", mock.Anything, tweet).Return(serviceErr)
+
+	err := uc.Exec(context.Background(), tweet)
+
+	assert.Equal(t, serviceErr, err)
+	mockTrendService.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	MaxContentLength = 280
+
+	// URLLength es el peso fijo de cualquier URL, sin importar su largo real,
+	// igual que si pasara por un acortador.
+	URLLength = 23
+)
+
+var urlPattern = regexp.MustCompile(`https?://[^\s]+`)
+
+// ContentLength cuenta el contenido en clusters de grafemas, de modo que un
+// emoji compuesto o una letra con tilde cuentan como un único caracter. Los
+// grafemas fuera de los rangos latinos y de puntuación común (CJK, emoji)
+// pesan 2, y cada URL pesa URLLength.
+func ContentLength(content string) int {
+	length := 0
+	last := 0
+	for _, loc := range urlPattern.FindAllStringIndex(content, -1) {
+		length += graphemesWeight(content[last:loc[0]]) + URLLength
+		last = loc[1]
+	}
+	return length + graphemesWeight(content[last:])
+}
+
+func graphemesWeight(text string) int {
+	weight := 0
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		weight += graphemeWeight(graphemes.Runes()[0])
+	}
+	return weight
+}
+
+func graphemeWeight(r rune) int {
+	switch {
+	case r <= 0x10FF,
+		r >= 0x2000 && r <= 0x200D,
+		r >= 0x2010 && r <= 0x201F,
+		r >= 0x2032 && r <= 0x2037:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// NormalizeText aplica NFC, unifica saltos de línea, elimina caracteres de
+// control (excepto saltos de línea y tabulaciones) y recorta espacios.
+func NormalizeText(content string) string {
+	content = norm.NFC.String(content)
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, content)
+	return strings.TrimSpace(content)
+}
@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+func TestContentLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{name: "ascii", content: "Hola mundo", expected: 10},
+		{name: "acentos", content: "acción", expected: 6},
+		{name: "acento combinado", content: "accio\u0301n", expected: 6},
+		{name: "emoji", content: "👍", expected: 2},
+		{name: "emoji compuesto", content: "👨‍👩‍👧", expected: 2},
+		{name: "cjk", content: "日本語", expected: 6},
+		{name: "url", content: "mirá https://example.com/un/path/bastante/largo?con=query", expected: 5 + dmntweet.URLLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dmntweet.ContentLength(tt.content))
+		})
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	assert.Equal(t, "acción", dmntweet.NormalizeText("accio\u0301n"))
+	assert.Equal(t, "hola\nmundo", dmntweet.NormalizeText("  hola\r\nmun\u0000do\u0007 "))
+}
+
+func TestValidate_EmojiWithinLimit(t *testing.T) {
+	tweet := dmntweet.Tweet{Content: strings.Repeat("😀", 100)}
+
+	assert.NoError(t, tweet.Validate())
+}
+
+func TestValidate_TooLongDetails(t *testing.T) {
+	tweet := dmntweet.Tweet{Content: strings.Repeat("日", 141)}
+
+	err := tweet.Validate()
+
+	var appErr *apperrors.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.ErrorIs(t, err, dmntweet.ErrInvalidContent)
+	assert.Equal(t, apperrors.CodeTweetContentTooLong, appErr.Code)
+	assert.Equal(t, 282, appErr.Details["length"])
+	assert.Equal(t, -2, appErr.Details["remaining"])
+}
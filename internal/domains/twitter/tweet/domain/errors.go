@@ -3,5 +3,9 @@ package domain
 import "errors"
 
 var (
-	ErrTweetNotFound = errors.New("tweet: tweet not found")
+	ErrTweetNotFound  = errors.New("tweet: tweet not found")
+	ErrInvalidContent = errors.New("tweet: invalid content")
+	ErrInvalidHashtag = errors.New("tweet: invalid hashtag")
+	ErrInvalidCursor  = errors.New("tweet: invalid pagination cursor")
+	ErrReplyNotFound  = errors.New("tweet: replied tweet not found")
 )
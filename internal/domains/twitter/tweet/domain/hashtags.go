@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxHashtags limita cuántos hashtags de un tweet se indexan, de modo que
+// la escritura del tweet y su índice entre en una única transacción.
+const MaxHashtags = 10
+
+// El prefijo evita tomar como hashtag fragmentos como "C#" o "a&#", ya que
+// el paquete regexp no soporta lookbehind.
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{M}\p{N}_&#＃])[#＃]([\p{L}\p{M}\p{N}_]+)`)
+
+var hashtagFolder = cases.Fold()
+
+// ExtractHashtags devuelve los hashtags del contenido sin el "#",
+// normalizados con NormalizeHashtag, sin duplicados y en orden de aparición.
+func ExtractHashtags(content string) []string {
+	matches := hashtagPattern.FindAllStringSubmatch(content, -1)
+
+	hashtags := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		tag := NormalizeHashtag(match[1])
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		hashtags = append(hashtags, tag)
+		if len(hashtags) == MaxHashtags {
+			break
+		}
+	}
+	return hashtags
+}
+
+// NormalizeHashtag aplica NFC y case folding para que #Golang y #golang
+// compartan feed. Devuelve "" si el tag no contiene al menos una letra.
+func NormalizeHashtag(tag string) string {
+	tag = strings.TrimLeft(tag, "#＃")
+	tag = hashtagFolder.String(norm.NFC.String(tag))
+
+	hasLetter := false
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsMark(r) && !unicode.IsNumber(r) && r != '_' {
+			return ""
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+	if !hasLetter {
+		return ""
+	}
+	return tag
+}
@@ -0,0 +1,29 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{name: "case folding", content: "#Golang y #golang", expected: []string{"golang"}},
+		{name: "unicode", content: "Vamos #Argentina #Ñandú #日本", expected: []string{"argentina", "ñandú", "日本"}},
+		{name: "sólo números", content: "Puesto #1", expected: []string{}},
+		{name: "dentro de palabra", content: "C#sharp y a&#39;", expected: []string{}},
+		{name: "puntuación", content: "(#go), #rust!", expected: []string{"go", "rust"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dmntweet.ExtractHashtags(tt.content))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "regexp"
+
+// MaxMentions limita cuántos usuarios pueden notificarse desde un mismo tweet.
+const MaxMentions = 10
+
+// Los handles siguen las reglas de Twitter: hasta 15 letras ASCII, dígitos o
+// "_". Como no hay un directorio de usuarios, el handle es el userId.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@＠!#$%&*])[@＠]([A-Za-z0-9_]{1,15})(?:$|[^A-Za-z0-9_@＠])`)
+
+// ExtractMentions devuelve los handles mencionados en el contenido sin el
+// "@", sin duplicados y en orden de aparición.
+func ExtractMentions(content string) []string {
+	mentions := make([]string, 0)
+	seen := make(map[string]struct{})
+
+	// Los delimitadores forman parte del match, así que se avanza hasta el
+	// final del handle para no perder menciones consecutivas ("@a @b").
+	for offset := 0; offset < len(content); {
+		loc := mentionPattern.FindStringSubmatchIndex(content[offset:])
+		if loc == nil {
+			break
+		}
+		handle := content[offset+loc[2] : offset+loc[3]]
+		offset += loc[3]
+
+		if _, ok := seen[handle]; ok {
+			continue
+		}
+		seen[handle] = struct{}{}
+		mentions = append(mentions, handle)
+		if len(mentions) == MaxMentions {
+			break
+		}
+	}
+	return mentions
+}
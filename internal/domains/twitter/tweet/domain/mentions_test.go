@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{name: "consecutivas", content: "@ana @beto hola", expected: []string{"ana", "beto"}},
+		{name: "duplicadas", content: "@ana y @ana", expected: []string{"ana"}},
+		{name: "puntuación", content: "Hola (@ana), @beto!", expected: []string{"ana", "beto"}},
+		{name: "email", content: "Escribime a ana@mail.com", expected: []string{}},
+		{name: "demasiado largo", content: "@abcdefghijklmnopq", expected: []string{}},
+		{name: "sin mención", content: "Hola mundo", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dmntweet.ExtractMentions(tt.content))
+		})
+	}
+}
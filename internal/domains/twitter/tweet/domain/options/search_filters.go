@@ -1,8 +1,10 @@
 package options
 
 type SearchFilters struct {
-	UserID  *string
-	TweetID *string
+	UserID          *string
+	TweetID         *string
+	ExcludeReplies  bool
+	ExcludeRetweets bool
 }
 
 func NewSearchFilters() SearchFilters {
@@ -17,3 +19,13 @@ func (sf SearchFilters) WithTweetID(tweetID string) SearchFilters {
 	sf.TweetID = &tweetID
 	return sf
 }
+
+func (sf SearchFilters) WithExcludeReplies(exclude bool) SearchFilters {
+	sf.ExcludeReplies = exclude
+	return sf
+}
+
+func (sf SearchFilters) WithExcludeRetweets(exclude bool) SearchFilters {
+	sf.ExcludeRetweets = exclude
+	return sf
+}
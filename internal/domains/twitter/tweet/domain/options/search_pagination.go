@@ -1,14 +1,16 @@
 package options
 
+// SearchPagination pagina por cursor: Cursor es el NextCursor de la página
+// anterior, vacío para la primera.
 type SearchPagination struct {
 	Limit  int
-	Offset int
+	Cursor string
 }
 
 func NewSearchPagination() SearchPagination {
 	return SearchPagination{
 		Limit:  0,
-		Offset: 0,
+		Cursor: "",
 	}
 }
 
@@ -17,7 +19,7 @@ func (sp SearchPagination) WithLimit(limit int) SearchPagination {
 	return sp
 }
 
-func (sp SearchPagination) WithOffset(offset int) SearchPagination {
-	sp.Offset = offset
+func (sp SearchPagination) WithCursor(cursor string) SearchPagination {
+	sp.Cursor = cursor
 	return sp
 }
@@ -0,0 +1,6 @@
+package domain
+
+type TweetsPage struct {
+	Tweets     []Tweet `json:"tweets"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
@@ -1,29 +1,40 @@
 package domain
 
 import (
-	"fmt"
-	"strings"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
 )
 
 type Tweet struct {
-	ID        string `json:"id"`
-	UserID    string `json:"userId"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"createdAt"`
+	ID            string   `json:"id"`
+	UserID        string   `json:"userId"`
+	Content       string   `json:"content"`
+	CreatedAt     string   `json:"createdAt"`
+	Hashtags      []string `json:"hashtags,omitempty"`
+	Mentions      []string `json:"mentions,omitempty"`
+	ReplyToID     string   `json:"replyToId,omitempty"`
+	ReplyToUserID string   `json:"replyToUserId,omitempty"`
+	RetweetOfID   string   `json:"retweetOfId,omitempty"`
 }
 
 func (t Tweet) Validate() error {
-	if len(t.Content) == 0 {
-		return fmt.Errorf("El contenido del tweet no puede estar vacío")
+	if t.Content == "" {
+		return apperrors.NewInvalidInputError("El contenido del tweet no puede estar vacío", ErrInvalidContent).
+			WithCode(apperrors.CodeTweetContentEmpty)
 	}
-	if len(t.Content) > 280 {
-		return fmt.Errorf("El contenido del tweet excede el máximo permitido")
+	if length := ContentLength(t.Content); length > MaxContentLength {
+		return apperrors.NewInvalidInputError("El contenido del tweet excede el máximo permitido", ErrInvalidContent).
+			WithCode(apperrors.CodeTweetContentTooLong).
+			WithDetails(map[string]any{
+				"length":    length,
+				"max":       MaxContentLength,
+				"remaining": MaxContentLength - length,
+			})
 	}
 	return nil
 }
 
 func (t Tweet) NormalizeContent() string {
-	return strings.TrimSpace(t.Content)
+	return NormalizeText(t.Content)
 }
 
 type TweetCreatedEvent struct {
@@ -5,6 +5,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/daos"
 	"go.uber.org/zap"
@@ -19,7 +20,8 @@ func (r *TweetRepository) Create(ctx context.Context, tweet dmntweet.Tweet) erro
 		zap.String("table", r.tableName),
 	)
 
-	item, err := attributevalue.MarshalMap(daos.ToTweetDAOModel(tweet))
+	dao := daos.ToTweetDAOModel(tweet)
+	item, err := attributevalue.MarshalMap(dao)
 	if err != nil {
 		r.logger.Error("Error al serializar tweet para DynamoDB",
 			zap.String("tweet_id", tweet.ID),
@@ -29,10 +31,14 @@ func (r *TweetRepository) Create(ctx context.Context, tweet dmntweet.Tweet) erro
 		return err
 	}
 
-	_, err = r.dynamoDBClient.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String(r.tableName),
-		Item:      item,
-	})
+	if len(dao.Hashtags) > 0 {
+		err = r.createWithHashtags(ctx, item, dao)
+	} else {
+		_, err = r.dynamoDBClient.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(r.tableName),
+			Item:      item,
+		})
+	}
 	if err != nil {
 		r.logger.Error("Error al guardar tweet en DynamoDB",
 			zap.String("tweet_id", tweet.ID),
@@ -49,3 +55,32 @@ func (r *TweetRepository) Create(ctx context.Context, tweet dmntweet.Tweet) erro
 	)
 	return nil
 }
+
+// createWithHashtags guarda el tweet y sus entradas en el índice de hashtags
+// en una única transacción, para que el feed nunca apunte a un tweet que no
+// se llegó a persistir.
+func (r *TweetRepository) createWithHashtags(ctx context.Context, item map[string]types.AttributeValue, dao daos.TweetDAO) error {
+	transactItems := []types.TransactWriteItem{
+		{Put: &types.Put{TableName: aws.String(r.tableName), Item: item}},
+	}
+
+	for _, entry := range daos.ToHashtagDAOs(dao) {
+		hashtagItem, err := attributevalue.MarshalMap(entry)
+		if err != nil {
+			r.logger.Error("Error al serializar hashtag para DynamoDB",
+				zap.String("tweet_id", dao.ID),
+				zap.String("tag", entry.Tag),
+				zap.Error(err),
+			)
+			return err
+		}
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Put: &types.Put{TableName: aws.String(r.hashtagsTable), Item: hashtagItem},
+		})
+	}
+
+	_, err := r.dynamoDBClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	return err
+}
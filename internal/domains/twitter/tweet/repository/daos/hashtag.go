@@ -0,0 +1,60 @@
+package daos
+
+import (
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"time"
+)
+
+// HashtagDAO es una entrada del índice hashtag -> tweet. Guarda una copia del
+// tweet para poder servir el feed sin una segunda lectura a la tabla tweets.
+type HashtagDAO struct {
+	Tag           string    `json:"tag" dynamodbav:"tag"`
+	SortKey       string    `json:"sort_key" dynamodbav:"sort_key"`
+	TweetID       string    `json:"tweet_id" dynamodbav:"tweet_id"`
+	UserID        string    `json:"user_id" dynamodbav:"user_id"`
+	Content       string    `json:"content" dynamodbav:"content"`
+	CreatedAt     time.Time `json:"created_at" dynamodbav:"created_at"`
+	Hashtags      []string  `json:"hashtags,omitempty" dynamodbav:"hashtags,omitempty"`
+	Mentions      []string  `json:"mentions,omitempty" dynamodbav:"mentions,omitempty"`
+	ReplyToID     string    `json:"reply_to_id,omitempty" dynamodbav:"reply_to_id,omitempty"`
+	ReplyToUserID string    `json:"reply_to_user_id,omitempty" dynamodbav:"reply_to_user_id,omitempty"`
+	RetweetOfID   string    `json:"retweet_of_id,omitempty" dynamodbav:"retweet_of_id,omitempty"`
+}
+
+func HashtagSortKey(createdAt time.Time, tweetID string) string {
+	return createdAt.UTC().Format(time.RFC3339) + "#" + tweetID
+}
+
+func ToHashtagDAOs(tweet TweetDAO) []HashtagDAO {
+	entries := make([]HashtagDAO, 0, len(tweet.Hashtags))
+	for _, tag := range tweet.Hashtags {
+		entries = append(entries, HashtagDAO{
+			Tag:           tag,
+			SortKey:       HashtagSortKey(tweet.CreatedAt, tweet.ID),
+			TweetID:       tweet.ID,
+			UserID:        tweet.UserID,
+			Content:       tweet.Content,
+			CreatedAt:     tweet.CreatedAt,
+			Hashtags:      tweet.Hashtags,
+			Mentions:      tweet.Mentions,
+			ReplyToID:     tweet.ReplyToID,
+			ReplyToUserID: tweet.ReplyToUserID,
+			RetweetOfID:   tweet.RetweetOfID,
+		})
+	}
+	return entries
+}
+
+func HashtagToTweetModel(dao HashtagDAO) dmntweet.Tweet {
+	return dmntweet.Tweet{
+		ID:            dao.TweetID,
+		UserID:        dao.UserID,
+		Content:       dao.Content,
+		CreatedAt:     dao.CreatedAt.Format(time.RFC3339),
+		Hashtags:      dao.Hashtags,
+		Mentions:      dao.Mentions,
+		ReplyToID:     dao.ReplyToID,
+		ReplyToUserID: dao.ReplyToUserID,
+		RetweetOfID:   dao.RetweetOfID,
+	}
+}
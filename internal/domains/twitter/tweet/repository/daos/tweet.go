@@ -6,10 +6,15 @@ import (
 )
 
 type TweetDAO struct {
-	ID        string    `json:"id" dynamodbav:"id"`
-	UserID    string    `json:"user_id" dynamodbav:"user_id"`
-	Content   string    `json:"content" dynamodbav:"content"`
-	CreatedAt time.Time `json:"created_at" dynamodbav:"created_at"`
+	ID            string    `json:"id" dynamodbav:"id"`
+	UserID        string    `json:"user_id" dynamodbav:"user_id"`
+	Content       string    `json:"content" dynamodbav:"content"`
+	CreatedAt     time.Time `json:"created_at" dynamodbav:"created_at"`
+	Hashtags      []string  `json:"hashtags,omitempty" dynamodbav:"hashtags,omitempty"`
+	Mentions      []string  `json:"mentions,omitempty" dynamodbav:"mentions,omitempty"`
+	ReplyToID     string    `json:"reply_to_id,omitempty" dynamodbav:"reply_to_id,omitempty"`
+	ReplyToUserID string    `json:"reply_to_user_id,omitempty" dynamodbav:"reply_to_user_id,omitempty"`
+	RetweetOfID   string    `json:"retweet_of_id,omitempty" dynamodbav:"retweet_of_id,omitempty"`
 }
 
 func (t *TweetDAO) TableName() string {
@@ -18,10 +23,15 @@ func (t *TweetDAO) TableName() string {
 
 func ToTweetModel(dao TweetDAO) dmntweet.Tweet {
 	return dmntweet.Tweet{
-		ID:        dao.ID,
-		UserID:    dao.UserID,
-		Content:   dao.Content,
-		CreatedAt: dao.CreatedAt.Format(time.RFC3339),
+		ID:            dao.ID,
+		UserID:        dao.UserID,
+		Content:       dao.Content,
+		CreatedAt:     dao.CreatedAt.Format(time.RFC3339),
+		Hashtags:      dao.Hashtags,
+		Mentions:      dao.Mentions,
+		ReplyToID:     dao.ReplyToID,
+		ReplyToUserID: dao.ReplyToUserID,
+		RetweetOfID:   dao.RetweetOfID,
 	}
 }
 
@@ -31,9 +41,14 @@ func ToTweetDAOModel(tweetModel dmntweet.Tweet) TweetDAO {
 		createdAt = time.Now()
 	}
 	return TweetDAO{
-		ID:        tweetModel.ID,
-		UserID:    tweetModel.UserID,
-		Content:   tweetModel.Content,
-		CreatedAt: createdAt,
+		ID:            tweetModel.ID,
+		UserID:        tweetModel.UserID,
+		Content:       tweetModel.Content,
+		CreatedAt:     createdAt,
+		Hashtags:      tweetModel.Hashtags,
+		Mentions:      tweetModel.Mentions,
+		ReplyToID:     tweetModel.ReplyToID,
+		ReplyToUserID: tweetModel.ReplyToUserID,
+		RetweetOfID:   tweetModel.RetweetOfID,
 	}
 }
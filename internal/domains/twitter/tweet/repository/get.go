@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/daos"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -36,7 +37,9 @@ func (r *TweetRepository) Get(ctx context.Context, tweetID string) (dmntweet.Twe
 		r.logger.Warn("Tweet no encontrado",
 			zap.String("tweet_id", tweetID),
 		)
-		return dmntweet.Tweet{}, fmt.Errorf("tweet with ID %s not found", tweetID)
+		return dmntweet.Tweet{}, apperrors.NewNotFoundError(fmt.Sprintf("El tweet %s no existe", tweetID), dmntweet.ErrTweetNotFound).
+			WithCode(apperrors.CodeTweetNotFound).
+			WithDetails(map[string]any{"tweet_id": tweetID})
 	}
 
 	tweet := &daos.TweetDAO{}
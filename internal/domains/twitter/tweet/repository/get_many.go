@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/daos"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixTweetCache = "tweet:"
+
+	// maxBatchGetKeys es el límite de claves por BatchGetItem de DynamoDB.
+	maxBatchGetKeys     = 100
+	maxBatchGetAttempts = 3
+)
+
+// GetMany obtiene varios tweets por ID, primero desde Redis y después con
+// BatchGetItem para los que faltan. Los tweets que no existen no aparecen en el
+// resultado, que respeta el orden de ids.
+func (r *TweetRepository) GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return []dmntweet.Tweet{}, nil
+	}
+
+	found := make(map[string]dmntweet.Tweet, len(unique))
+	missing := r.getLocalTweets(unique, found)
+	missing = r.getCachedTweets(ctx, missing, found)
+
+	fetched := make([]dmntweet.Tweet, 0, len(missing))
+	for start := 0; start < len(missing); start += maxBatchGetKeys {
+		end := min(start+maxBatchGetKeys, len(missing))
+		tweets, err := r.batchGetTweets(ctx, missing[start:end])
+		if err != nil {
+			return nil, err
+		}
+		fetched = append(fetched, tweets...)
+	}
+
+	for _, tweet := range fetched {
+		found[tweet.ID] = tweet
+	}
+	r.cacheTweets(ctx, fetched)
+
+	tweets := make([]dmntweet.Tweet, 0, len(found))
+	for _, id := range unique {
+		if tweet, ok := found[id]; ok {
+			tweets = append(tweets, tweet)
+		}
+	}
+
+	r.logger.Debug("Tweets obtenidos por ID",
+		zap.Int("requested", len(unique)),
+		zap.Int("cache_hits", len(unique)-len(missing)),
+		zap.Int("found", len(tweets)),
+	)
+
+	return tweets, nil
+}
+
+// getLocalTweets completa found con los tweets del caché en memoria y devuelve
+// los IDs que hay que buscar en Redis.
+func (r *TweetRepository) getLocalTweets(ids []string, found map[string]dmntweet.Tweet) []string {
+	if r.localTweets == nil {
+		return ids
+	}
+
+	missing := make([]string, 0, len(ids))
+	for _, id := range ids {
+		tweet, ok := r.localTweets.Get(id)
+		metrics.ObserveCacheTier(metrics.CacheTweet, metrics.CacheTierL1, ok)
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		found[id] = tweet
+	}
+	return missing
+}
+
+// getCachedTweets completa found con los tweets cacheados y devuelve los IDs
+// que hay que buscar en DynamoDB. Un error de Redis sólo degrada a DynamoDB.
+func (r *TweetRepository) getCachedTweets(ctx context.Context, ids []string, found map[string]dmntweet.Tweet) []string {
+	if r.tweetCacheTTL <= 0 || len(ids) == 0 {
+		return ids
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = prefixTweetCache + id
+	}
+
+	values, err := r.redisClient.MGet(ctx, keys...)
+	if err != nil {
+		r.logger.Warn("Error obteniendo tweets desde caché", zap.Error(err))
+		return ids
+	}
+
+	missing := make([]string, 0, len(ids))
+	for i, id := range ids {
+		var tweet dmntweet.Tweet
+		if i >= len(values) || len(values[i]) == 0 || json.Unmarshal(values[i], &tweet) != nil {
+			metrics.ObserveCacheTier(metrics.CacheTweet, metrics.CacheTierL2, false)
+			missing = append(missing, id)
+			continue
+		}
+		metrics.ObserveCacheTier(metrics.CacheTweet, metrics.CacheTierL2, true)
+		found[id] = tweet
+		r.setLocalTweet(tweet)
+	}
+	return missing
+}
+
+func (r *TweetRepository) cacheTweets(ctx context.Context, tweets []dmntweet.Tweet) {
+	for _, tweet := range tweets {
+		r.setLocalTweet(tweet)
+	}
+	if r.tweetCacheTTL <= 0 {
+		return
+	}
+
+	for _, tweet := range tweets {
+		data, err := json.Marshal(tweet)
+		if err != nil {
+			continue
+		}
+		if err := r.redisClient.Set(ctx, prefixTweetCache+tweet.ID, data, r.tweetCacheTTL); err != nil {
+			r.logger.Warn("Error guardando tweet en caché",
+				zap.String("tweet_id", tweet.ID),
+				zap.Error(err))
+		}
+	}
+}
+
+func (r *TweetRepository) setLocalTweet(tweet dmntweet.Tweet) {
+	if r.localTweets != nil {
+		r.localTweets.Set(tweet.ID, tweet)
+	}
+}
+
+// batchGetTweets hace un BatchGetItem de hasta maxBatchGetKeys IDs y reintenta
+// las claves que DynamoDB devuelve como no procesadas.
+func (r *TweetRepository) batchGetTweets(ctx context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	keys := make([]map[string]types.AttributeValue, len(ids))
+	for i, id := range ids {
+		keys[i] = map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		}
+	}
+
+	requestItems := map[string]types.KeysAndAttributes{
+		r.tableName: {Keys: keys},
+	}
+
+	tweets := make([]dmntweet.Tweet, 0, len(ids))
+	for attempt := 1; len(requestItems) > 0; attempt++ {
+		if attempt > maxBatchGetAttempts {
+			err := fmt.Errorf("quedaron %d tweets sin procesar tras %d intentos", len(requestItems[r.tableName].Keys), maxBatchGetAttempts)
+			r.logger.Error("Error obteniendo tweets por lote", zap.Error(err))
+			return nil, err
+		}
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(time.Duration(attempt*50) * time.Millisecond):
+			}
+		}
+
+		result, err := r.dynamoDBClient.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: requestItems,
+		})
+		if err != nil {
+			r.logger.Error("Error obteniendo tweets por lote de DynamoDB",
+				zap.Int("keys_count", len(ids)),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		for _, item := range result.Responses[r.tableName] {
+			var dao daos.TweetDAO
+			if err := attributevalue.UnmarshalMap(item, &dao); err != nil {
+				r.logger.Error("Error al deserializar tweet", zap.Error(err))
+				return nil, err
+			}
+			tweets = append(tweets, daos.ToTweetModel(dao))
+		}
+
+		requestItems = result.UnprocessedKeys
+	}
+
+	return tweets, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/daos"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type hashtagCursor struct {
+	Tag     string `json:"t"`
+	SortKey string `json:"s"`
+}
+
+func (r *TweetRepository) SearchByHashtag(ctx context.Context, tag string, limit int, cursor string) ([]dmntweet.Tweet, string, error) {
+	r.logger.Debug("Buscando tweets por hashtag",
+		zap.String("tag", tag),
+		zap.Int("limit", limit),
+		zap.String("table_name", r.hashtagsTable),
+		zap.Bool("has_cursor", cursor != ""),
+	)
+
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.hashtagsTable),
+		KeyConditionExpression: aws.String("tag = :tag"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":tag": &types.AttributeValueMemberS{Value: tag},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(int32(limit)),
+	}
+
+	if cursor != "" {
+		startKey, err := decodeHashtagCursor(cursor, tag)
+		if err != nil {
+			r.logger.Warn("Cursor de hashtag inválido",
+				zap.String("tag", tag),
+				zap.String("cursor", cursor),
+				zap.Error(err),
+			)
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmntweet.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
+		}
+		input.ExclusiveStartKey = startKey
+	}
+
+	result, err := r.dynamoDBClient.Query(ctx, input)
+	if err != nil {
+		r.logger.Error("Error al consultar hashtags en DynamoDB",
+			zap.String("tag", tag),
+			zap.Error(err),
+		)
+		return nil, "", err
+	}
+
+	var entries []daos.HashtagDAO
+	if err := attributevalue.UnmarshalListOfMaps(result.Items, &entries); err != nil {
+		r.logger.Error("Error al deserializar hashtags de DynamoDB",
+			zap.String("tag", tag),
+			zap.Error(err),
+		)
+		return nil, "", err
+	}
+
+	tweets := make([]dmntweet.Tweet, len(entries))
+	for i, entry := range entries {
+		tweets[i] = daos.HashtagToTweetModel(entry)
+	}
+
+	var nextCursor string
+	if result.LastEvaluatedKey != nil && len(entries) > 0 {
+		nextCursor, err = encodeHashtagCursor(hashtagCursor{
+			Tag:     tag,
+			SortKey: entries[len(entries)-1].SortKey,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	r.logger.Debug("Tweets por hashtag encontrados",
+		zap.String("tag", tag),
+		zap.Int("count", len(tweets)),
+		zap.Bool("has_next_page", nextCursor != ""),
+	)
+
+	return tweets, nextCursor, nil
+}
+
+func encodeHashtagCursor(cursor hashtagCursor) (string, error) {
+	bytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func decodeHashtagCursor(token, tag string) (map[string]types.AttributeValue, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor hashtagCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Tag != tag || cursor.SortKey == "" {
+		return nil, dmntweet.ErrInvalidCursor
+	}
+
+	return map[string]types.AttributeValue{
+		"tag":      &types.AttributeValueMemberS{Value: cursor.Tag},
+		"sort_key": &types.AttributeValueMemberS{Value: cursor.SortKey},
+	}, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"time"
 )
 
 type DBInterface interface {
@@ -11,6 +12,13 @@ type DBInterface interface {
 	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
 }
 
+type RedisClientInterface interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	MGet(ctx context.Context, keys ...string) ([][]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+}
+
 type LoggerInterface interface {
 	Error(msg string, keysAndValues ...interface{})
 	Info(msg string, keysAndValues ...interface{})
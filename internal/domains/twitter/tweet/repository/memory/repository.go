@@ -0,0 +1,195 @@
+// Package memory guarda los tweets en memoria, con las mismas reglas de orden,
+// filtros y paginación que el repositorio de DynamoDB. No tiene caché propia:
+// el mapa ya es la fuente de verdad.
+package memory
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+type Repository struct {
+	mu     sync.RWMutex
+	tweets map[string]dmntweet.Tweet
+}
+
+func NewRepository() *Repository {
+	return &Repository{tweets: make(map[string]dmntweet.Tweet)}
+}
+
+// pageCursor es la posición del último tweet devuelto; Scope es el usuario o
+// el hashtag de la consulta, para rechazar cursores de otra.
+type pageCursor struct {
+	Scope     string `json:"s"`
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
+func (r *Repository) Create(_ context.Context, tweet dmntweet.Tweet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tweets[tweet.ID] = normalize(tweet)
+	return nil
+}
+
+func (r *Repository) Get(_ context.Context, tweetID string) (dmntweet.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweet, ok := r.tweets[tweetID]
+	if !ok {
+		return dmntweet.Tweet{}, apperrors.NewNotFoundError(fmt.Sprintf("El tweet %s no existe", tweetID), dmntweet.ErrTweetNotFound).
+			WithCode(apperrors.CodeTweetNotFound).
+			WithDetails(map[string]any{"tweet_id": tweetID})
+	}
+	return tweet, nil
+}
+
+func (r *Repository) GetMany(_ context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweets := make([]dmntweet.Tweet, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		if tweet, ok := r.tweets[id]; ok {
+			tweets = append(tweets, tweet)
+		}
+	}
+	return tweets, nil
+}
+
+func (r *Repository) Search(_ context.Context, userID string, limit int, cursor string, filters options.SearchFilters) ([]dmntweet.Tweet, string, error) {
+	return r.page(userID, limit, cursor, func(tweet dmntweet.Tweet) bool {
+		if tweet.UserID != userID {
+			return false
+		}
+		if filters.ExcludeReplies && tweet.ReplyToID != "" {
+			return false
+		}
+		if filters.ExcludeRetweets && tweet.RetweetOfID != "" {
+			return false
+		}
+		return true
+	})
+}
+
+func (r *Repository) SearchByHashtag(_ context.Context, tag string, limit int, cursor string) ([]dmntweet.Tweet, string, error) {
+	return r.page(tag, limit, cursor, func(tweet dmntweet.Tweet) bool {
+		for _, hashtag := range tweet.Hashtags {
+			if hashtag == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (r *Repository) GetCachedUserTweets(context.Context, string, options.SearchFilters) (dmntweet.TweetsPage, bool) {
+	return dmntweet.TweetsPage{}, false
+}
+
+func (r *Repository) SetCachedUserTweets(context.Context, string, options.SearchFilters, dmntweet.TweetsPage) error {
+	return nil
+}
+
+func (r *Repository) InvalidateUserTweets(context.Context, string) error {
+	return nil
+}
+
+// page devuelve hasta limit tweets que cumplen match, del más nuevo al más
+// viejo, a partir de cursor.
+func (r *Repository) page(scope string, limit int, cursor string, match func(dmntweet.Tweet) bool) ([]dmntweet.Tweet, string, error) {
+	var after *pageCursor
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor, scope)
+		if err != nil {
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmntweet.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
+		}
+		after = &decoded
+	}
+
+	r.mu.RLock()
+	matched := make([]dmntweet.Tweet, 0)
+	for _, tweet := range r.tweets {
+		if match(tweet) {
+			matched = append(matched, tweet)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		return newer(matched[i].CreatedAt, matched[i].ID, matched[j].CreatedAt, matched[j].ID)
+	})
+
+	tweets := make([]dmntweet.Tweet, 0, limit)
+	var nextCursor string
+	for _, tweet := range matched {
+		if after != nil && !newer(after.CreatedAt, after.ID, tweet.CreatedAt, tweet.ID) {
+			continue
+		}
+		if len(tweets) == limit {
+			last := tweets[len(tweets)-1]
+			nextCursor = encodeCursor(pageCursor{Scope: scope, CreatedAt: last.CreatedAt, ID: last.ID})
+			break
+		}
+		tweets = append(tweets, tweet)
+	}
+	return tweets, nextCursor, nil
+}
+
+// newer ordena como los índices de DynamoDB: por fecha y, a igual fecha, por
+// ID, ambos descendentes.
+func newer(createdAtA, idA, createdAtB, idB string) bool {
+	if createdAtA != createdAtB {
+		return createdAtA > createdAtB
+	}
+	return idA > idB
+}
+
+// normalize lleva la fecha a RFC3339 en UTC, como sale de DynamoDB, para que
+// el orden lexicográfico coincida con el cronológico.
+func normalize(tweet dmntweet.Tweet) dmntweet.Tweet {
+	createdAt, err := time.Parse(time.RFC3339, tweet.CreatedAt)
+	if err != nil {
+		createdAt = time.Now()
+	}
+	tweet.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	return tweet
+}
+
+func encodeCursor(cursor pageCursor) string {
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func decodeCursor(token, scope string) (pageCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, err
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return pageCursor{}, err
+	}
+	if cursor.Scope != scope || cursor.CreatedAt == "" || cursor.ID == "" {
+		return pageCursor{}, dmntweet.ErrInvalidCursor
+	}
+	return cursor, nil
+}
@@ -0,0 +1,65 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/memory"
+)
+
+func TestRepository_SearchPaginatesNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	for _, tweet := range []dmntweet.Tweet{
+		{ID: "twt-1", UserID: "u1", Content: "uno", CreatedAt: "2026-10-19T10:00:00Z"},
+		{ID: "twt-2", UserID: "u1", Content: "dos", CreatedAt: "2026-10-19T11:00:00Z", ReplyToID: "twt-9"},
+		{ID: "twt-3", UserID: "u1", Content: "tres", CreatedAt: "2026-10-19T12:00:00Z"},
+		{ID: "twt-4", UserID: "u2", Content: "otro", CreatedAt: "2026-10-19T13:00:00Z"},
+	} {
+		require.NoError(t, repo.Create(ctx, tweet))
+	}
+
+	page, cursor, err := repo.Search(ctx, "u1", 2, "", options.NewSearchFilters())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"twt-3", "twt-2"}, ids(page))
+	require.NotEmpty(t, cursor)
+
+	page, cursor, err = repo.Search(ctx, "u1", 2, cursor, options.NewSearchFilters())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"twt-1"}, ids(page))
+	assert.Empty(t, cursor)
+
+	page, _, err = repo.Search(ctx, "u1", 10, "", options.NewSearchFilters().WithExcludeReplies(true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"twt-3", "twt-1"}, ids(page))
+
+	_, _, err = repo.Search(ctx, "u2", 2, "no-es-un-cursor", options.NewSearchFilters())
+	assert.ErrorIs(t, err, dmntweet.ErrInvalidCursor)
+}
+
+func TestRepository_GetMany(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRepository()
+	require.NoError(t, repo.Create(ctx, dmntweet.Tweet{ID: "twt-1", UserID: "u1", Content: "uno"}))
+	require.NoError(t, repo.Create(ctx, dmntweet.Tweet{ID: "twt-2", UserID: "u1", Content: "dos"}))
+
+	tweets, err := repo.GetMany(ctx, []string{"twt-2", "twt-missing", "twt-1", "twt-2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"twt-2", "twt-1"}, ids(tweets))
+
+	_, err = repo.Get(ctx, "twt-missing")
+	assert.ErrorIs(t, err, dmntweet.ErrTweetNotFound)
+}
+
+func ids(tweets []dmntweet.Tweet) []string {
+	result := make([]string, len(tweets))
+	for i, tweet := range tweets {
+		result[i] = tweet.ID
+	}
+	return result
+}
@@ -4,23 +4,41 @@ import (
 	"encoding/json"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
 	"github.com/juanmalvarez3/twit/pkg/logger"
+	"time"
 )
 
 type TweetRepository struct {
 	dynamoDBClient *dynamodb.Client
+	redisClient    RedisClientInterface
 	tableName      string
+	hashtagsTable  string
+	cacheTTL       time.Duration
+	tweetCacheTTL  time.Duration
+	localTweets    *localcache.LRU[dmntweet.Tweet]
 	logger         *logger.Logger
 }
 
 func NewTweetRepository(
 	dynamoDBClient *dynamodb.Client,
+	redisClient RedisClientInterface,
 	tableName string,
+	hashtagsTable string,
+	cacheTTL time.Duration,
+	tweetCacheTTL time.Duration,
+	localTweets *localcache.LRU[dmntweet.Tweet],
 	logger *logger.Logger,
 ) *TweetRepository {
 	return &TweetRepository{
 		dynamoDBClient: dynamoDBClient,
+		redisClient:    redisClient,
 		tableName:      tableName,
+		hashtagsTable:  hashtagsTable,
+		cacheTTL:       cacheTTL,
+		tweetCacheTTL:  tweetCacheTTL,
+		localTweets:    localTweets,
 		logger:         logger,
 	}
 }
@@ -2,34 +2,52 @@ package repository
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/repository/daos"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
 	"go.uber.org/zap"
-	"time"
 )
 
-type TweetDAO struct {
-	ID        string    `dynamodbav:"id"`
-	UserID    string    `dynamodbav:"user_id"`
-	Content   string    `dynamodbav:"content"`
-	CreatedAt time.Time `dynamodbav:"created_at"`
+const (
+	userIndexName = "user_id-created_at-index"
+
+	// maxSearchQueries acota las lecturas de una página cuando los filtros
+	// descartan muchos ítems; si se alcanza, la página sale incompleta pero con
+	// cursor para seguir.
+	maxSearchQueries = 5
+)
+
+// userTweetsCursor es la clave del último tweet devuelto en el índice por
+// usuario. Se codifica en base64url para que sea opaco para el cliente.
+type userTweetsCursor struct {
+	UserID    string `json:"u"`
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
 }
 
-func (r *TweetRepository) Search(ctx context.Context, userID string, limit int, lastEvaluatedKey string) ([]dmntweet.Tweet, string, error) {
+func (r *TweetRepository) Search(ctx context.Context, userID string, limit int, cursor string, filters options.SearchFilters) ([]dmntweet.Tweet, string, error) {
 	r.logger.Debug("Buscando tweets por usuario",
 		zap.String("user_id", userID),
 		zap.Int("limit", limit),
 		zap.String("table_name", r.tableName),
-		zap.String("index_name", "user_id-created_at-index"),
-		zap.Bool("has_pagination_token", lastEvaluatedKey != ""),
+		zap.String("index_name", userIndexName),
+		zap.Bool("has_cursor", cursor != ""),
+		zap.Bool("exclude_replies", filters.ExcludeReplies),
+		zap.Bool("exclude_retweets", filters.ExcludeRetweets),
 	)
 
 	input := &dynamodb.QueryInput{
 		TableName:              aws.String(r.tableName),
-		IndexName:              aws.String("user_id-created_at-index"),
+		IndexName:              aws.String(userIndexName),
 		KeyConditionExpression: aws.String("user_id = :userID"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":userID": &types.AttributeValueMemberS{Value: userID},
@@ -37,36 +55,55 @@ func (r *TweetRepository) Search(ctx context.Context, userID string, limit int,
 		ScanIndexForward: aws.Bool(false),
 		Limit:            aws.Int32(int32(limit)),
 	}
+	if filter := searchFilterExpression(filters); filter != "" {
+		input.FilterExpression = aws.String(filter)
+	}
 
-	if lastEvaluatedKey != "" {
-		exclusiveStartKey, err := r.deserializeLastEvaluatedKey(lastEvaluatedKey)
+	if cursor != "" {
+		startKey, err := decodeUserTweetsCursor(cursor, userID)
 		if err != nil {
-			r.logger.Error("Error al deserializar token de paginación",
-				zap.String("token", lastEvaluatedKey),
+			r.logger.Warn("Cursor de tweets de usuario inválido",
+				zap.String("user_id", userID),
+				zap.String("cursor", cursor),
 				zap.Error(err),
 			)
-			return nil, "", err
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmntweet.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
 		}
-		input.ExclusiveStartKey = exclusiveStartKey
+		input.ExclusiveStartKey = startKey
 	}
 
-	result, err := r.dynamoDBClient.Query(ctx, input)
-	if err != nil {
-		r.logger.Error("Error al consultar tweets en DynamoDB",
-			zap.String("user_id", userID),
-			zap.Error(err),
-		)
-		return nil, "", err
+	// Los filtros se aplican después del Limit de DynamoDB, así que una
+	// consulta puede devolver menos tweets que los pedidos aunque haya más.
+	var items []map[string]types.AttributeValue
+	var lastKey map[string]types.AttributeValue
+	for query := 0; query < maxSearchQueries; query++ {
+		result, err := r.dynamoDBClient.Query(ctx, input)
+		if err != nil {
+			r.logger.Error("Error al consultar tweets en DynamoDB",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+			return nil, "", err
+		}
+
+		items = append(items, result.Items...)
+		lastKey = result.LastEvaluatedKey
+		if len(items) >= limit || lastKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = lastKey
 	}
 
-	r.logger.Debug("Consulta de tweets completada",
-		zap.String("user_id", userID),
-		zap.Int("items_count", len(result.Items)),
-		zap.Bool("has_more", result.LastEvaluatedKey != nil),
-	)
+	if len(items) > limit {
+		items = items[:limit]
+		lastKey = items[limit-1]
+	} else if len(items) == limit && lastKey != nil {
+		lastKey = items[limit-1]
+	}
 
-	var daos []TweetDAO
-	if err := attributevalue.UnmarshalListOfMaps(result.Items, &daos); err != nil {
+	var tweetDAOs []daos.TweetDAO
+	if err := attributevalue.UnmarshalListOfMaps(items, &tweetDAOs); err != nil {
 		r.logger.Error("Error al deserializar tweets de DynamoDB",
 			zap.String("user_id", userID),
 			zap.Error(err),
@@ -74,21 +111,18 @@ func (r *TweetRepository) Search(ctx context.Context, userID string, limit int,
 		return nil, "", err
 	}
 
-	tweets := make([]dmntweet.Tweet, len(daos))
-	for i, dao := range daos {
-		tweets[i] = dmntweet.Tweet{
-			ID:        dao.ID,
-			UserID:    dao.UserID,
-			Content:   dao.Content,
-			CreatedAt: dao.CreatedAt.Format(time.RFC3339),
-		}
+	tweets := make([]dmntweet.Tweet, len(tweetDAOs))
+	for i, dao := range tweetDAOs {
+		tweets[i] = daos.ToTweetModel(dao)
 	}
 
-	var nextToken string
-	if result.LastEvaluatedKey != nil {
-		nextToken, err = r.serializeLastEvaluatedKey(result.LastEvaluatedKey)
+	var nextCursor string
+	if lastKey != nil {
+		var err error
+		nextCursor, err = encodeUserTweetsCursor(lastKey)
 		if err != nil {
-			r.logger.Error("Error al serializar token de paginación",
+			r.logger.Error("Error al generar cursor de paginación",
+				zap.String("user_id", userID),
 				zap.Error(err),
 			)
 			return nil, "", err
@@ -98,8 +132,63 @@ func (r *TweetRepository) Search(ctx context.Context, userID string, limit int,
 	r.logger.Debug("Tweets encontrados exitosamente",
 		zap.String("user_id", userID),
 		zap.Int("count", len(tweets)),
-		zap.Bool("has_next_page", nextToken != ""),
+		zap.Bool("has_next_page", nextCursor != ""),
 	)
 
-	return tweets, nextToken, nil
+	return tweets, nextCursor, nil
+}
+
+func searchFilterExpression(filters options.SearchFilters) string {
+	var conditions []string
+	if filters.ExcludeReplies {
+		conditions = append(conditions, "attribute_not_exists(reply_to_id)")
+	}
+	if filters.ExcludeRetweets {
+		conditions = append(conditions, "attribute_not_exists(retweet_of_id)")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// encodeUserTweetsCursor acepta tanto un ítem como un LastEvaluatedKey: los
+// dos tienen las claves de la tabla y del índice.
+func encodeUserTweetsCursor(key map[string]types.AttributeValue) (string, error) {
+	cursor := userTweetsCursor{
+		UserID:    stringAttribute(key, "user_id"),
+		CreatedAt: stringAttribute(key, "created_at"),
+		ID:        stringAttribute(key, "id"),
+	}
+
+	bytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func stringAttribute(item map[string]types.AttributeValue, name string) string {
+	if value, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return value.Value
+	}
+	return ""
+}
+
+func decodeUserTweetsCursor(token, userID string) (map[string]types.AttributeValue, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor userTweetsCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.UserID != userID || cursor.CreatedAt == "" || cursor.ID == "" {
+		return nil, dmntweet.ErrInvalidCursor
+	}
+
+	return map[string]types.AttributeValue{
+		"user_id":    &types.AttributeValueMemberS{Value: cursor.UserID},
+		"created_at": &types.AttributeValueMemberS{Value: cursor.CreatedAt},
+		"id":         &types.AttributeValueMemberS{Value: cursor.ID},
+	}, nil
 }
@@ -0,0 +1,277 @@
+// Package sqlstore guarda los tweets en una base relacional, con las mismas
+// reglas de orden, filtros y paginación que el repositorio de DynamoDB. El
+// índice por hashtag es la tabla tweet_hashtags. No tiene caché propia.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+const tweetColumns = "t.id, t.user_id, t.content, t.created_at, t.hashtags, t.mentions, t.reply_to_id, t.reply_to_user_id, t.retweet_of_id"
+
+type Repository struct {
+	db *sqldb.DB
+}
+
+func NewRepository(db *sqldb.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// pageCursor es la posición del último tweet devuelto; Scope es el usuario o
+// el hashtag de la consulta, para rechazar cursores de otra.
+type pageCursor struct {
+	Scope     string `json:"s"`
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
+// Create guarda el tweet y sus entradas de hashtag en una transacción. Si el
+// tweet ya existía lo sobrescribe, como PutItem.
+func (r *Repository) Create(ctx context.Context, tweet dmntweet.Tweet) error {
+	tweet = normalize(tweet)
+	hashtags, err := json.Marshal(nonNil(tweet.Hashtags))
+	if err != nil {
+		return err
+	}
+	mentions, err := json.Marshal(nonNil(tweet.Mentions))
+	if err != nil {
+		return err
+	}
+
+	return r.db.InTx(ctx, func(tx *sqldb.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO tweets
+			(id, user_id, content, created_at, hashtags, mentions, reply_to_id, reply_to_user_id, retweet_of_id)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET
+				user_id = excluded.user_id,
+				content = excluded.content,
+				created_at = excluded.created_at,
+				hashtags = excluded.hashtags,
+				mentions = excluded.mentions,
+				reply_to_id = excluded.reply_to_id,
+				reply_to_user_id = excluded.reply_to_user_id,
+				retweet_of_id = excluded.retweet_of_id`,
+			tweet.ID, tweet.UserID, tweet.Content, tweet.CreatedAt, string(hashtags), string(mentions),
+			tweet.ReplyToID, tweet.ReplyToUserID, tweet.RetweetOfID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM tweet_hashtags WHERE tweet_id = ?", tweet.ID); err != nil {
+			return err
+		}
+		for _, tag := range tweet.Hashtags {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO tweet_hashtags (tag, created_at, tweet_id) VALUES (?, ?, ?)
+				ON CONFLICT DO NOTHING`, tag, tweet.CreatedAt, tweet.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Get(ctx context.Context, tweetID string) (dmntweet.Tweet, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+tweetColumns+" FROM tweets t WHERE t.id = ?", tweetID)
+	tweet, err := scanTweet(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dmntweet.Tweet{}, apperrors.NewNotFoundError(fmt.Sprintf("El tweet %s no existe", tweetID), dmntweet.ErrTweetNotFound).
+			WithCode(apperrors.CodeTweetNotFound).
+			WithDetails(map[string]any{"tweet_id": tweetID})
+	}
+	return tweet, err
+}
+
+// GetMany devuelve los tweets que existen en el orden de ids, sin repetidos.
+func (r *Repository) GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return []dmntweet.Tweet{}, nil
+	}
+
+	args := make([]any, len(unique))
+	for i, id := range unique {
+		args[i] = id
+	}
+	byID, err := r.query(ctx, "SELECT "+tweetColumns+" FROM tweets t WHERE t.id IN ("+sqldb.Placeholders(len(args))+")", args...)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]dmntweet.Tweet, len(byID))
+	for _, tweet := range byID {
+		found[tweet.ID] = tweet
+	}
+	tweets := make([]dmntweet.Tweet, 0, len(found))
+	for _, id := range unique {
+		if tweet, ok := found[id]; ok {
+			tweets = append(tweets, tweet)
+		}
+	}
+	return tweets, nil
+}
+
+func (r *Repository) Search(ctx context.Context, userID string, limit int, cursor string, filters options.SearchFilters) ([]dmntweet.Tweet, string, error) {
+	conditions := []string{"t.user_id = ?"}
+	args := []any{userID}
+	if filters.ExcludeReplies {
+		conditions = append(conditions, "t.reply_to_id = ''")
+	}
+	if filters.ExcludeRetweets {
+		conditions = append(conditions, "t.retweet_of_id = ''")
+	}
+	return r.page(ctx, userID, limit, cursor, "FROM tweets t", conditions, args, "t.created_at", "t.id")
+}
+
+func (r *Repository) SearchByHashtag(ctx context.Context, tag string, limit int, cursor string) ([]dmntweet.Tweet, string, error) {
+	return r.page(ctx, tag, limit, cursor, "FROM tweet_hashtags h JOIN tweets t ON t.id = h.tweet_id",
+		[]string{"h.tag = ?"}, []any{tag}, "h.created_at", "h.tweet_id")
+}
+
+func (r *Repository) GetCachedUserTweets(context.Context, string, options.SearchFilters) (dmntweet.TweetsPage, bool) {
+	return dmntweet.TweetsPage{}, false
+}
+
+func (r *Repository) SetCachedUserTweets(context.Context, string, options.SearchFilters, dmntweet.TweetsPage) error {
+	return nil
+}
+
+func (r *Repository) InvalidateUserTweets(context.Context, string) error {
+	return nil
+}
+
+// page devuelve hasta limit tweets de la consulta, del más nuevo al más
+// viejo, a partir de cursor. Pide uno de más para saber si hay otra página.
+func (r *Repository) page(ctx context.Context, scope string, limit int, cursor, from string, conditions []string, args []any, createdAtColumn, idColumn string) ([]dmntweet.Tweet, string, error) {
+	if cursor != "" {
+		after, err := decodeCursor(cursor, scope)
+		if err != nil {
+			return nil, "", apperrors.NewInvalidInputError("El cursor de paginación no es válido", dmntweet.ErrInvalidCursor).
+				WithCode(apperrors.CodeInvalidCursor)
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s < ? OR (%s = ? AND %s < ?))", createdAtColumn, createdAtColumn, idColumn))
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	if limit <= 0 {
+		return []dmntweet.Tweet{}, "", nil
+	}
+
+	query := fmt.Sprintf("SELECT %s %s WHERE %s ORDER BY %s DESC, %s DESC LIMIT ?",
+		tweetColumns, from, strings.Join(conditions, " AND "), createdAtColumn, idColumn)
+	tweets, err := r.query(ctx, query, append(args, limit+1)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(tweets) > limit {
+		tweets = tweets[:limit]
+		last := tweets[len(tweets)-1]
+		nextCursor = encodeCursor(pageCursor{Scope: scope, CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return tweets, nextCursor, nil
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...any) ([]dmntweet.Tweet, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tweets := make([]dmntweet.Tweet, 0)
+	for rows.Next() {
+		tweet, err := scanTweet(rows)
+		if err != nil {
+			return nil, err
+		}
+		tweets = append(tweets, tweet)
+	}
+	return tweets, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTweet(row scanner) (dmntweet.Tweet, error) {
+	var (
+		tweet              dmntweet.Tweet
+		hashtags, mentions string
+	)
+	if err := row.Scan(&tweet.ID, &tweet.UserID, &tweet.Content, &tweet.CreatedAt, &hashtags, &mentions,
+		&tweet.ReplyToID, &tweet.ReplyToUserID, &tweet.RetweetOfID); err != nil {
+		return dmntweet.Tweet{}, err
+	}
+	if err := json.Unmarshal([]byte(hashtags), &tweet.Hashtags); err != nil {
+		return dmntweet.Tweet{}, err
+	}
+	if err := json.Unmarshal([]byte(mentions), &tweet.Mentions); err != nil {
+		return dmntweet.Tweet{}, err
+	}
+	if len(tweet.Hashtags) == 0 {
+		tweet.Hashtags = nil
+	}
+	if len(tweet.Mentions) == 0 {
+		tweet.Mentions = nil
+	}
+	return tweet, nil
+}
+
+// normalize lleva la fecha a RFC3339 en UTC, como sale de DynamoDB, para que
+// el orden lexicográfico coincida con el cronológico.
+func normalize(tweet dmntweet.Tweet) dmntweet.Tweet {
+	createdAt, err := time.Parse(time.RFC3339, tweet.CreatedAt)
+	if err != nil {
+		createdAt = time.Now()
+	}
+	tweet.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	return tweet
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func encodeCursor(cursor pageCursor) string {
+	bytes, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func decodeCursor(token, scope string) (pageCursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, err
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(bytes, &cursor); err != nil {
+		return pageCursor{}, err
+	}
+	if cursor.Scope != scope || cursor.CreatedAt == "" || cursor.ID == "" {
+		return pageCursor{}, dmntweet.ErrInvalidCursor
+	}
+	return cursor, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+const prefixUserTweetsCache = "user_tweets:"
+
+// userTweetsCacheKey identifica la primera página de tweets de un usuario para
+// una combinación de filtros. InvalidateUserTweets borra todas.
+func userTweetsCacheKey(userID string, filters options.SearchFilters) string {
+	return fmt.Sprintf("%s%s:%t:%t", prefixUserTweetsCache, userID, filters.ExcludeReplies, filters.ExcludeRetweets)
+}
+
+func userTweetsCacheKeys(userID string) []string {
+	keys := make([]string, 0, 4)
+	for _, excludeReplies := range []bool{false, true} {
+		for _, excludeRetweets := range []bool{false, true} {
+			keys = append(keys, userTweetsCacheKey(userID, options.NewSearchFilters().
+				WithExcludeReplies(excludeReplies).
+				WithExcludeRetweets(excludeRetweets)))
+		}
+	}
+	return keys
+}
+
+func (r *TweetRepository) GetCachedUserTweets(ctx context.Context, userID string, filters options.SearchFilters) (dmntweet.TweetsPage, bool) {
+	if r.cacheTTL <= 0 {
+		return dmntweet.TweetsPage{}, false
+	}
+
+	data, err := r.redisClient.Get(ctx, userTweetsCacheKey(userID, filters))
+	if err != nil || len(data) == 0 {
+		metrics.ObserveCacheTier(metrics.CacheUserTweets, metrics.CacheTierL2, false)
+		return dmntweet.TweetsPage{}, false
+	}
+
+	var page dmntweet.TweetsPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		r.logger.Warn("Error deserializando tweets de usuario desde caché",
+			zap.String("user_id", userID),
+			zap.Error(err))
+		metrics.ObserveCacheTier(metrics.CacheUserTweets, metrics.CacheTierL2, false)
+		return dmntweet.TweetsPage{}, false
+	}
+
+	metrics.ObserveCacheTier(metrics.CacheUserTweets, metrics.CacheTierL2, true)
+	return page, true
+}
+
+func (r *TweetRepository) SetCachedUserTweets(ctx context.Context, userID string, filters options.SearchFilters, page dmntweet.TweetsPage) error {
+	if r.cacheTTL <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, userTweetsCacheKey(userID, filters), data, r.cacheTTL)
+}
+
+func (r *TweetRepository) InvalidateUserTweets(ctx context.Context, userID string) error {
+	if r.cacheTTL <= 0 {
+		return nil
+	}
+	return r.redisClient.Del(ctx, userTweetsCacheKeys(userID)...)
+}
@@ -13,6 +13,16 @@ func (s Service) Create(ctx context.Context, twt dmntweet.Tweet) (dmntweet.Tweet
 		return dmntweet.Tweet{}, err
 	}
 
+	// La primera página del perfil del autor queda desactualizada.
+	if err := s.repository.InvalidateUserTweets(ctx, twt.UserID); err != nil {
+		s.logger.Warn("Error invalidando caché de tweets del usuario",
+			zap.String("tweet_id", twt.ID),
+			zap.String("user_id", twt.UserID),
+			zap.Error(err),
+			zap.String("action", actionCreate),
+		)
+	}
+
 	err = s.publisher.Publish(ctx, events.Event{
 		Type:  events.TweetCreatedEventType,
 		Tweet: twt,
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"fmt"
+
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -27,7 +30,9 @@ func (s Service) Get(ctx context.Context, id string) (dmntweet.Tweet, error) {
 			zap.String("tweet_id", id),
 			zap.String("action", actionGet),
 		)
-		return dmntweet.Tweet{}, dmntweet.ErrTweetNotFound
+		return dmntweet.Tweet{}, apperrors.NewNotFoundError(fmt.Sprintf("El tweet %s no existe", id), dmntweet.ErrTweetNotFound).
+			WithCode(apperrors.CodeTweetNotFound).
+			WithDetails(map[string]any{"tweet_id": id})
 	}
 
 	s.logger.Debug("Tweet obtenido exitosamente", 
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"go.uber.org/zap"
+)
+
+// GetMany devuelve los tweets que existen entre los IDs pedidos, en el mismo
+// orden; los que no existen se omiten.
+func (s Service) GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error) {
+	s.logger.Debug("Obteniendo tweets por ID",
+		zap.Int("ids_count", len(ids)),
+		zap.String("action", actionGetMany),
+	)
+
+	tweets, err := s.repository.GetMany(ctx, ids)
+	if err != nil {
+		s.logger.Error("Error al obtener tweets por ID",
+			zap.Int("ids_count", len(ids)),
+			zap.Error(err),
+			zap.String("action", actionGetMany),
+		)
+		return nil, err
+	}
+
+	return tweets, nil
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
 	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/events"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
 )
 
 type Repository interface {
 	Create(ctx context.Context, tweet dmntweet.Tweet) error
 	Get(ctx context.Context, tweetID string) (dmntweet.Tweet, error)
-	Search(ctx context.Context, userID string, limit int, lastEvaluatedKey string) ([]dmntweet.Tweet, string, error)
+	GetMany(ctx context.Context, ids []string) ([]dmntweet.Tweet, error)
+	Search(ctx context.Context, userID string, limit int, cursor string, filters options.SearchFilters) ([]dmntweet.Tweet, string, error)
+	SearchByHashtag(ctx context.Context, tag string, limit int, cursor string) ([]dmntweet.Tweet, string, error)
+	GetCachedUserTweets(ctx context.Context, userID string, filters options.SearchFilters) (dmntweet.TweetsPage, bool)
+	SetCachedUserTweets(ctx context.Context, userID string, filters options.SearchFilters, page dmntweet.TweetsPage) error
+	InvalidateUserTweets(ctx context.Context, userID string) error
 }
 
 type Publisher interface {
@@ -12,20 +12,23 @@ const (
 	maxLimit     = 100
 )
 
+// Search devuelve los tweets de un usuario, del más nuevo al más viejo, y el
+// cursor de la página siguiente. La primera página con el límite por defecto
+// se sirve desde caché.
 func (s Service) Search(ctx context.Context, opts options.SearchOptions) ([]dmntweet.Tweet, string, error) {
 	userID := "<no_user_id>"
 	if opts.Filters.UserID != nil {
 		userID = *opts.Filters.UserID
 	}
-	
-	s.logger.Debug("Buscando tweets", 
+
+	s.logger.Debug("Buscando tweets",
 		zap.String("user_id", userID),
 		zap.Int("limit_request", opts.Pagination.Limit),
-		zap.Int("offset_request", opts.Pagination.Offset),
+		zap.Bool("has_cursor", opts.Pagination.Cursor != ""),
 		zap.String("action", actionSearch),
 	)
 
-	if opts.Pagination.Limit == 0 {
+	if opts.Pagination.Limit <= 0 {
 		opts.Pagination = opts.Pagination.WithLimit(defaultLimit)
 		s.logger.Debug("Ajustando límite a valor por defecto",
 			zap.Int("default_limit", defaultLimit),
@@ -41,13 +44,21 @@ func (s Service) Search(ctx context.Context, opts options.SearchOptions) ([]dmnt
 		)
 	}
 
-	if opts.Pagination.Offset <= 0 {
-		opts.Pagination = opts.Pagination.WithOffset(0)
+	cacheable := opts.Pagination.Cursor == "" && opts.Pagination.Limit == defaultLimit
+	if cacheable {
+		if page, ok := s.repository.GetCachedUserTweets(ctx, userID, opts.Filters); ok {
+			s.logger.Debug("Tweets obtenidos desde caché",
+				zap.String("user_id", userID),
+				zap.Int("count", len(page.Tweets)),
+				zap.String("action", actionSearch),
+			)
+			return page.Tweets, page.NextCursor, nil
+		}
 	}
 
-	twtList, total, err := s.repository.Search(ctx, userID, opts.Pagination.Limit, "")
+	twtList, nextCursor, err := s.repository.Search(ctx, userID, opts.Pagination.Limit, opts.Pagination.Cursor, opts.Filters)
 	if err != nil {
-		s.logger.Error("Error al buscar tweets", 
+		s.logger.Error("Error al buscar tweets",
 			zap.String("user_id", userID),
 			zap.Int("limit", opts.Pagination.Limit),
 			zap.Error(err),
@@ -56,12 +67,23 @@ func (s Service) Search(ctx context.Context, opts options.SearchOptions) ([]dmnt
 		return nil, "", err
 	}
 
-	s.logger.Debug("Tweets encontrados exitosamente", 
+	if cacheable {
+		page := dmntweet.TweetsPage{Tweets: twtList, NextCursor: nextCursor}
+		if err := s.repository.SetCachedUserTweets(ctx, userID, opts.Filters, page); err != nil {
+			s.logger.Warn("Error guardando tweets del usuario en caché",
+				zap.String("user_id", userID),
+				zap.Error(err),
+				zap.String("action", actionSearch),
+			)
+		}
+	}
+
+	s.logger.Debug("Tweets encontrados exitosamente",
 		zap.String("user_id", userID),
 		zap.Int("count", len(twtList)),
-		zap.String("total", total),
+		zap.Bool("has_next_page", nextCursor != ""),
 		zap.String("action", actionSearch),
 	)
 
-	return twtList, total, nil
+	return twtList, nextCursor, nil
 }
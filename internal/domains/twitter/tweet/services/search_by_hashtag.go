@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"go.uber.org/zap"
+)
+
+func (s Service) SearchByHashtag(ctx context.Context, tag string, limit int, cursor string) (dmntweet.TweetsPage, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	s.logger.Debug("Buscando tweets por hashtag",
+		zap.String("tag", tag),
+		zap.Int("limit", limit),
+		zap.String("action", actionSearchByHashtag),
+	)
+
+	tweets, nextCursor, err := s.repository.SearchByHashtag(ctx, tag, limit, cursor)
+	if err != nil {
+		s.logger.Error("Error al buscar tweets por hashtag",
+			zap.String("tag", tag),
+			zap.Error(err),
+			zap.String("action", actionSearchByHashtag),
+		)
+		return dmntweet.TweetsPage{}, err
+	}
+
+	return dmntweet.TweetsPage{
+		Tweets:     tweets,
+		NextCursor: nextCursor,
+	}, nil
+}
@@ -7,9 +7,12 @@ import (
 const (
 	target = "tweets_service"
 
-	actionCreate = "create"
-	actionGet    = "get"
-	actionSearch = "search"
+	actionCreate  = "create"
+	actionGet     = "get"
+	actionGetMany = "get_many"
+	actionSearch  = "search"
+
+	actionSearchByHashtag = "search_by_hashtag"
 )
 
 type action string
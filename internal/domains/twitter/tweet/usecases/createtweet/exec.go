@@ -2,16 +2,23 @@ package createtweet
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 	"time"
 )
 
 func (u UseCase) CreateTweet(ctx context.Context, tweet *dmntweet.Tweet) (*dmntweet.Tweet, error) {
+	ctx, span := tracing.Start(ctx, "createtweet.CreateTweet")
+	defer span.End()
+
+	tweet.Content = tweet.NormalizeContent()
 	u.logger.Debug("Validando tweet",
 		zap.String("user_id", tweet.UserID),
-		zap.Int("content_length", len(tweet.Content)),
+		zap.Int("content_length", dmntweet.ContentLength(tweet.Content)),
 	)
 
 	err := tweet.Validate()
@@ -21,14 +28,36 @@ func (u UseCase) CreateTweet(ctx context.Context, tweet *dmntweet.Tweet) (*dmntw
 			zap.String("content", tweet.Content),
 			zap.Error(err),
 		)
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
-	tweet.Content = tweet.NormalizeContent()
-	u.logger.Debug("Contenido normalizado",
-		zap.String("user_id", tweet.UserID),
-		zap.String("content", tweet.Content),
-	)
+	tweet.Hashtags = dmntweet.ExtractHashtags(tweet.Content)
+	tweet.Mentions = dmntweet.ExtractMentions(tweet.Content)
+
+	// Los retweets todavía no se crean por la API; el campo existe para poder
+	// filtrarlos en el perfil.
+	tweet.RetweetOfID = ""
+	tweet.ReplyToUserID = ""
+	if tweet.ReplyToID != "" {
+		parent, err := u.twtService.Get(ctx, tweet.ReplyToID)
+		if err != nil {
+			if errors.Is(err, dmntweet.ErrTweetNotFound) {
+				err = apperrors.NewNotFoundError("El tweet al que se responde no existe", dmntweet.ErrReplyNotFound).
+					WithCode(apperrors.CodeReplyNotFound).
+					WithDetails(map[string]any{"tweet_id": tweet.ReplyToID})
+			}
+			u.logger.Error("Error al obtener el tweet respondido",
+				zap.String("user_id", tweet.UserID),
+				zap.String("reply_to_id", tweet.ReplyToID),
+				zap.Error(err),
+			)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		tweet.ReplyToUserID = parent.UserID
+	}
+
 	tweet.ID = "twt-" + uuid.New().String()
 	tweet.CreatedAt = time.Now().UTC().Format(time.RFC3339)
 	created, err := u.twtService.Create(ctx, *tweet)
@@ -38,6 +67,7 @@ func (u UseCase) CreateTweet(ctx context.Context, tweet *dmntweet.Tweet) (*dmntw
 			zap.String("content", tweet.Content),
 			zap.Error(err),
 		)
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
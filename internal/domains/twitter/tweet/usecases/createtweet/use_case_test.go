@@ -219,3 +219,45 @@ func TestCreateTweet_EmptyUserID(t *testing.T) {
 	mockTwtService.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
+
+func TestCreateTweet_MentionsAndReply(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := createtweet.NewUseCase(mockTwtService, mockLogger)
+
+	tweet := &dmntweet.Tweet{UserID: "user-1", Content: "@ana @beto de acuerdo", ReplyToID: "twt-parent", ReplyToUserID: "spoofed"}
+	parent := dmntweet.Tweet{ID: "twt-parent", UserID: "user-2", Content: "Opinión"}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+
+	mockTwtService.On("Get", mock.Anything, "twt-parent").Return(parent, nil)
+	mockTwtService.On("Create", mock.Anything, mock.MatchedBy(func(t dmntweet.Tweet) bool {
+		return t.ReplyToUserID == "user-2" && assert.ObjectsAreEqual([]string{"ana", "beto"}, t.Mentions)
+	})).Return(dmntweet.Tweet{ID: "twt-123", UserID: "user-1"}, nil)
+
+	result, err := uc.CreateTweet(context.Background(), tweet)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockTwtService.AssertExpectations(t)
+}
+
+func TestCreateTweet_ReplyNotFound(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := createtweet.NewUseCase(mockTwtService, mockLogger)
+
+	tweet := &dmntweet.Tweet{UserID: "user-1", Content: "Respuesta", ReplyToID: "twt-missing"}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+
+	mockTwtService.On("Get", mock.Anything, "twt-missing").Return(dmntweet.Tweet{}, dmntweet.ErrTweetNotFound)
+
+	result, err := uc.CreateTweet(context.Background(), tweet)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, dmntweet.ErrReplyNotFound)
+	mockTwtService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,41 @@
+package gethashtagtweets
+
+import (
+	"context"
+	"fmt"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+func (u UseCase) Exec(ctx context.Context, tag string, limit int, cursor string) (dmntweet.TweetsPage, error) {
+	ctx, span := tracing.Start(ctx, "gethashtagtweets.Exec")
+	defer span.End()
+
+	normalized := dmntweet.NormalizeHashtag(tag)
+	if normalized == "" {
+		err := apperrors.NewInvalidInputError(fmt.Sprintf("El hashtag %s no es válido", tag), dmntweet.ErrInvalidHashtag).
+			WithCode(apperrors.CodeHashtagInvalid).
+			WithDetails(map[string]any{"tag": tag})
+		tracing.RecordError(span, err)
+		return dmntweet.TweetsPage{}, err
+	}
+
+	u.logger.Debug("Obteniendo tweets por hashtag",
+		zap.String("tag", normalized),
+		zap.Int("limit", limit),
+	)
+
+	page, err := u.twtService.SearchByHashtag(ctx, normalized, limit, cursor)
+	if err != nil {
+		u.logger.Error("Error al obtener tweets por hashtag",
+			zap.String("tag", normalized),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return dmntweet.TweetsPage{}, err
+	}
+
+	return page, nil
+}
@@ -0,0 +1,18 @@
+package gethashtagtweets
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"go.uber.org/zap"
+)
+
+type TweetsService interface {
+	SearchByHashtag(ctx context.Context, tag string, limit int, cursor string) (dmntweet.TweetsPage, error)
+}
+
+type Logger interface {
+	Debug(msg string, fields ...zap.Field)
+	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
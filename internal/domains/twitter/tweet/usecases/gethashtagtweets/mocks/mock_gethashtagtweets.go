@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type TweetsService struct {
+	mock.Mock
+}
+
+func (m *TweetsService) SearchByHashtag(ctx context.Context, tag string, limit int, cursor string) (dmntweet.TweetsPage, error) {
+	args := m.Called(ctx, tag, limit, cursor)
+	return args.Get(0).(dmntweet.TweetsPage), args.Error(1)
+}
+
+type Logger struct {
+	mock.Mock
+}
+
+func (m *Logger) Debug(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Info(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Warn(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Error(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
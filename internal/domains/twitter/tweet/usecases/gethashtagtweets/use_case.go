@@ -0,0 +1,23 @@
+package gethashtagtweets
+
+const (
+	target = "use_case_get_hashtag_tweets"
+
+	getHashtagTweets = "get_hashtag_tweets"
+)
+
+type UseCase struct {
+	twtService TweetsService
+	logger     Logger
+}
+
+func NewUseCase(twtService TweetsService, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		twtService: twtService,
+		logger:     logger,
+	}
+}
@@ -0,0 +1,62 @@
+package gethashtagtweets_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/gethashtagtweets"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/gethashtagtweets/mocks"
+)
+
+func TestExec_NormalizesTag(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := gethashtagtweets.NewUseCase(mockTwtService, mockLogger)
+
+	expected := dmntweet.TweetsPage{
+		Tweets:     []dmntweet.Tweet{{ID: "twt-1", Content: "Hola #Golang", Hashtags: []string{"golang"}}},
+		NextCursor: "abc",
+	}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockTwtService.On("SearchByHashtag", mock.Anything, "golang", 20, "").Return(expected, nil)
+
+	page, err := uc.Exec(context.Background(), "#GoLang", 20, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, page)
+	mockTwtService.AssertExpectations(t)
+}
+
+func TestExec_InvalidTag(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := gethashtagtweets.NewUseCase(mockTwtService, mockLogger)
+
+	_, err := uc.Exec(context.Background(), "123", 20, "")
+
+	assert.ErrorIs(t, err, dmntweet.ErrInvalidHashtag)
+	mockTwtService.AssertNotCalled(t, "SearchByHashtag")
+}
+
+func TestExec_ServiceError(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := gethashtagtweets.NewUseCase(mockTwtService, mockLogger)
+
+	serviceErr := errors.New("service error")
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockTwtService.On("SearchByHashtag", mock.Anything, "golang", 0, "cursor").
+		Return(dmntweet.TweetsPage{}, serviceErr)
+
+	_, err := uc.Exec(context.Background(), "golang", 0, "cursor")
+
+	assert.Equal(t, serviceErr, err)
+	mockTwtService.AssertExpectations(t)
+}
@@ -3,10 +3,14 @@ package gettweet
 import (
 	"context"
 	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
 	"go.uber.org/zap"
 )
 
 func (u UseCase) GetTweet(ctx context.Context, tweetID string) (dmntweet.Tweet, error) {
+	ctx, span := tracing.Start(ctx, "gettweet.GetTweet")
+	defer span.End()
+
 	u.logger.Debug("Obteniendo tweet",
 		zap.String("tweet_id", tweetID),
 	)
@@ -17,6 +21,7 @@ func (u UseCase) GetTweet(ctx context.Context, tweetID string) (dmntweet.Tweet,
 			zap.String("tweet_id", tweetID),
 			zap.Error(err),
 		)
+		tracing.RecordError(span, err)
 		return dmntweet.Tweet{}, err
 	}
 
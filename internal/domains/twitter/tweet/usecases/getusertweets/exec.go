@@ -0,0 +1,50 @@
+package getusertweets
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.uber.org/zap"
+)
+
+// Exec devuelve los tweets publicados por el usuario, del más nuevo al más
+// viejo, para su perfil.
+func (u UseCase) Exec(ctx context.Context, userID string, limit int, cursor string, includeReplies, includeRetweets bool) (dmntweet.TweetsPage, error) {
+	ctx, span := tracing.Start(ctx, "getusertweets.Exec")
+	defer span.End()
+
+	u.logger.Debug("Obteniendo tweets del usuario",
+		zap.String("user_id", userID),
+		zap.Int("limit", limit),
+		zap.Bool("include_replies", includeReplies),
+		zap.Bool("include_retweets", includeRetweets),
+	)
+
+	opts := options.NewSearchOptions().
+		WithFilters(options.NewSearchFilters().
+			WithUserID(userID).
+			WithExcludeReplies(!includeReplies).
+			WithExcludeRetweets(!includeRetweets)).
+		WithPagination(options.NewSearchPagination().
+			WithLimit(limit).
+			WithCursor(cursor))
+
+	tweets, nextCursor, err := u.twtService.Search(ctx, opts)
+	if err != nil {
+		u.logger.Error("Error al obtener tweets del usuario",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		tracing.RecordError(span, err)
+		return dmntweet.TweetsPage{}, err
+	}
+
+	if tweets == nil {
+		tweets = []dmntweet.Tweet{}
+	}
+	return dmntweet.TweetsPage{
+		Tweets:     tweets,
+		NextCursor: nextCursor,
+	}, nil
+}
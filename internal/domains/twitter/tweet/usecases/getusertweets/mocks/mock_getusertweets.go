@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type TweetsService struct {
+	mock.Mock
+}
+
+func (m *TweetsService) Search(ctx context.Context, opts options.SearchOptions) ([]dmntweet.Tweet, string, error) {
+	args := m.Called(ctx, opts)
+	tweets, _ := args.Get(0).([]dmntweet.Tweet)
+	return tweets, args.String(1), args.Error(2)
+}
+
+type Logger struct {
+	mock.Mock
+}
+
+func (m *Logger) Debug(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Info(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Warn(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
+
+func (m *Logger) Error(msg string, fields ...zap.Field) {
+	m.Called(msg, fields)
+}
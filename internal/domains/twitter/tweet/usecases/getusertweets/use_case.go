@@ -0,0 +1,23 @@
+package getusertweets
+
+const (
+	target = "use_case_get_user_tweets"
+
+	getUserTweets = "get_user_tweets"
+)
+
+type UseCase struct {
+	twtService TweetsService
+	logger     Logger
+}
+
+func NewUseCase(twtService TweetsService, logger Logger) UseCase {
+	if logger == nil {
+		panic("logger cannot be nil")
+	}
+
+	return UseCase{
+		twtService: twtService,
+		logger:     logger,
+	}
+}
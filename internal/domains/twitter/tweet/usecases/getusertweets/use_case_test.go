@@ -0,0 +1,66 @@
+package getusertweets_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/options"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/getusertweets"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/usecases/getusertweets/mocks"
+)
+
+func TestExec_ThreadsFiltersAndCursor(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := getusertweets.NewUseCase(mockTwtService, mockLogger)
+
+	tweets := []dmntweet.Tweet{{ID: "twt-2", UserID: "user-1"}, {ID: "twt-1", UserID: "user-1"}}
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockTwtService.On("Search", mock.Anything, mock.MatchedBy(func(opts options.SearchOptions) bool {
+		return opts.Filters.UserID != nil && *opts.Filters.UserID == "user-1" &&
+			opts.Filters.ExcludeReplies && !opts.Filters.ExcludeRetweets &&
+			opts.Pagination.Limit == 20 && opts.Pagination.Cursor == "abc"
+	})).Return(tweets, "def", nil)
+
+	page, err := uc.Exec(context.Background(), "user-1", 20, "abc", false, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, dmntweet.TweetsPage{Tweets: tweets, NextCursor: "def"}, page)
+	mockTwtService.AssertExpectations(t)
+}
+
+func TestExec_EmptyProfile(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := getusertweets.NewUseCase(mockTwtService, mockLogger)
+
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockTwtService.On("Search", mock.Anything, mock.Anything).Return(nil, "", nil)
+
+	page, err := uc.Exec(context.Background(), "user-1", 0, "", true, true)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, page.Tweets)
+	assert.Empty(t, page.Tweets)
+}
+
+func TestExec_ServiceError(t *testing.T) {
+	mockTwtService := new(mocks.TweetsService)
+	mockLogger := new(mocks.Logger)
+	uc := getusertweets.NewUseCase(mockTwtService, mockLogger)
+
+	serviceErr := errors.New("service error")
+	mockLogger.On("Debug", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+	mockTwtService.On("Search", mock.Anything, mock.Anything).Return(nil, "", serviceErr)
+
+	_, err := uc.Exec(context.Background(), "user-1", 0, "", true, true)
+
+	assert.Equal(t, serviceErr, err)
+}
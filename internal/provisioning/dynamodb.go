@@ -0,0 +1,358 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+type DynamoDBAPI interface {
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+	CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+	UpdateTable(ctx context.Context, params *dynamodb.UpdateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error)
+	DeleteTable(ctx context.Context, params *dynamodb.DeleteTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error)
+	DescribeTimeToLive(ctx context.Context, params *dynamodb.DescribeTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	UpdateTimeToLive(ctx context.Context, params *dynamodb.UpdateTimeToLiveInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error)
+}
+
+// applyTable crea la tabla si no existe y si existe le agrega los índices y
+// el TTL que falten. Una clave distinta de la declarada no se puede cambiar en
+// el lugar: la tabla se recrea sólo si figura en Options.Recreate.
+func (m *Migrator) applyTable(ctx context.Context, table Table) error {
+	current, err := m.describeTable(ctx, table.Name)
+	if err != nil {
+		return err
+	}
+
+	if current != nil {
+		if mismatch := keyMismatch(table.HashKey, table.RangeKey, current.KeySchema, current.AttributeDefinitions); mismatch != "" {
+			if !m.options.Recreate[table.Name] {
+				return fmt.Errorf("la tabla %s tiene otra clave (%s): hay que recrearla con --recreate=%s, lo que borra sus datos",
+					table.Name, mismatch, table.Name)
+			}
+			if err := m.deleteTable(ctx, table.Name); err != nil {
+				return err
+			}
+			current = nil
+		}
+	}
+
+	if current == nil {
+		if err := m.createTable(ctx, table); err != nil {
+			return err
+		}
+	} else {
+		for _, index := range table.Indexes {
+			if err := m.applyIndex(ctx, table, index, current); err != nil {
+				return err
+			}
+		}
+	}
+
+	return m.applyTTL(ctx, table)
+}
+
+func (m *Migrator) createTable(ctx context.Context, table Table) error {
+	input := &dynamodb.CreateTableInput{
+		TableName:            aws.String(table.Name),
+		BillingMode:          types.BillingModePayPerRequest,
+		KeySchema:            keySchema(table.HashKey, table.RangeKey),
+		AttributeDefinitions: attributeDefinitions(table),
+	}
+	for _, index := range table.Indexes {
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, types.GlobalSecondaryIndex{
+			IndexName:  aws.String(index.Name),
+			KeySchema:  keySchema(index.HashKey, index.RangeKey),
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		})
+	}
+
+	if _, err := m.dynamo.CreateTable(ctx, input); err != nil {
+		return fmt.Errorf("error creando la tabla %s: %w", table.Name, err)
+	}
+	m.logger.Info("Tabla creada", zap.String("table", table.Name), zap.Int("indexes", len(table.Indexes)))
+	return m.waitTable(ctx, table.Name, func(description *types.TableDescription) bool {
+		return description != nil && tableActive(description)
+	})
+}
+
+func (m *Migrator) deleteTable(ctx context.Context, name string) error {
+	if _, err := m.dynamo.DeleteTable(ctx, &dynamodb.DeleteTableInput{TableName: aws.String(name)}); err != nil {
+		return fmt.Errorf("error borrando la tabla %s: %w", name, err)
+	}
+	m.logger.Warn("Tabla borrada para recrearla con la clave declarada", zap.String("table", name))
+	return m.waitTable(ctx, name, func(description *types.TableDescription) bool {
+		return description == nil
+	})
+}
+
+// applyIndex crea el índice si falta. Como la clave de un índice tampoco se
+// puede cambiar, uno con otra clave se borra y se vuelve a crear sólo si la
+// tabla figura en Options.Recreate.
+func (m *Migrator) applyIndex(ctx context.Context, table Table, index Index, current *types.TableDescription) error {
+	if existing := findIndex(current, index.Name); existing != nil {
+		mismatch := keyMismatch(index.HashKey, index.RangeKey, existing.KeySchema, current.AttributeDefinitions)
+		if mismatch == "" {
+			return nil
+		}
+		if !m.options.Recreate[table.Name] {
+			return fmt.Errorf("el índice %s de %s tiene otra clave (%s): hay que recrearlo con --recreate=%s",
+				index.Name, table.Name, mismatch, table.Name)
+		}
+		if err := m.updateTable(ctx, table.Name, &dynamodb.UpdateTableInput{
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{{
+				Delete: &types.DeleteGlobalSecondaryIndexAction{IndexName: aws.String(index.Name)},
+			}},
+		}, func(description *types.TableDescription) bool {
+			return tableActive(description) && findIndex(description, index.Name) == nil
+		}); err != nil {
+			return err
+		}
+		m.logger.Warn("Índice borrado para recrearlo con la clave declarada",
+			zap.String("table", table.Name), zap.String("index", index.Name))
+	}
+
+	definitions := []types.AttributeDefinition{attributeDefinition(index.HashKey)}
+	if index.RangeKey.Name != "" {
+		definitions = append(definitions, attributeDefinition(index.RangeKey))
+	}
+	if err := m.updateTable(ctx, table.Name, &dynamodb.UpdateTableInput{
+		AttributeDefinitions: definitions,
+		GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{{
+			Create: &types.CreateGlobalSecondaryIndexAction{
+				IndexName:  aws.String(index.Name),
+				KeySchema:  keySchema(index.HashKey, index.RangeKey),
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			},
+		}},
+	}, func(description *types.TableDescription) bool {
+		return tableActive(description)
+	}); err != nil {
+		return err
+	}
+	m.logger.Info("Índice creado", zap.String("table", table.Name), zap.String("index", index.Name))
+	return nil
+}
+
+// updateTable aplica un cambio de índices y espera a que done se cumpla. Los
+// cambios van de a uno porque DynamoDB no acepta otro mientras un índice se
+// está creando o borrando.
+func (m *Migrator) updateTable(ctx context.Context, name string, input *dynamodb.UpdateTableInput, done func(*types.TableDescription) bool) error {
+	input.TableName = aws.String(name)
+	if _, err := m.dynamo.UpdateTable(ctx, input); err != nil {
+		return fmt.Errorf("error actualizando los índices de %s: %w", name, err)
+	}
+	return m.waitTable(ctx, name, func(description *types.TableDescription) bool {
+		return description != nil && done(description)
+	})
+}
+
+func (m *Migrator) applyTTL(ctx context.Context, table Table) error {
+	if table.TTLAttribute == "" {
+		return nil
+	}
+
+	enabled, attribute, err := m.describeTTL(ctx, table.Name)
+	if err != nil {
+		return err
+	}
+	if enabled && attribute == table.TTLAttribute {
+		return nil
+	}
+
+	if _, err := m.dynamo.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(table.Name),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			AttributeName: aws.String(table.TTLAttribute),
+			Enabled:       aws.Bool(true),
+		},
+	}); err != nil {
+		return fmt.Errorf("error habilitando el TTL de %s: %w", table.Name, err)
+	}
+	m.logger.Info("TTL habilitado", zap.String("table", table.Name), zap.String("attribute", table.TTLAttribute))
+	return nil
+}
+
+func (m *Migrator) verifyTable(ctx context.Context, table Table) ([]Problem, error) {
+	current, err := m.describeTable(ctx, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	resource := "dynamodb:" + table.Name
+	if current == nil {
+		return []Problem{{Resource: resource, Detail: "la tabla no existe"}}, nil
+	}
+
+	var problems []Problem
+	if mismatch := keyMismatch(table.HashKey, table.RangeKey, current.KeySchema, current.AttributeDefinitions); mismatch != "" {
+		problems = append(problems, Problem{Resource: resource, Detail: "clave distinta de la declarada: " + mismatch})
+	}
+	if !tableActive(current) {
+		problems = append(problems, Problem{Resource: resource, Detail: fmt.Sprintf("la tabla está en estado %s", current.TableStatus)})
+	}
+	for _, index := range table.Indexes {
+		existing := findIndex(current, index.Name)
+		switch {
+		case existing == nil:
+			problems = append(problems, Problem{Resource: resource, Detail: "falta el índice " + index.Name})
+		case keyMismatch(index.HashKey, index.RangeKey, existing.KeySchema, current.AttributeDefinitions) != "":
+			problems = append(problems, Problem{Resource: resource, Detail: fmt.Sprintf("el índice %s tiene otra clave: %s",
+				index.Name, keyMismatch(index.HashKey, index.RangeKey, existing.KeySchema, current.AttributeDefinitions))})
+		case existing.IndexStatus != types.IndexStatusActive:
+			problems = append(problems, Problem{Resource: resource, Detail: fmt.Sprintf("el índice %s está en estado %s",
+				index.Name, existing.IndexStatus)})
+		}
+	}
+
+	if table.TTLAttribute != "" {
+		enabled, attribute, err := m.describeTTL(ctx, table.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !enabled || attribute != table.TTLAttribute {
+			problems = append(problems, Problem{Resource: resource, Detail: "el TTL no está habilitado sobre " + table.TTLAttribute})
+		}
+	}
+	return problems, nil
+}
+
+// describeTable devuelve nil si la tabla no existe.
+func (m *Migrator) describeTable(ctx context.Context, name string) (*types.TableDescription, error) {
+	out, err := m.dynamo.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(name)})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error describiendo la tabla %s: %w", name, err)
+	}
+	return out.Table, nil
+}
+
+// describeTTL informa si el TTL está habilitado (o habilitándose) y sobre qué
+// atributo.
+func (m *Migrator) describeTTL(ctx context.Context, name string) (bool, string, error) {
+	out, err := m.dynamo.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{TableName: aws.String(name)})
+	if err != nil {
+		return false, "", fmt.Errorf("error consultando el TTL de %s: %w", name, err)
+	}
+	description := out.TimeToLiveDescription
+	if description == nil {
+		return false, "", nil
+	}
+	enabled := description.TimeToLiveStatus == types.TimeToLiveStatusEnabled ||
+		description.TimeToLiveStatus == types.TimeToLiveStatusEnabling
+	return enabled, aws.ToString(description.AttributeName), nil
+}
+
+// waitTable consulta la tabla cada PollInterval hasta que done se cumpla, por
+// no más de WaitTimeout. done recibe nil cuando la tabla no existe.
+func (m *Migrator) waitTable(ctx context.Context, name string, done func(*types.TableDescription) bool) error {
+	ctx, cancel := context.WithTimeout(ctx, m.options.WaitTimeout)
+	defer cancel()
+
+	for {
+		description, err := m.describeTable(ctx, name)
+		if err != nil {
+			return err
+		}
+		if done(description) {
+			return nil
+		}
+		if err := m.sleep(ctx); err != nil {
+			return fmt.Errorf("esperando la tabla %s: %w", name, err)
+		}
+	}
+}
+
+func tableActive(description *types.TableDescription) bool {
+	if description.TableStatus != types.TableStatusActive {
+		return false
+	}
+	for _, index := range description.GlobalSecondaryIndexes {
+		if index.IndexStatus != types.IndexStatusActive {
+			return false
+		}
+	}
+	return true
+}
+
+func findIndex(description *types.TableDescription, name string) *types.GlobalSecondaryIndexDescription {
+	for i := range description.GlobalSecondaryIndexes {
+		if aws.ToString(description.GlobalSecondaryIndexes[i].IndexName) == name {
+			return &description.GlobalSecondaryIndexes[i]
+		}
+	}
+	return nil
+}
+
+// keyMismatch compara una clave declarada con la desplegada y describe la
+// diferencia, o devuelve "" si coinciden.
+func keyMismatch(hashKey, rangeKey Attribute, schema []types.KeySchemaElement, definitions []types.AttributeDefinition) string {
+	want := describeKey(hashKey, rangeKey)
+	var gotHash, gotRange Attribute
+	for _, element := range schema {
+		attribute := Attribute{Name: aws.ToString(element.AttributeName)}
+		for _, definition := range definitions {
+			if aws.ToString(definition.AttributeName) == attribute.Name {
+				attribute.Type = definition.AttributeType
+			}
+		}
+		if element.KeyType == types.KeyTypeHash {
+			gotHash = attribute
+		} else {
+			gotRange = attribute
+		}
+	}
+	if have := describeKey(gotHash, gotRange); have != want {
+		return fmt.Sprintf("se esperaba %s y es %s", want, have)
+	}
+	return ""
+}
+
+func describeKey(hashKey, rangeKey Attribute) string {
+	key := fmt.Sprintf("HASH %s (%s)", hashKey.Name, hashKey.Type)
+	if rangeKey.Name != "" {
+		key += fmt.Sprintf(", RANGE %s (%s)", rangeKey.Name, rangeKey.Type)
+	}
+	return key
+}
+
+func keySchema(hashKey, rangeKey Attribute) []types.KeySchemaElement {
+	schema := []types.KeySchemaElement{{AttributeName: aws.String(hashKey.Name), KeyType: types.KeyTypeHash}}
+	if rangeKey.Name != "" {
+		schema = append(schema, types.KeySchemaElement{AttributeName: aws.String(rangeKey.Name), KeyType: types.KeyTypeRange})
+	}
+	return schema
+}
+
+// attributeDefinitions define una vez cada atributo que aparece en la clave
+// de la tabla o de sus índices.
+func attributeDefinitions(table Table) []types.AttributeDefinition {
+	seen := make(map[string]struct{})
+	var definitions []types.AttributeDefinition
+	add := func(attribute Attribute) {
+		if _, ok := seen[attribute.Name]; ok || attribute.Name == "" {
+			return
+		}
+		seen[attribute.Name] = struct{}{}
+		definitions = append(definitions, attributeDefinition(attribute))
+	}
+
+	add(table.HashKey)
+	add(table.RangeKey)
+	for _, index := range table.Indexes {
+		add(index.HashKey)
+		add(index.RangeKey)
+	}
+	return definitions
+}
+
+func attributeDefinition(attribute Attribute) types.AttributeDefinition {
+	return types.AttributeDefinition{AttributeName: aws.String(attribute.Name), AttributeType: attribute.Type}
+}
@@ -0,0 +1,261 @@
+package provisioning_test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamotypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// fakeDynamoDB guarda las tablas en memoria. Las tablas e índices quedan
+// activos en el momento, así que el migrador nunca espera.
+type fakeDynamoDB struct {
+	tables map[string]*dynamotypes.TableDescription
+	ttl    map[string]string
+	calls  map[string]int
+}
+
+func newFakeDynamoDB() *fakeDynamoDB {
+	return &fakeDynamoDB{
+		tables: make(map[string]*dynamotypes.TableDescription),
+		ttl:    make(map[string]string),
+		calls:  make(map[string]int),
+	}
+}
+
+// put crea una tabla sin pasar por el migrador, como la dejaría otra
+// herramienta.
+func (f *fakeDynamoDB) put(name string, hashKey, rangeKey string, indexes ...string) {
+	table := &dynamotypes.TableDescription{
+		TableName:   aws.String(name),
+		TableStatus: dynamotypes.TableStatusActive,
+		KeySchema:   keySchema(hashKey, rangeKey),
+	}
+	table.AttributeDefinitions = append(table.AttributeDefinitions, definitions(hashKey, rangeKey)...)
+	for _, index := range indexes {
+		table.GlobalSecondaryIndexes = append(table.GlobalSecondaryIndexes, dynamotypes.GlobalSecondaryIndexDescription{
+			IndexName:   aws.String(index),
+			IndexStatus: dynamotypes.IndexStatusActive,
+			KeySchema:   keySchema(strings.TrimSuffix(index, "-index"), ""),
+		})
+		table.AttributeDefinitions = append(table.AttributeDefinitions, definitions(strings.TrimSuffix(index, "-index"), "")...)
+	}
+	f.tables[name] = table
+}
+
+func (f *fakeDynamoDB) DescribeTable(_ context.Context, params *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	table, ok := f.tables[aws.ToString(params.TableName)]
+	if !ok {
+		return nil, &dynamotypes.ResourceNotFoundException{Message: aws.String("no existe")}
+	}
+	return &dynamodb.DescribeTableOutput{Table: table}, nil
+}
+
+func (f *fakeDynamoDB) CreateTable(_ context.Context, params *dynamodb.CreateTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	f.calls["CreateTable"]++
+	table := &dynamotypes.TableDescription{
+		TableName:            params.TableName,
+		TableStatus:          dynamotypes.TableStatusActive,
+		KeySchema:            params.KeySchema,
+		AttributeDefinitions: params.AttributeDefinitions,
+	}
+	for _, index := range params.GlobalSecondaryIndexes {
+		table.GlobalSecondaryIndexes = append(table.GlobalSecondaryIndexes, dynamotypes.GlobalSecondaryIndexDescription{
+			IndexName:   index.IndexName,
+			IndexStatus: dynamotypes.IndexStatusActive,
+			KeySchema:   index.KeySchema,
+		})
+	}
+	f.tables[aws.ToString(params.TableName)] = table
+	return &dynamodb.CreateTableOutput{TableDescription: table}, nil
+}
+
+func (f *fakeDynamoDB) UpdateTable(_ context.Context, params *dynamodb.UpdateTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error) {
+	f.calls["UpdateTable"]++
+	table := f.tables[aws.ToString(params.TableName)]
+	for _, definition := range params.AttributeDefinitions {
+		if !defined(table, aws.ToString(definition.AttributeName)) {
+			table.AttributeDefinitions = append(table.AttributeDefinitions, definition)
+		}
+	}
+	for _, update := range params.GlobalSecondaryIndexUpdates {
+		if update.Create != nil {
+			table.GlobalSecondaryIndexes = append(table.GlobalSecondaryIndexes, dynamotypes.GlobalSecondaryIndexDescription{
+				IndexName:   update.Create.IndexName,
+				IndexStatus: dynamotypes.IndexStatusActive,
+				KeySchema:   update.Create.KeySchema,
+			})
+		}
+		if update.Delete != nil {
+			var kept []dynamotypes.GlobalSecondaryIndexDescription
+			for _, index := range table.GlobalSecondaryIndexes {
+				if aws.ToString(index.IndexName) != aws.ToString(update.Delete.IndexName) {
+					kept = append(kept, index)
+				}
+			}
+			table.GlobalSecondaryIndexes = kept
+		}
+	}
+	return &dynamodb.UpdateTableOutput{TableDescription: table}, nil
+}
+
+func (f *fakeDynamoDB) DeleteTable(_ context.Context, params *dynamodb.DeleteTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DeleteTableOutput, error) {
+	f.calls["DeleteTable"]++
+	delete(f.tables, aws.ToString(params.TableName))
+	delete(f.ttl, aws.ToString(params.TableName))
+	return &dynamodb.DeleteTableOutput{}, nil
+}
+
+func (f *fakeDynamoDB) DescribeTimeToLive(_ context.Context, params *dynamodb.DescribeTimeToLiveInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	attribute, ok := f.ttl[aws.ToString(params.TableName)]
+	if !ok {
+		return &dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: &dynamotypes.TimeToLiveDescription{
+			TimeToLiveStatus: dynamotypes.TimeToLiveStatusDisabled,
+		}}, nil
+	}
+	return &dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: &dynamotypes.TimeToLiveDescription{
+		TimeToLiveStatus: dynamotypes.TimeToLiveStatusEnabled,
+		AttributeName:    aws.String(attribute),
+	}}, nil
+}
+
+func (f *fakeDynamoDB) UpdateTimeToLive(_ context.Context, params *dynamodb.UpdateTimeToLiveInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error) {
+	f.calls["UpdateTimeToLive"]++
+	f.ttl[aws.ToString(params.TableName)] = aws.ToString(params.TimeToLiveSpecification.AttributeName)
+	return &dynamodb.UpdateTimeToLiveOutput{}, nil
+}
+
+func keySchema(hashKey, rangeKey string) []dynamotypes.KeySchemaElement {
+	schema := []dynamotypes.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: dynamotypes.KeyTypeHash}}
+	if rangeKey != "" {
+		schema = append(schema, dynamotypes.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: dynamotypes.KeyTypeRange})
+	}
+	return schema
+}
+
+func definitions(names ...string) []dynamotypes.AttributeDefinition {
+	var result []dynamotypes.AttributeDefinition
+	for _, name := range names {
+		if name != "" {
+			result = append(result, dynamotypes.AttributeDefinition{AttributeName: aws.String(name), AttributeType: dynamotypes.ScalarAttributeTypeS})
+		}
+	}
+	return result
+}
+
+func defined(table *dynamotypes.TableDescription, name string) bool {
+	for _, definition := range table.AttributeDefinitions {
+		if aws.ToString(definition.AttributeName) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeSQS identifica las colas por nombre; la URL y el ARN se derivan de él.
+type fakeSQS struct {
+	queues map[string]map[string]string
+	calls  map[string]int
+}
+
+func newFakeSQS() *fakeSQS {
+	return &fakeSQS{queues: make(map[string]map[string]string), calls: make(map[string]int)}
+}
+
+func queueURL(name string) string { return "http://sqs.local/000000000000/" + name }
+
+func queueARN(name string) string { return "arn:aws:sqs:us-east-1:000000000000:" + name }
+
+func (f *fakeSQS) GetQueueUrl(_ context.Context, params *sqs.GetQueueUrlInput, _ ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error) {
+	name := aws.ToString(params.QueueName)
+	if _, ok := f.queues[name]; !ok {
+		return nil, &sqstypes.QueueDoesNotExist{Message: aws.String("no existe")}
+	}
+	return &sqs.GetQueueUrlOutput{QueueUrl: aws.String(queueURL(name))}, nil
+}
+
+func (f *fakeSQS) CreateQueue(_ context.Context, params *sqs.CreateQueueInput, _ ...func(*sqs.Options)) (*sqs.CreateQueueOutput, error) {
+	f.calls["CreateQueue"]++
+	name := aws.ToString(params.QueueName)
+	attributes := map[string]string{}
+	for key, value := range params.Attributes {
+		attributes[key] = value
+	}
+	f.queues[name] = attributes
+	return &sqs.CreateQueueOutput{QueueUrl: aws.String(queueURL(name))}, nil
+}
+
+func (f *fakeSQS) GetQueueAttributes(_ context.Context, params *sqs.GetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	name := aws.ToString(params.QueueUrl)[strings.LastIndex(aws.ToString(params.QueueUrl), "/")+1:]
+	attributes := map[string]string{}
+	for _, attribute := range params.AttributeNames {
+		if attribute == sqstypes.QueueAttributeNameQueueArn {
+			attributes[string(attribute)] = queueARN(name)
+		} else if value, ok := f.queues[name][string(attribute)]; ok {
+			attributes[string(attribute)] = value
+		}
+	}
+	return &sqs.GetQueueAttributesOutput{Attributes: attributes}, nil
+}
+
+func (f *fakeSQS) SetQueueAttributes(_ context.Context, params *sqs.SetQueueAttributesInput, _ ...func(*sqs.Options)) (*sqs.SetQueueAttributesOutput, error) {
+	f.calls["SetQueueAttributes"]++
+	name := aws.ToString(params.QueueUrl)[strings.LastIndex(aws.ToString(params.QueueUrl), "/")+1:]
+	for key, value := range params.Attributes {
+		f.queues[name][key] = value
+	}
+	return &sqs.SetQueueAttributesOutput{}, nil
+}
+
+// fakeSNS guarda los tópicos por ARN con los endpoints suscritos.
+type fakeSNS struct {
+	topics map[string][]string
+	calls  map[string]int
+}
+
+func newFakeSNS() *fakeSNS {
+	return &fakeSNS{topics: make(map[string][]string), calls: make(map[string]int)}
+}
+
+func topicARN(name string) string { return "arn:aws:sns:us-east-1:000000000000:" + name }
+
+func (f *fakeSNS) CreateTopic(_ context.Context, params *sns.CreateTopicInput, _ ...func(*sns.Options)) (*sns.CreateTopicOutput, error) {
+	arn := topicARN(aws.ToString(params.Name))
+	if _, ok := f.topics[arn]; !ok {
+		f.calls["CreateTopic"]++
+		f.topics[arn] = nil
+	}
+	return &sns.CreateTopicOutput{TopicArn: aws.String(arn)}, nil
+}
+
+func (f *fakeSNS) GetTopicAttributes(_ context.Context, params *sns.GetTopicAttributesInput, _ ...func(*sns.Options)) (*sns.GetTopicAttributesOutput, error) {
+	if _, ok := f.topics[aws.ToString(params.TopicArn)]; !ok {
+		return nil, &snstypes.NotFoundException{Message: aws.String("no existe")}
+	}
+	return &sns.GetTopicAttributesOutput{Attributes: map[string]string{"TopicArn": aws.ToString(params.TopicArn)}}, nil
+}
+
+func (f *fakeSNS) ListSubscriptionsByTopic(_ context.Context, params *sns.ListSubscriptionsByTopicInput, _ ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error) {
+	var subscriptions []snstypes.Subscription
+	for _, endpoint := range f.topics[aws.ToString(params.TopicArn)] {
+		subscriptions = append(subscriptions, snstypes.Subscription{
+			TopicArn: params.TopicArn,
+			Protocol: aws.String("sqs"),
+			Endpoint: aws.String(endpoint),
+		})
+	}
+	return &sns.ListSubscriptionsByTopicOutput{Subscriptions: subscriptions}, nil
+}
+
+func (f *fakeSNS) Subscribe(_ context.Context, params *sns.SubscribeInput, _ ...func(*sns.Options)) (*sns.SubscribeOutput, error) {
+	f.calls["Subscribe"]++
+	arn := aws.ToString(params.TopicArn)
+	f.topics[arn] = append(f.topics[arn], aws.ToString(params.Endpoint))
+	return &sns.SubscribeOutput{SubscriptionArn: aws.String(arn + ":sub")}, nil
+}
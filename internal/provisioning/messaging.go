@@ -0,0 +1,346 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+	"go.uber.org/zap"
+)
+
+type SNSAPI interface {
+	CreateTopic(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error)
+	GetTopicAttributes(ctx context.Context, params *sns.GetTopicAttributesInput, optFns ...func(*sns.Options)) (*sns.GetTopicAttributesOutput, error)
+	ListSubscriptionsByTopic(ctx context.Context, params *sns.ListSubscriptionsByTopicInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error)
+	Subscribe(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error)
+}
+
+type SQSAPI interface {
+	GetQueueUrl(ctx context.Context, params *sqs.GetQueueUrlInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueUrlOutput, error)
+	CreateQueue(ctx context.Context, params *sqs.CreateQueueInput, optFns ...func(*sqs.Options)) (*sqs.CreateQueueOutput, error)
+	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+	SetQueueAttributes(ctx context.Context, params *sqs.SetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.SetQueueAttributesOutput, error)
+}
+
+// queueRef es una cola ya creada.
+type queueRef struct {
+	URL string
+	ARN string
+}
+
+// applyMessaging crea las colas con su DLQ, los tópicos y las suscripciones
+// que falten, y deja en cada cola suscrita la política que permite a sus
+// tópicos enviarle mensajes.
+func (m *Migrator) applyMessaging(ctx context.Context, schema Schema) error {
+	queues := make(map[string]queueRef, len(schema.Queues))
+	for _, queue := range schema.Queues {
+		dlq, err := m.ensureQueue(ctx, queue.DLQ, nil)
+		if err != nil {
+			return err
+		}
+		ref, err := m.ensureQueue(ctx, queue.Name, map[string]string{
+			string(sqstypes.QueueAttributeNameRedrivePolicy): redrivePolicy(dlq.ARN, queue.MaxReceiveCount),
+		})
+		if err != nil {
+			return err
+		}
+		queues[queue.Name] = ref
+	}
+
+	topics := make(map[string]string, len(schema.Topics))
+	for _, topic := range schema.Topics {
+		out, err := m.sns.CreateTopic(ctx, &sns.CreateTopicInput{Name: aws.String(topic.Name)})
+		if err != nil {
+			return fmt.Errorf("error creando el tópico %s: %w", topic.Name, err)
+		}
+		arn := aws.ToString(out.TopicArn)
+		if topic.ARN != "" && arn != topic.ARN {
+			m.logger.Warn("El ARN configurado no es el del tópico",
+				zap.String("topic", topic.Name),
+				zap.String("configured", topic.ARN),
+				zap.String("actual", arn))
+		}
+		topics[topic.Name] = arn
+	}
+
+	sources := make(map[string][]string)
+	for _, subscription := range schema.Subscriptions {
+		topicARN, queue := topics[subscription.Topic], queues[subscription.Queue]
+		if topicARN == "" || queue.ARN == "" {
+			return fmt.Errorf("la suscripción %s -> %s no tiene su tópico o su cola declarados", subscription.Topic, subscription.Queue)
+		}
+		subscribed, err := m.subscribed(ctx, topicARN, queue.ARN)
+		if err != nil {
+			return err
+		}
+		if !subscribed {
+			if _, err := m.sns.Subscribe(ctx, &sns.SubscribeInput{
+				TopicArn:              aws.String(topicARN),
+				Protocol:              aws.String("sqs"),
+				Endpoint:              aws.String(queue.ARN),
+				ReturnSubscriptionArn: true,
+			}); err != nil {
+				return fmt.Errorf("error suscribiendo %s a %s: %w", subscription.Queue, subscription.Topic, err)
+			}
+			m.logger.Info("Suscripción creada", zap.String("topic", subscription.Topic), zap.String("queue", subscription.Queue))
+		}
+		sources[subscription.Queue] = append(sources[subscription.Queue], topicARN)
+	}
+
+	for name, topicARNs := range sources {
+		queue := queues[name]
+		if err := m.ensureQueueAttribute(ctx, name, queue.URL, sqstypes.QueueAttributeNamePolicy,
+			snsPolicy(queue.ARN, topicARNs)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureQueue crea la cola si no existe. Si existe, actualiza los atributos
+// declarados que difieran.
+func (m *Migrator) ensureQueue(ctx context.Context, name string, attributes map[string]string) (queueRef, error) {
+	url, err := m.queueURL(ctx, name)
+	if err != nil {
+		return queueRef{}, err
+	}
+
+	if url == "" {
+		out, err := m.sqs.CreateQueue(ctx, &sqs.CreateQueueInput{QueueName: aws.String(name), Attributes: attributes})
+		if err != nil {
+			return queueRef{}, fmt.Errorf("error creando la cola %s: %w", name, err)
+		}
+		url = aws.ToString(out.QueueUrl)
+		m.logger.Info("Cola creada", zap.String("queue", name))
+	} else {
+		for attribute, value := range attributes {
+			if err := m.ensureQueueAttribute(ctx, name, url, sqstypes.QueueAttributeName(attribute), value); err != nil {
+				return queueRef{}, err
+			}
+		}
+	}
+
+	arn, err := m.queueAttribute(ctx, name, url, sqstypes.QueueAttributeNameQueueArn)
+	if err != nil {
+		return queueRef{}, err
+	}
+	return queueRef{URL: url, ARN: arn}, nil
+}
+
+func (m *Migrator) ensureQueueAttribute(ctx context.Context, name, url string, attribute sqstypes.QueueAttributeName, value string) error {
+	current, err := m.queueAttribute(ctx, name, url, attribute)
+	if err != nil {
+		return err
+	}
+	if sameJSON(current, value) {
+		return nil
+	}
+
+	if _, err := m.sqs.SetQueueAttributes(ctx, &sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(url),
+		Attributes: map[string]string{string(attribute): value},
+	}); err != nil {
+		return fmt.Errorf("error actualizando %s de la cola %s: %w", attribute, name, err)
+	}
+	m.logger.Info("Atributo de cola actualizado", zap.String("queue", name), zap.String("attribute", string(attribute)))
+	return nil
+}
+
+func (m *Migrator) verifyMessaging(ctx context.Context, schema Schema) ([]Problem, error) {
+	var problems []Problem
+
+	queues := make(map[string]string, len(schema.Queues))
+	for _, queue := range schema.Queues {
+		resource := "sqs:" + queue.Name
+		dlqURL, err := m.queueURL(ctx, queue.DLQ)
+		if err != nil {
+			return nil, err
+		}
+		if dlqURL == "" {
+			problems = append(problems, Problem{Resource: "sqs:" + queue.DLQ, Detail: "la cola no existe"})
+		}
+
+		url, err := m.queueURL(ctx, queue.Name)
+		if err != nil {
+			return nil, err
+		}
+		if url == "" {
+			problems = append(problems, Problem{Resource: resource, Detail: "la cola no existe"})
+			continue
+		}
+		arn, err := m.queueAttribute(ctx, queue.Name, url, sqstypes.QueueAttributeNameQueueArn)
+		if err != nil {
+			return nil, err
+		}
+		queues[queue.Name] = arn
+
+		if dlqURL == "" {
+			continue
+		}
+		dlqARN, err := m.queueAttribute(ctx, queue.DLQ, dlqURL, sqstypes.QueueAttributeNameQueueArn)
+		if err != nil {
+			return nil, err
+		}
+		redrive, err := m.queueAttribute(ctx, queue.Name, url, sqstypes.QueueAttributeNameRedrivePolicy)
+		if err != nil {
+			return nil, err
+		}
+		if !sameJSON(redrive, redrivePolicy(dlqARN, queue.MaxReceiveCount)) {
+			problems = append(problems, Problem{Resource: resource, Detail: fmt.Sprintf(
+				"no redirige a %s tras %d recepciones", queue.DLQ, queue.MaxReceiveCount)})
+		}
+	}
+
+	topics := make(map[string]string, len(schema.Topics))
+	for _, topic := range schema.Topics {
+		_, err := m.sns.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{TopicArn: aws.String(topic.ARN)})
+		var notFound *snstypes.NotFoundException
+		if errors.As(err, &notFound) {
+			problems = append(problems, Problem{Resource: "sns:" + topic.Name, Detail: "no existe el tópico " + topic.ARN})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error consultando el tópico %s: %w", topic.Name, err)
+		}
+		topics[topic.Name] = topic.ARN
+	}
+
+	for _, subscription := range schema.Subscriptions {
+		topicARN, queueARN := topics[subscription.Topic], queues[subscription.Queue]
+		if topicARN == "" || queueARN == "" {
+			continue
+		}
+		subscribed, err := m.subscribed(ctx, topicARN, queueARN)
+		if err != nil {
+			return nil, err
+		}
+		if !subscribed {
+			problems = append(problems, Problem{Resource: "sns:" + subscription.Topic,
+				Detail: "la cola " + subscription.Queue + " no está suscrita"})
+		}
+	}
+	return problems, nil
+}
+
+// queueURL devuelve "" si la cola no existe.
+func (m *Migrator) queueURL(ctx context.Context, name string) (string, error) {
+	out, err := m.sqs.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{QueueName: aws.String(name)})
+	if queueMissing(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error buscando la cola %s: %w", name, err)
+	}
+	return aws.ToString(out.QueueUrl), nil
+}
+
+func (m *Migrator) queueAttribute(ctx context.Context, name, url string, attribute sqstypes.QueueAttributeName) (string, error) {
+	out, err := m.sqs.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(url),
+		AttributeNames: []sqstypes.QueueAttributeName{attribute},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error leyendo %s de la cola %s: %w", attribute, name, err)
+	}
+	return out.Attributes[string(attribute)], nil
+}
+
+// subscribed informa si la cola ya está suscrita al tópico.
+func (m *Migrator) subscribed(ctx context.Context, topicARN, queueARN string) (bool, error) {
+	input := &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(topicARN)}
+	for {
+		out, err := m.sns.ListSubscriptionsByTopic(ctx, input)
+		if err != nil {
+			return false, fmt.Errorf("error listando las suscripciones de %s: %w", topicARN, err)
+		}
+		for _, subscription := range out.Subscriptions {
+			if aws.ToString(subscription.Protocol) == "sqs" && aws.ToString(subscription.Endpoint) == queueARN {
+				return true, nil
+			}
+		}
+		if aws.ToString(out.NextToken) == "" {
+			return false, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+// queueMissing reconoce el error de cola inexistente tanto con el protocolo
+// JSON de SQS como con el de consultas que todavía usa LocalStack.
+func queueMissing(err error) bool {
+	var missing *sqstypes.QueueDoesNotExist
+	if errors.As(err, &missing) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "AWS.SimpleQueueService.NonExistentQueue"
+}
+
+func redrivePolicy(dlqARN string, maxReceiveCount int) string {
+	policy, _ := json.Marshal(map[string]string{
+		"deadLetterTargetArn": dlqARN,
+		"maxReceiveCount":     strconv.Itoa(maxReceiveCount),
+	})
+	return string(policy)
+}
+
+// snsPolicy permite a los tópicos dados enviar mensajes a la cola.
+func snsPolicy(queueARN string, topicARNs []string) string {
+	sorted := append([]string(nil), topicARNs...)
+	sort.Strings(sorted)
+	policy, _ := json.Marshal(map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []map[string]any{{
+			"Sid":       "twit-sns",
+			"Effect":    "Allow",
+			"Principal": map[string]string{"Service": "sns.amazonaws.com"},
+			"Action":    "sqs:SendMessage",
+			"Resource":  queueARN,
+			"Condition": map[string]any{"ArnEquals": map[string][]string{"aws:SourceArn": sorted}},
+		}},
+	})
+	return string(policy)
+}
+
+// sameJSON compara dos documentos JSON sin importar el formato. SQS devuelve
+// maxReceiveCount como número aunque se haya enviado como texto, así que los
+// números se comparan por su representación.
+func sameJSON(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var decodedA, decodedB any
+	if json.Unmarshal([]byte(a), &decodedA) != nil || json.Unmarshal([]byte(b), &decodedB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(stringify(decodedA), stringify(decodedB))
+}
+
+func stringify(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = stringify(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = stringify(item)
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return v
+	}
+}
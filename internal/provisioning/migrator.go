@@ -0,0 +1,105 @@
+package provisioning
+
+import (
+	"context"
+	"time"
+
+	"github.com/juanmalvarez3/twit/pkg/logger"
+)
+
+const (
+	DefaultPollInterval = 2 * time.Second
+	DefaultWaitTimeout  = 10 * time.Minute
+)
+
+type Options struct {
+	// Recreate son las tablas que se pueden borrar y volver a crear cuando su
+	// clave o la de un índice no coincide con la declarada. Se pierden sus
+	// datos.
+	Recreate map[string]bool
+	// PollInterval es la espera entre consultas mientras una tabla o un
+	// índice se crea o se borra.
+	PollInterval time.Duration
+	// WaitTimeout limita cada una de esas esperas.
+	WaitTimeout time.Duration
+}
+
+// Problem es una diferencia entre lo declarado y lo desplegado.
+type Problem struct {
+	Resource string
+	Detail   string
+}
+
+func (p Problem) String() string {
+	return p.Resource + ": " + p.Detail
+}
+
+// Migrator aplica y verifica un Schema. Los clientes de un servicio que el
+// Schema no usa pueden ser nil.
+type Migrator struct {
+	dynamo  DynamoDBAPI
+	sns     SNSAPI
+	sqs     SQSAPI
+	options Options
+	logger  *logger.Logger
+}
+
+func New(dynamo DynamoDBAPI, snsClient SNSAPI, sqsClient SQSAPI, options Options, log *logger.Logger) *Migrator {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	if options.WaitTimeout <= 0 {
+		options.WaitTimeout = DefaultWaitTimeout
+	}
+	return &Migrator{
+		dynamo:  dynamo,
+		sns:     snsClient,
+		sqs:     sqsClient,
+		options: options,
+		logger:  log.Named("provisioning"),
+	}
+}
+
+// Apply crea o actualiza los recursos del Schema. Es idempotente: sobre
+// recursos que ya coinciden con lo declarado no hace cambios.
+func (m *Migrator) Apply(ctx context.Context, schema Schema) error {
+	for _, table := range schema.Tables {
+		if err := m.applyTable(ctx, table); err != nil {
+			return err
+		}
+	}
+	if len(schema.Queues) > 0 || len(schema.Topics) > 0 {
+		return m.applyMessaging(ctx, schema)
+	}
+	return nil
+}
+
+// Verify compara los recursos desplegados con el Schema sin modificarlos.
+func (m *Migrator) Verify(ctx context.Context, schema Schema) ([]Problem, error) {
+	var problems []Problem
+	for _, table := range schema.Tables {
+		tableProblems, err := m.verifyTable(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, tableProblems...)
+	}
+	if len(schema.Queues) > 0 || len(schema.Topics) > 0 {
+		messagingProblems, err := m.verifyMessaging(ctx, schema)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, messagingProblems...)
+	}
+	return problems, nil
+}
+
+// sleep espera PollInterval, o falla si antes se cancela ctx.
+func (m *Migrator) sleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(m.options.PollInterval):
+		return nil
+	}
+}
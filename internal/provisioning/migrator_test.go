@@ -0,0 +1,213 @@
+package provisioning_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker/snssqs"
+	"github.com/juanmalvarez3/twit/internal/provisioning"
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tables = config.DynamoDBConfig{
+	TweetsTable:        "tweets",
+	UsersTable:         "users",
+	FollowsTable:       "follows",
+	TimelinesTable:     "timelines",
+	HashtagsTable:      "hashtags",
+	NotificationsTable: "notifications",
+}
+
+func routes() snssqs.Routes {
+	return snssqs.Routes{
+		Topics: map[string]string{
+			broker.TopicTweets:  topicARN("tweets-topic"),
+			broker.TopicFollows: topicARN("follows-topic"),
+		},
+		Queues: map[string]string{
+			broker.SubscriptionOrchestrateFanout: queueURL("orchestrate-queue"),
+			broker.SubscriptionNotifications:     queueURL("notifications-queue"),
+			broker.SubscriptionUpdateTimeline:    queueURL("update-timeline-queue"),
+		},
+	}
+}
+
+func newMigrator(t *testing.T, dynamo *fakeDynamoDB, snsClient *fakeSNS, sqsClient *fakeSQS, options provisioning.Options) *provisioning.Migrator {
+	t.Helper()
+	log, err := logger.New("error", "test")
+	require.NoError(t, err)
+	return provisioning.New(dynamo, snsClient, sqsClient, options, log)
+}
+
+func TestDeclare(t *testing.T) {
+	schema := provisioning.Declare(tables, routes(), broker.DefaultTopology(), 5)
+
+	require.Len(t, schema.Tables, 6)
+	assert.Equal(t, []provisioning.Queue{
+		{Name: "notifications-queue", DLQ: "notifications-queue-dlq", MaxReceiveCount: 5},
+		{Name: "orchestrate-queue", DLQ: "orchestrate-queue-dlq", MaxReceiveCount: 5},
+		{Name: "update-timeline-queue", DLQ: "update-timeline-queue-dlq", MaxReceiveCount: 5},
+	}, schema.Queues)
+	assert.Equal(t, []provisioning.Topic{
+		{Name: "follows-topic", ARN: topicARN("follows-topic")},
+		{Name: "tweets-topic", ARN: topicARN("tweets-topic")},
+	}, schema.Topics)
+	// update-timeline no tiene tópico SNS: sus mensajes van directo a la cola.
+	assert.ElementsMatch(t, []provisioning.Subscription{
+		{Topic: "tweets-topic", Queue: "orchestrate-queue"},
+		{Topic: "tweets-topic", Queue: "notifications-queue"},
+		{Topic: "follows-topic", Queue: "notifications-queue"},
+	}, schema.Subscriptions)
+}
+
+func TestApplyCreatesTablesAndIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	dynamo := newFakeDynamoDB()
+	migrator := newMigrator(t, dynamo, nil, nil, provisioning.Options{})
+	schema := provisioning.Schema{Tables: provisioning.Declare(tables, snssqs.Routes{}, nil, 0).Tables}
+
+	require.NoError(t, migrator.Apply(ctx, schema))
+	assert.Equal(t, 6, dynamo.calls["CreateTable"])
+	assert.Equal(t, 1, dynamo.calls["UpdateTimeToLive"])
+	assert.Len(t, dynamo.tables["follows"].GlobalSecondaryIndexes, 2)
+	assert.Equal(t, "ttl", dynamo.ttl["timelines"])
+
+	problems, err := migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, migrator.Apply(ctx, schema))
+	assert.Equal(t, 6, dynamo.calls["CreateTable"])
+	assert.Zero(t, dynamo.calls["UpdateTable"])
+	assert.Equal(t, 1, dynamo.calls["UpdateTimeToLive"])
+}
+
+func TestApplyAddsMissingIndexesAndTTL(t *testing.T) {
+	ctx := context.Background()
+	dynamo := newFakeDynamoDB()
+	dynamo.put("follows", "follower_id", "followed_id", "followed_id-index")
+	dynamo.put("timelines", "user_id", "SK")
+	migrator := newMigrator(t, dynamo, nil, nil, provisioning.Options{})
+	schema := provisioning.Schema{Tables: provisioning.Declare(tables, snssqs.Routes{}, nil, 0).Tables}
+
+	problems, err := migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Contains(t, problems, provisioning.Problem{Resource: "dynamodb:follows", Detail: "falta el índice follower_id-index"})
+	assert.Contains(t, problems, provisioning.Problem{Resource: "dynamodb:timelines", Detail: "el TTL no está habilitado sobre ttl"})
+
+	require.NoError(t, migrator.Apply(ctx, schema))
+	assert.Equal(t, 1, dynamo.calls["UpdateTable"])
+	assert.Equal(t, 4, dynamo.calls["CreateTable"])
+
+	problems, err = migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestApplyKeyMismatch(t *testing.T) {
+	ctx := context.Background()
+	schema := provisioning.Schema{Tables: []provisioning.Table{provisioning.Declare(tables, snssqs.Routes{}, nil, 0).Tables[3]}}
+	require.Equal(t, "timelines", schema.Tables[0].Name)
+
+	t.Run("sin permiso para recrear falla", func(t *testing.T) {
+		dynamo := newFakeDynamoDB()
+		dynamo.put("timelines", "user_id", "tweet_id")
+		migrator := newMigrator(t, dynamo, nil, nil, provisioning.Options{})
+
+		err := migrator.Apply(ctx, schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--recreate=timelines")
+		assert.Zero(t, dynamo.calls["DeleteTable"])
+
+		problems, err := migrator.Verify(ctx, schema)
+		require.NoError(t, err)
+		require.NotEmpty(t, problems)
+		assert.Contains(t, problems[0].Detail, "RANGE SK (S)")
+	})
+
+	t.Run("con permiso la recrea", func(t *testing.T) {
+		dynamo := newFakeDynamoDB()
+		dynamo.put("timelines", "user_id", "tweet_id")
+		migrator := newMigrator(t, dynamo, nil, nil, provisioning.Options{Recreate: map[string]bool{"timelines": true}})
+
+		require.NoError(t, migrator.Apply(ctx, schema))
+		assert.Equal(t, 1, dynamo.calls["DeleteTable"])
+		assert.Equal(t, "SK", aws.ToString(dynamo.tables["timelines"].KeySchema[1].AttributeName))
+
+		problems, err := migrator.Verify(ctx, schema)
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+}
+
+func TestApplyMessaging(t *testing.T) {
+	ctx := context.Background()
+	snsClient, sqsClient := newFakeSNS(), newFakeSQS()
+	migrator := newMigrator(t, nil, snsClient, sqsClient, provisioning.Options{})
+	schema := provisioning.Declare(tables, routes(), broker.DefaultTopology(), 5)
+	schema.Tables = nil
+
+	problems, err := migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Contains(t, problems, provisioning.Problem{Resource: "sqs:orchestrate-queue", Detail: "la cola no existe"})
+	assert.Contains(t, problems, provisioning.Problem{Resource: "sns:tweets-topic", Detail: "no existe el tópico " + topicARN("tweets-topic")})
+
+	require.NoError(t, migrator.Apply(ctx, schema))
+	assert.Equal(t, 6, sqsClient.calls["CreateQueue"])
+	assert.Equal(t, 2, snsClient.calls["CreateTopic"])
+	assert.Equal(t, 3, snsClient.calls["Subscribe"])
+	assert.ElementsMatch(t, []string{queueARN("orchestrate-queue"), queueARN("notifications-queue")},
+		snsClient.topics[topicARN("tweets-topic")])
+
+	var redrive map[string]string
+	require.NoError(t, json.Unmarshal([]byte(sqsClient.queues["orchestrate-queue"]["RedrivePolicy"]), &redrive))
+	assert.Equal(t, queueARN("orchestrate-queue-dlq"), redrive["deadLetterTargetArn"])
+	assert.Equal(t, "5", redrive["maxReceiveCount"])
+	assert.Contains(t, sqsClient.queues["notifications-queue"]["Policy"], topicARN("follows-topic"))
+	assert.Contains(t, sqsClient.queues["notifications-queue"]["Policy"], topicARN("tweets-topic"))
+	assert.NotContains(t, sqsClient.queues["update-timeline-queue"], "Policy")
+
+	problems, err = migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	setCalls := sqsClient.calls["SetQueueAttributes"]
+	require.NoError(t, migrator.Apply(ctx, schema))
+	assert.Equal(t, 6, sqsClient.calls["CreateQueue"])
+	assert.Equal(t, 3, snsClient.calls["Subscribe"])
+	assert.Equal(t, setCalls, sqsClient.calls["SetQueueAttributes"])
+}
+
+func TestApplyMessagingFixesRedrivePolicy(t *testing.T) {
+	ctx := context.Background()
+	snsClient, sqsClient := newFakeSNS(), newFakeSQS()
+	migrator := newMigrator(t, nil, snsClient, sqsClient, provisioning.Options{})
+	schema := provisioning.Declare(tables, routes(), broker.DefaultTopology(), 5)
+	schema.Tables = nil
+	require.NoError(t, migrator.Apply(ctx, schema))
+
+	// SQS devuelve maxReceiveCount como número: no es una diferencia.
+	sqsClient.queues["orchestrate-queue"]["RedrivePolicy"] =
+		`{"deadLetterTargetArn":"` + queueARN("orchestrate-queue-dlq") + `","maxReceiveCount":5}`
+	problems, err := migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	sqsClient.queues["orchestrate-queue"]["RedrivePolicy"] =
+		`{"deadLetterTargetArn":"` + queueARN("orchestrate-queue-dlq") + `","maxReceiveCount":3}`
+	problems, err = migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Equal(t, []provisioning.Problem{{Resource: "sqs:orchestrate-queue",
+		Detail: "no redirige a orchestrate-queue-dlq tras 5 recepciones"}}, problems)
+
+	require.NoError(t, migrator.Apply(ctx, schema))
+	problems, err = migrator.Verify(ctx, schema)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
@@ -0,0 +1,133 @@
+// Package provisioning declara los recursos de AWS que la aplicación espera
+// encontrar (tablas de DynamoDB con sus índices y TTL, tópicos SNS, colas SQS
+// con sus DLQ y suscripciones), los crea o actualiza sin repetir lo que ya
+// existe y verifica que lo desplegado coincida con lo declarado.
+package provisioning
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/adapters/broker/snssqs"
+	"github.com/juanmalvarez3/twit/pkg/config"
+)
+
+// Schema son los recursos declarados. Un Schema sin tablas o sin colas no
+// toca ese servicio.
+type Schema struct {
+	Tables        []Table
+	Topics        []Topic
+	Queues        []Queue
+	Subscriptions []Subscription
+}
+
+// Attribute es un atributo de clave. Un Name vacío indica que la clave no
+// tiene ese componente.
+type Attribute struct {
+	Name string
+	Type types.ScalarAttributeType
+}
+
+type Index struct {
+	Name     string
+	HashKey  Attribute
+	RangeKey Attribute
+}
+
+type Table struct {
+	Name     string
+	HashKey  Attribute
+	RangeKey Attribute
+	Indexes  []Index
+	// TTLAttribute es el atributo de vencimiento; vacío si la tabla no vence
+	// ítems.
+	TTLAttribute string
+}
+
+type Topic struct {
+	Name string
+	// ARN es el configurado en SNS_*, el que usan los productores.
+	ARN string
+}
+
+// Queue es una cola SQS con su cola de mensajes muertos, a la que pasa un
+// mensaje tras MaxReceiveCount recepciones sin borrar.
+type Queue struct {
+	Name            string
+	DLQ             string
+	MaxReceiveCount int
+}
+
+type Subscription struct {
+	Topic string
+	Queue string
+}
+
+// Declare arma el esquema a partir de la configuración y de las rutas del
+// broker: las mismas tablas, tópicos y colas que usa la aplicación. Las
+// claves e índices son los que leen y escriben los repositorios de DynamoDB.
+func Declare(tables config.DynamoDBConfig, routes snssqs.Routes, topology broker.Topology, maxReceiveCount int) Schema {
+	str := func(name string) Attribute { return Attribute{Name: name, Type: types.ScalarAttributeTypeS} }
+
+	schema := Schema{
+		Tables: []Table{
+			{
+				Name:    tables.TweetsTable,
+				HashKey: str("id"),
+				Indexes: []Index{{Name: "user_id-created_at-index", HashKey: str("user_id"), RangeKey: str("created_at")}},
+			},
+			{Name: tables.UsersTable, HashKey: str("id")},
+			{
+				Name:     tables.FollowsTable,
+				HashKey:  str("follower_id"),
+				RangeKey: str("followed_id"),
+				Indexes: []Index{
+					{Name: "followed_id-index", HashKey: str("followed_id")},
+					{Name: "follower_id-index", HashKey: str("follower_id")},
+				},
+			},
+			{Name: tables.TimelinesTable, HashKey: str("user_id"), RangeKey: str("SK"), TTLAttribute: "ttl"},
+			{Name: tables.HashtagsTable, HashKey: str("tag"), RangeKey: str("sort_key")},
+			{Name: tables.NotificationsTable, HashKey: str("recipient_id"), RangeKey: str("id")},
+		},
+	}
+
+	subscriptions := make([]string, 0, len(routes.Queues))
+	for subscription := range routes.Queues {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Strings(subscriptions)
+	for _, subscription := range subscriptions {
+		name := queueName(routes.Queues[subscription])
+		schema.Queues = append(schema.Queues, Queue{Name: name, DLQ: name + "-dlq", MaxReceiveCount: maxReceiveCount})
+	}
+
+	topics := make([]string, 0, len(routes.Topics))
+	for topic := range routes.Topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	for _, topic := range topics {
+		arn := routes.Topics[topic]
+		schema.Topics = append(schema.Topics, Topic{Name: topicName(arn), ARN: arn})
+		for _, subscription := range topology.Subscriptions(topic) {
+			if url, ok := routes.Queues[subscription]; ok {
+				schema.Subscriptions = append(schema.Subscriptions, Subscription{Topic: topicName(arn), Queue: queueName(url)})
+			}
+		}
+	}
+	return schema
+}
+
+// queueName es el último segmento de la URL de la cola.
+func queueName(url string) string {
+	return path.Base(url)
+}
+
+// topicName es el último segmento del ARN del tópico.
+func topicName(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"go.uber.org/zap"
+)
+
+type messageHandler func(ctx context.Context, message broker.Message) error
+
+// consumerWorker arma un worker que consume subscription. Un error de handle
+// deja el lote sin confirmar para que el broker lo vuelva a entregar.
+func consumerWorker(name string, a *app.App, subscription string, handle messageHandler, checks ...health.Checker) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	consumer := broker.NewConsumer(a.Infra.Broker, subscription, func(messages []broker.Message) error {
+		// El lote en curso se procesa con un contexto que no se cancela al
+		// cerrar el worker, así termina antes de salir.
+		ctx := context.Background()
+		for _, message := range messages {
+			log.Info("Procesando mensaje", zap.String("messageId", message.ID))
+			if err := handle(message.Context(ctx), message); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, log)
+
+	checks = append(checks,
+		health.Broker(a.Infra.Broker, subscription),
+		health.Staleness(name+"_poll", consumer.LastSuccessfulPoll,
+			time.Duration(a.Config.Health.PollStalenessSeconds)*time.Second),
+	)
+
+	return Worker{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			consumer.Start(ctx)
+			return nil
+		},
+		Checks: checks,
+	}
+}
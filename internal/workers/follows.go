@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// follows consume los follows creados (cola process-new-follow) y completa el
+// timeline del seguidor.
+func follows(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	processFollowUseCase := a.ProcessNewFollow()
+
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "process-new-follow.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		var followEvent events.FollowCreatedEvent
+		if err := json.Unmarshal(message.Body, &followEvent); err != nil {
+			log.Error("Error al deserializar evento de follow", zap.Error(err))
+			tracing.RecordError(span, err)
+			return nil
+		}
+
+		if err := processFollowUseCase.ProcessNewFollow(msgCtx, followEvent); err != nil {
+			log.Error("Error al procesar follow creado",
+				zap.Error(err),
+				zap.String("followerId", followEvent.Follow.FollowerID),
+				zap.String("followedId", followEvent.Follow.FollowedID))
+			tracing.RecordError(span, err)
+			return nil
+		}
+
+		log.Info("Follow procesado correctamente",
+			zap.String("followerId", followEvent.Follow.FollowerID),
+			zap.String("followedId", followEvent.Follow.FollowedID))
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionProcessNewFollow, handle,
+		a.TableCheck(a.Config.DynamoDB.TweetsTable),
+		a.TableCheck(a.Config.DynamoDB.FollowsTable),
+		a.TableCheck(a.Config.DynamoDB.TimelinesTable),
+		health.Redis(a.Infra.Cache),
+	)
+}
@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	dmnfollow "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain"
+	followEvents "github.com/juanmalvarez3/twit/internal/domains/twitter/follow/domain/events"
+	tweetEvents "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/events"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// notifications genera las notificaciones de tweets y de nuevos seguidores.
+func notifications(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	notifyTweetUseCase := a.NotifyTweet()
+	notifyFollowUseCase := a.NotifyFollow()
+
+	handleTweet := func(ctx context.Context, span trace.Span, payload []byte) {
+		var tweetEvent tweetEvents.TweetCreatedEvent
+		if err := json.Unmarshal(payload, &tweetEvent); err != nil {
+			log.Error("Error al deserializar evento de tweet", zap.Error(err))
+			tracing.RecordError(span, err)
+			return
+		}
+
+		if err := notifyTweetUseCase.Exec(ctx, tweetEvent.Tweet); err != nil {
+			log.Error("Error al notificar tweet",
+				zap.Error(err),
+				zap.String("userId", tweetEvent.Tweet.UserID),
+				zap.String("tweetId", tweetEvent.Tweet.ID))
+			tracing.RecordError(span, err)
+			return
+		}
+
+		log.Info("Notificaciones de tweet procesadas",
+			zap.String("userId", tweetEvent.Tweet.UserID),
+			zap.String("tweetId", tweetEvent.Tweet.ID))
+	}
+
+	handleFollow := func(ctx context.Context, span trace.Span, payload []byte) {
+		var followEvent followEvents.FollowCreatedEvent
+		if err := json.Unmarshal(payload, &followEvent); err != nil {
+			log.Error("Error al deserializar evento de follow", zap.Error(err))
+			tracing.RecordError(span, err)
+			return
+		}
+
+		follow := dmnfollow.Follow{
+			ID:         followEvent.Follow.ID,
+			FollowerID: followEvent.Follow.FollowerID,
+			FollowedID: followEvent.Follow.FollowedID,
+			CreatedAt:  followEvent.Follow.CreatedAt,
+		}
+		if err := notifyFollowUseCase.Exec(ctx, follow); err != nil {
+			log.Error("Error al notificar nuevo seguidor",
+				zap.Error(err),
+				zap.String("followerId", follow.FollowerID),
+				zap.String("followedId", follow.FollowedID))
+			tracing.RecordError(span, err)
+			return
+		}
+
+		log.Info("Notificación de nuevo seguidor procesada",
+			zap.String("followerId", follow.FollowerID),
+			zap.String("followedId", follow.FollowedID))
+	}
+
+	// La suscripción recibe de los tópicos de tweets y de follows; el tópico
+	// del mensaje indica qué evento contiene.
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "notifications.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		switch message.Topic {
+		case broker.TopicTweets:
+			handleTweet(msgCtx, span, message.Body)
+		case broker.TopicFollows:
+			handleFollow(msgCtx, span, message.Body)
+		default:
+			log.Warn("Mensaje de un tópico desconocido, se descarta",
+				zap.String("topic", message.Topic),
+				zap.String("messageId", message.ID))
+		}
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionNotifications, handle,
+		a.TableCheck(a.Config.DynamoDB.NotificationsTable),
+	)
+}
@@ -0,0 +1,50 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// populateCache guarda en Redis los timelines leídos de DynamoDB.
+func populateCache(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	populateCacheUC := a.PopulateCache()
+
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "populate-cache.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		var timeline dmntimeline.Timeline
+		if err := json.Unmarshal(message.Body, &timeline); err != nil {
+			log.Error("Error al deserializar timeline",
+				zap.Error(err),
+				zap.ByteString("messageBody", message.Body))
+			tracing.RecordError(span, err)
+			return err
+		}
+
+		if err := populateCacheUC.Exec(msgCtx, timeline); err != nil {
+			log.Error("Error al actualizar timeline", zap.Error(err))
+			tracing.RecordError(span, err)
+			return err
+		}
+
+		log.Info("Timeline actualizado correctamente",
+			zap.String("userId", timeline.UserID),
+			zap.Int("entries_count", len(timeline.Entries)))
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionPopulateCache, handle,
+		health.Redis(a.Infra.Cache),
+	)
+}
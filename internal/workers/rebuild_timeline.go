@@ -0,0 +1,52 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	dmntimeline "github.com/juanmalvarez3/twit/internal/domains/twitter/timeline/domain"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// rebuildTimeline reconstruye desde los follows y los tweets los timelines
+// que no se encontraron en la cache ni en DynamoDB.
+func rebuildTimeline(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	rebuildTimelineUseCase := a.RebuildTimeline()
+
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "rebuild-timeline.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		var populateCacheEvent dmntimeline.PopulateCacheEvent
+		if err := json.Unmarshal(message.Body, &populateCacheEvent); err != nil {
+			log.Error("Error al deserializar evento de reconstrucción", zap.Error(err))
+			return nil
+		}
+
+		if err := rebuildTimelineUseCase.Exec(msgCtx, populateCacheEvent.UserID); err != nil {
+			log.Error("Error al reconstruir timeline",
+				zap.Error(err),
+				zap.String("userId", populateCacheEvent.UserID))
+			tracing.RecordError(span, err)
+			return err
+		}
+
+		log.Info("Timeline reconstruido correctamente",
+			zap.String("userId", populateCacheEvent.UserID))
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionRebuildTimeline, handle,
+		a.TableCheck(a.Config.DynamoDB.TweetsTable),
+		a.TableCheck(a.Config.DynamoDB.FollowsTable),
+		a.TableCheck(a.Config.DynamoDB.TimelinesTable),
+		health.Redis(a.Infra.Cache),
+	)
+}
@@ -0,0 +1,87 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+const (
+	minRestartDelay = time.Second
+	maxRestartDelay = 30 * time.Second
+
+	// stableRunTime es cuánto tiene que correr un worker sin fallar para que
+	// la espera entre reinicios vuelva al mínimo.
+	stableRunTime = time.Minute
+)
+
+var errStopped = errors.New("el worker terminó sin que se lo detuviera")
+
+type Logger interface {
+	Info(msg string, fields ...zap.Field)
+	Error(msg string, fields ...zap.Field)
+}
+
+// Run corre los workers hasta que se cancele ctx y devuelve cuando todos
+// terminaron.
+func Run(ctx context.Context, workers []Worker, logger Logger) {
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Supervise(ctx, w, logger)
+		}()
+	}
+	wg.Wait()
+}
+
+// Supervise corre w hasta que se cancele ctx. Si Run entra en pánico o
+// termina antes de la cancelación, lo vuelve a iniciar tras una espera que
+// se duplica en cada falla seguida, hasta maxRestartDelay.
+func Supervise(ctx context.Context, w Worker, logger Logger) {
+	delay := minRestartDelay
+	for {
+		logger.Info("Iniciando worker", zap.String("worker", w.Name))
+		started := time.Now()
+		err := runSafely(ctx, w)
+		if ctx.Err() != nil {
+			logger.Info("Worker detenido", zap.String("worker", w.Name))
+			return
+		}
+		if err == nil {
+			err = errStopped
+		}
+
+		if time.Since(started) >= stableRunTime {
+			delay = minRestartDelay
+		}
+		metrics.WorkerRestarts.WithLabelValues(w.Name).Inc()
+		logger.Error("Worker caído, se reinicia",
+			zap.String("worker", w.Name),
+			zap.Duration("delay", delay),
+			zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRestartDelay)
+	}
+}
+
+func runSafely(ctx context.Context, w Worker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("pánico: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return w.Run(ctx)
+}
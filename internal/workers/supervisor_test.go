@@ -0,0 +1,98 @@
+package workers_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/workers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string, ...zap.Field)  {}
+func (nopLogger) Error(string, ...zap.Field) {}
+
+func TestParse(t *testing.T) {
+	t.Run("all selecciona todos los workers", func(t *testing.T) {
+		names, err := workers.Parse("all")
+		require.NoError(t, err)
+		assert.Equal(t, workers.Names(), names)
+	})
+
+	t.Run("lista con repetidos y espacios", func(t *testing.T) {
+		names, err := workers.Parse("tweets, follows,tweets,")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"tweets", "follows"}, names)
+	})
+
+	t.Run("worker desconocido", func(t *testing.T) {
+		_, err := workers.Parse("tweets,nope")
+		assert.Error(t, err)
+	})
+}
+
+func TestSupervise(t *testing.T) {
+	t.Run("reinicia el worker tras un pánico", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var runs atomic.Int32
+		w := workers.Worker{
+			Name: "test",
+			Run: func(ctx context.Context) error {
+				if runs.Add(1) == 1 {
+					panic("boom")
+				}
+				cancel()
+				<-ctx.Done()
+				return nil
+			},
+		}
+
+		done := make(chan struct{})
+		go func() {
+			workers.Supervise(ctx, w, nopLogger{})
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("el supervisor no terminó")
+		}
+		assert.Equal(t, int32(2), runs.Load())
+	})
+
+	t.Run("no reinicia al cancelar el contexto", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var runs atomic.Int32
+		w := workers.Worker{
+			Name: "test",
+			Run: func(ctx context.Context) error {
+				runs.Add(1)
+				<-ctx.Done()
+				return nil
+			},
+		}
+
+		done := make(chan struct{})
+		go func() {
+			workers.Run(ctx, []workers.Worker{w}, nopLogger{})
+			close(done)
+		}()
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Run no terminó tras cancelar")
+		}
+		assert.LessOrEqual(t, runs.Load(), int32(1))
+	})
+}
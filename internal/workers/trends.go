@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/events"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// trends registra los hashtags de cada tweet creado (cola update-trends).
+func trends(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	recordTweetUseCase := a.RecordTweet()
+
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "update-trends.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		var tweetEvent events.TweetCreatedEvent
+		if err := json.Unmarshal(message.Body, &tweetEvent); err != nil {
+			log.Error("Error al deserializar evento de tweet", zap.Error(err))
+			tracing.RecordError(span, err)
+			return nil
+		}
+
+		if err := recordTweetUseCase.Exec(msgCtx, tweetEvent.Tweet); err != nil {
+			log.Error("Error al registrar tweet en tendencias",
+				zap.Error(err),
+				zap.String("userId", tweetEvent.Tweet.UserID),
+				zap.String("tweetId", tweetEvent.Tweet.ID))
+			tracing.RecordError(span, err)
+			return nil
+		}
+
+		log.Info("Tweet registrado en tendencias",
+			zap.String("userId", tweetEvent.Tweet.UserID),
+			zap.String("tweetId", tweetEvent.Tweet.ID))
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionUpdateTrends, handle,
+		health.Redis(a.Infra.Cache),
+	)
+}
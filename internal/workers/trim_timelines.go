@@ -0,0 +1,69 @@
+package workers
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"go.uber.org/zap"
+)
+
+// trimTimelines recorta periódicamente los timelines marcados como
+// excedidos de tamaño.
+func trimTimelines(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	cfg := a.Config
+	interval := time.Duration(cfg.Timeline.TrimIntervalSeconds) * time.Second
+	trimTimelinesUseCase := a.TrimTimelines()
+
+	var lastRun atomic.Int64
+	run := func(ctx context.Context) {
+		// Se procesan lotes hasta vaciar el set de pendientes para que una
+		// ráfaga de escrituras no se arrastre a las pasadas siguientes.
+		for ctx.Err() == nil {
+			processed, err := trimTimelinesUseCase.Exec(ctx)
+			if err != nil {
+				log.Error("Error recortando timelines", zap.Error(err))
+				return
+			}
+			if processed < cfg.Timeline.TrimBatchSize {
+				break
+			}
+		}
+		lastRun.Store(time.Now().UnixNano())
+	}
+
+	return Worker{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			log.Info("Iniciando recorte de timelines",
+				zap.Int("max_entries", cfg.Timeline.MaxEntries),
+				zap.Duration("interval", interval))
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			run(ctx)
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+					run(ctx)
+				}
+			}
+		},
+		Checks: []health.Checker{
+			a.TableCheck(cfg.DynamoDB.TimelinesTable),
+			health.Redis(a.Infra.Cache),
+			health.Staleness("trim_run", func() time.Time {
+				if nanos := lastRun.Load(); nanos != 0 {
+					return time.Unix(0, nanos)
+				}
+				return time.Time{}
+			}, 3*interval),
+		},
+	}
+}
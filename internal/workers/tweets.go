@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain/events"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// tweets consume los tweets creados (cola orchestrate-fanout) y los reparte
+// entre los timelines de los seguidores.
+func tweets(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	orchestrateFanoutUseCase := a.OrchestrateFanout()
+
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "orchestrate-fanout.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		var tweetEvent events.TweetCreatedEvent
+		if err := json.Unmarshal(message.Body, &tweetEvent); err != nil {
+			log.Error("Error al deserializar evento de tweet", zap.Error(err))
+			tracing.RecordError(span, err)
+			return nil
+		}
+
+		if err := orchestrateFanoutUseCase.Exec(msgCtx, tweetEvent.Tweet); err != nil {
+			log.Error("Error al procesar tweet creado",
+				zap.Error(err),
+				zap.String("userId", tweetEvent.Tweet.UserID),
+				zap.String("tweetId", tweetEvent.Tweet.ID))
+			tracing.RecordError(span, err)
+			return nil
+		}
+
+		log.Info("Tweet procesado correctamente",
+			zap.String("userId", tweetEvent.Tweet.UserID),
+			zap.String("tweetId", tweetEvent.Tweet.ID))
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionOrchestrateFanout, handle,
+		a.TableCheck(a.Config.DynamoDB.FollowsTable),
+	)
+}
@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juanmalvarez3/twit/internal/adapters/broker"
+	"github.com/juanmalvarez3/twit/internal/app"
+	dmntweet "github.com/juanmalvarez3/twit/internal/domains/twitter/tweet/domain"
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// UpdateTimelineRequest es el mensaje de la cola update-timeline: un tweet a
+// agregar al timeline de UserID.
+type UpdateTimelineRequest struct {
+	Tweet  dmntweet.Tweet `json:"tweet"`
+	UserID string         `json:"user_id"`
+}
+
+// updateTimeline agrega tweets a timelines individuales. Un error deja el
+// lote en la cola para reintentarlo.
+func updateTimeline(name string, a *app.App) Worker {
+	log := a.Logger.With(zap.String("worker", name))
+	updateTimelineUseCase := a.UpdateTimeline()
+
+	handle := func(ctx context.Context, message broker.Message) error {
+		msgCtx, span := tracing.Start(ctx, "update-timeline.process",
+			trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		var updateEvent UpdateTimelineRequest
+		if err := json.Unmarshal(message.Body, &updateEvent); err != nil {
+			log.Error("Error al deserializar evento de actualización",
+				zap.Error(err),
+				zap.ByteString("messageBody", message.Body))
+			tracing.RecordError(span, err)
+			return err
+		}
+
+		if err := updateTimelineUseCase.Exec(msgCtx, updateEvent.Tweet, updateEvent.UserID); err != nil {
+			log.Error("Error al actualizar timeline",
+				zap.Error(err),
+				zap.String("userId", updateEvent.UserID),
+				zap.String("tweetId", updateEvent.Tweet.ID))
+			tracing.RecordError(span, err)
+			return err
+		}
+
+		log.Info("Timeline actualizado correctamente",
+			zap.String("userId", updateEvent.UserID),
+			zap.String("tweetId", updateEvent.Tweet.ID))
+		return nil
+	}
+
+	return consumerWorker(name, a, broker.SubscriptionUpdateTimeline, handle,
+		a.TableCheck(a.Config.DynamoDB.TimelinesTable),
+		health.Redis(a.Infra.Cache),
+	)
+}
@@ -0,0 +1,98 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juanmalvarez3/twit/internal/app"
+	"github.com/juanmalvarez3/twit/pkg/health"
+)
+
+// Worker es un proceso de fondo que corre hasta que se cancele el contexto
+// de Run. Checks son los health checks de sus dependencias.
+type Worker struct {
+	Name   string
+	Run    func(ctx context.Context) error
+	Checks []health.Checker
+}
+
+type factory func(name string, a *app.App) Worker
+
+var registry = map[string]factory{
+	"tweets":           tweets,
+	"follows":          follows,
+	"update-timeline":  updateTimeline,
+	"populate-cache":   populateCache,
+	"rebuild-timeline": rebuildTimeline,
+	"trends":           trends,
+	"notifications":    notifications,
+	"trim-timelines":   trimTimelines,
+}
+
+// Names devuelve los nombres de todos los workers registrados, ordenados.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Parse convierte una lista separada por comas en nombres de workers. "all" o
+// una lista vacía seleccionan todos.
+func Parse(list string) ([]string, error) {
+	list = strings.TrimSpace(list)
+	if list == "" || list == "all" {
+		return Names(), nil
+	}
+
+	var names []string
+	seen := make(map[string]struct{})
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("worker desconocido %q, disponibles: %s", name, strings.Join(Names(), ","))
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no se indicó ningún worker")
+	}
+	return names, nil
+}
+
+// Build arma los workers pedidos con las dependencias compartidas.
+func Build(names []string, a *app.App) []Worker {
+	workers := make([]Worker, 0, len(names))
+	for _, name := range names {
+		workers = append(workers, registry[name](name, a))
+	}
+	return workers
+}
+
+// Checks junta los health checks de los workers sin repetir los que comparten
+// dependencia.
+func Checks(workers []Worker) []health.Checker {
+	var checks []health.Checker
+	seen := make(map[string]struct{})
+	for _, w := range workers {
+		for _, check := range w.Checks {
+			if _, ok := seen[check.Name()]; ok {
+				continue
+			}
+			seen[check.Name()] = struct{}{}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
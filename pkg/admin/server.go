@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juanmalvarez3/twit/pkg/health"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// Server es el listener administrativo que exponen los workers para que
+// puedan ser monitoreados sin depender del servidor HTTP de la API.
+type Server struct {
+	server *http.Server
+	mux    *http.ServeMux
+	logger logger.LoggerInterface
+}
+
+func New(port string, log logger.LoggerInterface) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+
+	return &Server{
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%s", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		mux:    mux,
+		logger: log,
+	}
+}
+
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// WithHealth registra /livez y /readyz, este último respaldado por los checks
+// de dependencias del worker.
+func (s *Server) WithHealth(registry *health.Registry) {
+	s.mux.Handle("/livez", health.LivenessHandler())
+	s.mux.Handle("/readyz", health.ReadinessHandler(registry))
+}
+
+func (s *Server) Start() {
+	go func() {
+		s.logger.Info("Listener administrativo iniciado", zap.String("addr", s.server.Addr))
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("Error en listener administrativo", zap.Error(err))
+		}
+	}()
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -1,16 +1,17 @@
-package dynamodb
+package awsconfig
 
 import (
 	"context"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	pkgConfig "github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
-func Provide(ctx context.Context) (*dynamodb.Client, error) {
-	cfg, err := pkgConfig.New()
+func Load(ctx context.Context, cfg *pkgConfig.Config) (aws.Config, error) {
 	credProvider := credentials.NewStaticCredentialsProvider(
 		cfg.AWS.AccessKey,
 		cfg.AWS.SecretKey,
@@ -31,7 +32,11 @@ func Provide(ctx context.Context) (*dynamodb.Client, error) {
 		config.WithEndpointResolverWithOptions(customResolver),
 	)
 	if err != nil {
-		return nil, err
+		return aws.Config{}, err
 	}
-	return dynamodb.NewFromConfig(awsCfg), nil
+
+	otelaws.AppendMiddlewares(&awsCfg.APIOptions)
+	metrics.AppendAWSMiddlewares(&awsCfg.APIOptions)
+
+	return awsCfg, nil
 }
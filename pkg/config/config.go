@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -16,6 +17,18 @@ type Config struct {
 	SQS      SQSConfig
 	Cache    CacheConfig
 	Log      LogConfig
+	Tracing  TracingConfig
+	Metrics  MetricsConfig
+	Health   HealthConfig
+	Trends   TrendsConfig
+	Stream   StreamConfig
+	Realtime RealtimeConfig
+	Timeline TimelineConfig
+	Follow   FollowConfig
+	Worker   WorkerConfig
+	Backend  BackendConfig
+	Broker   BrokerConfig
+	SQL      SQLConfig
 }
 
 type ServerConfig struct {
@@ -30,10 +43,12 @@ type AWSConfig struct {
 }
 
 type DynamoDBConfig struct {
-	TweetsTable    string
-	UsersTable     string
-	FollowsTable   string
-	TimelinesTable string
+	TweetsTable        string
+	UsersTable         string
+	FollowsTable       string
+	TimelinesTable     string
+	HashtagsTable      string
+	NotificationsTable string
 }
 
 type RedisConfig struct {
@@ -55,18 +70,141 @@ type SQSConfig struct {
 	ProcessFollowQueue   string
 	PopulateCacheQueue   string
 	RebuildTimelineQueue string
+	UpdateTrendsQueue    string
+	NotificationsQueue   string
 }
 
 type CacheConfig struct {
-	Enabled bool
-	TTL     int
+	Enabled           bool
+	TTL               int
+	JitterPercent     int
+	SlidingExpiration bool
+	LockMs            int
+	LockWaitMs        int
+	DedupSeconds      int
+	UserTweetsTTL     int
+	TweetTTL          int
+	L1Enabled         bool
+	L1TimelinesSize   int
+	L1TweetsSize      int
+	L1TTLMs           int
 }
 
 type LogConfig struct {
-	Level       string
+	Level string
+	// Environment es APP_ENV. Las relajaciones pensadas para desarrollo,
+	// como el WebSocket sin tokens, sólo se permiten si vale
+	// EnvironmentDevelopment de forma explícita.
 	Environment string
 }
 
+const EnvironmentDevelopment = "development"
+
+type MetricsConfig struct {
+	AdminPort string
+}
+
+type TrendsConfig struct {
+	BucketSeconds int
+	BaselineHours int
+	MinAuthors    int
+	Limit         int
+	CacheSeconds  int
+}
+
+type StreamConfig struct {
+	HeartbeatSeconds int
+	BufferSize       int
+	ResumeLimit      int
+}
+
+type RealtimeConfig struct {
+	AuthSecret         string
+	AllowedOrigins     []string
+	SendBuffer         int
+	SlowConsumerPolicy string
+	PingSeconds        int
+	MaxSubscriptions   int
+}
+
+type TimelineConfig struct {
+	StorageMode         string
+	MaxEntries          int
+	MaxAgeDays          int
+	TrimIntervalSeconds int
+	TrimBatchSize       int
+	RebuildConcurrency  int
+	RebuildPageSize     int
+	RebuildSize         int
+}
+
+type FollowConfig struct {
+	BackfillCount       int
+	BackfillMaxAgeHours int
+}
+
+type WorkerConfig struct {
+	Workers                string
+	ShutdownTimeoutSeconds int
+	// InProcess son los workers que la API corre en su propio proceso; vacío
+	// para ninguno.
+	InProcess string
+}
+
+// BackendConfig elige la implementación de cada dependencia externa. Con
+// todas en BackendMemory la API y los workers corren en un solo proceso sin
+// servicios externos.
+type BackendConfig struct {
+	Storage   string
+	Messaging string
+	Cache     string
+}
+
+// BrokerConfig ajusta la entrega de mensajes de los brokers que no la
+// configuran por fuera, como Redis Streams y el broker en memoria.
+type BrokerConfig struct {
+	VisibilityTimeoutSeconds int
+	// MaxDeliveries son las entregas tras las que un mensaje pasa a la cola de
+	// mensajes muertos de su suscripción.
+	MaxDeliveries int
+	StreamMaxLen  int
+	// ConsumerName identifica al proceso dentro de cada grupo de consumidores;
+	// vacío usa hostname y PID.
+	ConsumerName string
+}
+
+const (
+	BackendDynamoDB = "dynamodb"
+	BackendAWS      = "aws"
+	BackendRedis    = "redis"
+	BackendMemory   = "memory"
+	BackendSQL      = "sql"
+)
+
+// SQLConfig es la base relacional de STORAGE_BACKEND=sql. Driver es postgres
+// en despliegue; sqlite3 sólo está disponible en las pruebas.
+type SQLConfig struct {
+	Driver       string
+	DSN          string
+	MaxOpenConns int
+	// AutoMigrate aplica las migraciones pendientes al arrancar.
+	AutoMigrate bool
+}
+
+type HealthConfig struct {
+	CheckTimeoutMs       int
+	PollStalenessSeconds int
+}
+
+type TracingConfig struct {
+	Enabled     bool
+	ServiceName string
+	Exporter    string
+	Endpoint    string
+	FilePath    string
+	SampleRatio float64
+}
+
 func New() (*Config, error) {
 	_ = godotenv.Load()
 
@@ -81,17 +219,19 @@ func New() (*Config, error) {
 			SecretKey: getEnv("AWS_SECRET_ACCESS_KEY", "test"),
 		},
 		DynamoDB: DynamoDBConfig{
-			TweetsTable:    getEnv("DYNAMODB_TWEETS_TABLE", "tweets"),
-			UsersTable:     getEnv("DYNAMODB_USERS_TABLE", "users"),
-			FollowsTable:   getEnv("DYNAMODB_FOLLOWS_TABLE", "follows"),
-			TimelinesTable: getEnv("DYNAMODB_TIMELINES_TABLE", "timelines"),
+			TweetsTable:        getEnv("DYNAMODB_TWEETS_TABLE", "tweets"),
+			UsersTable:         getEnv("DYNAMODB_USERS_TABLE", "users"),
+			FollowsTable:       getEnv("DYNAMODB_FOLLOWS_TABLE", "follows"),
+			TimelinesTable:     getEnv("DYNAMODB_TIMELINES_TABLE", "timelines"),
+			HashtagsTable:      getEnv("DYNAMODB_HASHTAGS_TABLE", "hashtags"),
+			NotificationsTable: getEnv("DYNAMODB_NOTIFICATIONS_TABLE", "notifications"),
 		},
 		Redis: RedisConfig{
 			Host:        getEnv("REDIS_HOST", "localhost"),
 			Port:        getEnv("REDIS_PORT", "6379"),
 			Password:    getEnv("REDIS_PASSWORD", ""),
 			DB:          getEnvAsInt("REDIS_DB", 0),
-			TimelineTTL: getEnvAsInt("REDIS_TIMELINE_TTL", 3600*24),
+			TimelineTTL: getEnvAsInt("REDIS_TIMELINE_TTL", 0),
 		},
 		SNS: SNSConfig{
 			TweetsTopic:  getEnv("SNS_TWEETS_TOPIC", "arn:aws:sns:us-east-1:000000000000:tweets"),
@@ -103,14 +243,98 @@ func New() (*Config, error) {
 			ProcessFollowQueue:   getEnv("SQS_PROCESS_NEW_FOLLOW_QUEUE", "http://localstack:4566/000000000000/process-new-follow"),
 			PopulateCacheQueue:   getEnv("SQS_POPULATE_CACHE_QUEUE", "http://localstack:4566/000000000000/populate-cache"),
 			RebuildTimelineQueue: getEnv("SQS_REBUILD_TIMELINE_QUEUE", "http://localstack:4566/000000000000/rebuild-timeline"),
+			UpdateTrendsQueue:    getEnv("SQS_UPDATE_TRENDS_QUEUE", "http://localstack:4566/000000000000/update-trends"),
+			NotificationsQueue:   getEnv("SQS_NOTIFICATIONS_QUEUE", "http://localstack:4566/000000000000/notifications"),
 		},
 		Cache: CacheConfig{
-			Enabled: getEnvAsBool("CACHE_ENABLED", true),
-			TTL:     getEnvAsInt("CACHE_TTL", 3600),
+			Enabled:           getEnvAsBool("CACHE_ENABLED", true),
+			TTL:               getEnvAsInt("CACHE_TTL", 3600),
+			JitterPercent:     getEnvAsInt("CACHE_TTL_JITTER_PERCENT", 10),
+			SlidingExpiration: getEnvAsBool("CACHE_SLIDING_EXPIRATION", true),
+			LockMs:            getEnvAsInt("CACHE_LOCK_MS", 2000),
+			LockWaitMs:        getEnvAsInt("CACHE_LOCK_WAIT_MS", 500),
+			DedupSeconds:      getEnvAsInt("CACHE_PUBLISH_DEDUP_SECONDS", 30),
+			UserTweetsTTL:     getEnvAsInt("CACHE_USER_TWEETS_TTL", 60),
+			TweetTTL:          getEnvAsInt("CACHE_TWEET_TTL", 300),
+			L1Enabled:         getEnvAsBool("CACHE_L1_ENABLED", true),
+			L1TimelinesSize:   getEnvAsInt("CACHE_L1_TIMELINES_SIZE", 10000),
+			L1TweetsSize:      getEnvAsInt("CACHE_L1_TWEETS_SIZE", 50000),
+			L1TTLMs:           getEnvAsInt("CACHE_L1_TTL_MS", 5000),
 		},
 		Log: LogConfig{
 			Level:       getEnv("LOG_LEVEL", "info"),
-			Environment: getEnv("APP_ENV", "development"),
+			Environment: getEnv("APP_ENV", ""),
+		},
+		Tracing: TracingConfig{
+			Enabled:     getEnvAsBool("TRACING_ENABLED", false),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", ""),
+			Exporter:    getEnv("TRACING_EXPORTER", "otlp"),
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+			FilePath:    getEnv("TRACING_FILE_PATH", "traces.json"),
+			SampleRatio: getEnvAsFloat("TRACING_SAMPLE_RATIO", 1.0),
+		},
+		Metrics: MetricsConfig{
+			AdminPort: getEnv("ADMIN_PORT", "9090"),
+		},
+		Health: HealthConfig{
+			CheckTimeoutMs:       getEnvAsInt("HEALTH_CHECK_TIMEOUT_MS", 2000),
+			PollStalenessSeconds: getEnvAsInt("HEALTH_POLL_STALENESS_SECONDS", 120),
+		},
+		Trends: TrendsConfig{
+			BucketSeconds: getEnvAsInt("TRENDS_BUCKET_SECONDS", 300),
+			BaselineHours: getEnvAsInt("TRENDS_BASELINE_HOURS", 24),
+			MinAuthors:    getEnvAsInt("TRENDS_MIN_AUTHORS", 3),
+			Limit:         getEnvAsInt("TRENDS_LIMIT", 10),
+			CacheSeconds:  getEnvAsInt("TRENDS_CACHE_SECONDS", 30),
+		},
+		Stream: StreamConfig{
+			HeartbeatSeconds: getEnvAsInt("STREAM_HEARTBEAT_SECONDS", 15),
+			BufferSize:       getEnvAsInt("STREAM_BUFFER_SIZE", 32),
+			ResumeLimit:      getEnvAsInt("STREAM_RESUME_LIMIT", 100),
+		},
+		Realtime: RealtimeConfig{
+			AuthSecret:         getEnv("WS_AUTH_SECRET", ""),
+			AllowedOrigins:     getEnvAsList("WS_ALLOWED_ORIGINS"),
+			SendBuffer:         getEnvAsInt("WS_SEND_BUFFER", 64),
+			SlowConsumerPolicy: getEnv("WS_SLOW_CONSUMER_POLICY", "drop_oldest"),
+			PingSeconds:        getEnvAsInt("WS_PING_SECONDS", 30),
+			MaxSubscriptions:   getEnvAsInt("WS_MAX_SUBSCRIPTIONS", 20),
+		},
+		Timeline: TimelineConfig{
+			StorageMode:         getEnv("TIMELINE_STORAGE_MODE", "content"),
+			MaxEntries:          getEnvAsInt("TIMELINE_MAX_ENTRIES", 800),
+			MaxAgeDays:          getEnvAsInt("TIMELINE_MAX_AGE_DAYS", 30),
+			TrimIntervalSeconds: getEnvAsInt("TIMELINE_TRIM_INTERVAL_SECONDS", 60),
+			TrimBatchSize:       getEnvAsInt("TIMELINE_TRIM_BATCH_SIZE", 100),
+			RebuildConcurrency:  getEnvAsInt("TIMELINE_REBUILD_CONCURRENCY", 8),
+			RebuildPageSize:     getEnvAsInt("TIMELINE_REBUILD_PAGE_SIZE", 20),
+			RebuildSize:         getEnvAsInt("TIMELINE_REBUILD_SIZE", 200),
+		},
+		Follow: FollowConfig{
+			BackfillCount:       getEnvAsInt("FOLLOW_BACKFILL_COUNT", 50),
+			BackfillMaxAgeHours: getEnvAsInt("FOLLOW_BACKFILL_MAX_AGE_HOURS", 168),
+		},
+		Worker: WorkerConfig{
+			Workers:                getEnv("WORKERS", "all"),
+			ShutdownTimeoutSeconds: getEnvAsInt("WORKER_SHUTDOWN_TIMEOUT_SECONDS", 25),
+			InProcess:              getEnv("API_WORKERS", ""),
+		},
+		Backend: BackendConfig{
+			Storage:   getEnv("STORAGE_BACKEND", BackendDynamoDB),
+			Messaging: getEnv("MESSAGING_BACKEND", BackendAWS),
+			Cache:     getEnv("CACHE_BACKEND", BackendRedis),
+		},
+		Broker: BrokerConfig{
+			VisibilityTimeoutSeconds: getEnvAsInt("BROKER_VISIBILITY_TIMEOUT_SECONDS", 30),
+			MaxDeliveries:            getEnvAsInt("BROKER_MAX_DELIVERIES", 5),
+			StreamMaxLen:             getEnvAsInt("BROKER_STREAM_MAX_LEN", 100000),
+			ConsumerName:             getEnv("BROKER_CONSUMER_NAME", ""),
+		},
+		SQL: SQLConfig{
+			Driver:       getEnv("SQL_DRIVER", "postgres"),
+			DSN:          getEnv("SQL_DSN", ""),
+			MaxOpenConns: getEnvAsInt("SQL_MAX_OPEN_CONNS", 10),
+			AutoMigrate:  getEnvAsBool("SQL_AUTO_MIGRATE", true),
 		},
 	}, nil
 }
@@ -131,6 +355,26 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList separa el valor por comas, sin los elementos vacíos.
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func getEnvAsBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -0,0 +1,27 @@
+package errors
+
+// Códigos estables expuestos en el campo "code" de las respuestas de error.
+// Los clientes deben usarlos en lugar del mensaje, que depende del idioma.
+const (
+	CodeInvalidRequestBody           = "INVALID_REQUEST_BODY"
+	CodeInvalidCursor                = "INVALID_CURSOR"
+	CodeInvalidLimit                 = "INVALID_LIMIT"
+	CodeInvalidQueryParam            = "INVALID_QUERY_PARAM"
+	CodeTweetNotFound                = "TWEET_NOT_FOUND"
+	CodeTweetContentEmpty            = "TWEET_CONTENT_EMPTY"
+	CodeTweetContentTooLong          = "TWEET_CONTENT_TOO_LONG"
+	CodeReplyNotFound                = "REPLY_NOT_FOUND"
+	CodeHashtagInvalid               = "HASHTAG_INVALID"
+	CodeFollowIDInvalid              = "FOLLOW_ID_INVALID"
+	CodeFollowNotFound               = "FOLLOW_NOT_FOUND"
+	CodeSelfFollow                   = "SELF_FOLLOW"
+	CodeFollowAlreadyExists          = "FOLLOW_ALREADY_EXISTS"
+	CodeTimelineEmpty                = "TIMELINE_EMPTY"
+	CodeTrendWindowInvalid           = "TREND_WINDOW_INVALID"
+	CodeNotificationUserRequired     = "NOTIFICATION_USER_REQUIRED"
+	CodeRealtimeTokenInvalid         = "REALTIME_TOKEN_INVALID"
+	CodeRealtimeMessageInvalid       = "REALTIME_MESSAGE_INVALID"
+	CodeRealtimeTopicInvalid         = "REALTIME_TOPIC_INVALID"
+	CodeRealtimeConversationRequired = "REALTIME_CONVERSATION_REQUIRED"
+	CodeRealtimeTooManySubscriptions = "REALTIME_TOO_MANY_SUBSCRIPTIONS"
+)
@@ -21,7 +21,9 @@ const (
 
 type AppError struct {
 	Type       ErrorType
+	Code       string
 	Message    string
+	Details    map[string]any
 	Cause      error
 	StatusCode int
 }
@@ -37,6 +39,26 @@ func (e *AppError) Unwrap() error {
 	return e.Cause
 }
 
+// WithCode asigna un código estable y específico del dominio (por ejemplo
+// FOLLOW_ALREADY_EXISTS) que los clientes pueden usar en lugar del mensaje.
+func (e *AppError) WithCode(code string) *AppError {
+	e.Code = code
+	return e
+}
+
+func (e *AppError) WithDetails(details map[string]any) *AppError {
+	e.Details = details
+	return e
+}
+
+// ErrorCode devuelve el código específico si existe, o el tipo de error.
+func (e *AppError) ErrorCode() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return string(e.Type)
+}
+
 func NewAppError(errType ErrorType, message string, cause error) *AppError {
 	return &AppError{
 		Type:       errType,
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type DynamoDBDescriber interface {
+	DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
+}
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+func DynamoDBTable(client DynamoDBDescriber, table string) Checker {
+	return NewCheck("dynamodb:"+table, func(ctx context.Context) error {
+		out, err := client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String(table),
+		})
+		if err != nil {
+			return err
+		}
+		if out.Table != nil && out.Table.TableStatus != types.TableStatusActive &&
+			out.Table.TableStatus != types.TableStatusUpdating {
+			return fmt.Errorf("tabla %s en estado %s", table, out.Table.TableStatus)
+		}
+		return nil
+	})
+}
+
+func Redis(client Pinger) Checker {
+	return NewCheck("redis", client.Ping)
+}
+
+// Staleness falla cuando la última operación exitosa reportada por lastSuccess
+// es más antigua que maxAge, por ejemplo el último poll de un consumer.
+func Staleness(name string, lastSuccess func() time.Time, maxAge time.Duration) Checker {
+	return NewCheck(name, func(ctx context.Context) error {
+		last := lastSuccess()
+		if last.IsZero() {
+			return fmt.Errorf("sin operaciones exitosas registradas")
+		}
+		if age := time.Since(last); age > maxAge {
+			return fmt.Errorf("última operación exitosa hace %s (máximo %s)", age.Truncate(time.Second), maxAge)
+		}
+		return nil
+	})
+}
+
+type ResourcePinger interface {
+	Ping(ctx context.Context, resource string) error
+}
+
+// Broker verifica un tópico o una suscripción del broker de mensajes.
+func Broker(client ResourcePinger, name string) Checker {
+	return NewCheck("broker:"+name, func(ctx context.Context) error {
+		return client.Ping(ctx, name)
+	})
+}
+
+type ContextPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// SQL verifica la conexión con la base relacional.
+func SQL(db ContextPinger) Checker {
+	return NewCheck("sql", db.PingContext)
+}
@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK          = "ok"
+	StatusError       = "error"
+	StatusUnavailable = "unavailable"
+)
+
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type check struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c check) Name() string {
+	return c.name
+}
+
+func (c check) Check(ctx context.Context) error {
+	return c.fn(ctx)
+}
+
+func NewCheck(name string, fn func(ctx context.Context) error) Checker {
+	return check{name: name, fn: fn}
+}
+
+type CheckResult struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type Registry struct {
+	checks  []Checker
+	timeout time.Duration
+}
+
+func NewRegistry(timeout time.Duration, checks ...Checker) *Registry {
+	return &Registry{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+func (r *Registry) Add(checks ...Checker) {
+	r.checks = append(r.checks, checks...)
+}
+
+// Run ejecuta todos los checks en paralelo, cada uno con su propio timeout,
+// y devuelve el estado individual de cada dependencia.
+func (r *Registry) Run(ctx context.Context) Report {
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckResult, len(r.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range r.checks {
+		wg.Add(1)
+		go func(c Checker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := runCheck(checkCtx, c)
+			result := CheckResult{
+				Status:    StatusOK,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				result.Status = StatusError
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[c.Name()] = result
+			if err != nil {
+				report.Status = StatusUnavailable
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func runCheck(ctx context.Context, c Checker) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusOK})
+	})
+}
+
+func ReadinessHandler(registry *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := registry.Run(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
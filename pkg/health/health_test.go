@@ -0,0 +1,129 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/pkg/health"
+)
+
+func ok(context.Context) error { return nil }
+
+// blocked no respeta la cancelación, como un cliente colgado.
+func blocked(context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []health.Checker
+		status int
+		want   map[string]string
+	}{
+		{
+			name:   "todo ok",
+			checks: []health.Checker{health.NewCheck("redis", ok), health.NewCheck("dynamodb:tweets", ok)},
+			status: http.StatusOK,
+			want:   map[string]string{"redis": health.StatusOK, "dynamodb:tweets": health.StatusOK},
+		},
+		{
+			name: "una dependencia falla",
+			checks: []health.Checker{
+				health.NewCheck("redis", func(context.Context) error { return errors.New("connection refused") }),
+				health.NewCheck("dynamodb:tweets", ok),
+			},
+			status: http.StatusServiceUnavailable,
+			want:   map[string]string{"redis": health.StatusError, "dynamodb:tweets": health.StatusOK},
+		},
+		{
+			name:   "check que supera el timeout",
+			checks: []health.Checker{health.NewCheck("redis", ok), health.NewCheck("broker:tweets", blocked)},
+			status: http.StatusServiceUnavailable,
+			want:   map[string]string{"redis": health.StatusOK, "broker:tweets": health.StatusError},
+		},
+		{
+			name: "worker sin poll reciente",
+			checks: []health.Checker{
+				health.Staleness("tweets_poll", func() time.Time { return time.Now().Add(-3 * time.Minute) }, 2*time.Minute),
+			},
+			status: http.StatusServiceUnavailable,
+			want:   map[string]string{"tweets_poll": health.StatusError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := health.NewRegistry(50*time.Millisecond, tt.checks...)
+			recorder := httptest.NewRecorder()
+
+			start := time.Now()
+			health.ReadinessHandler(registry).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			assert.Less(t, time.Since(start), 500*time.Millisecond, "un check colgado no demora la respuesta")
+
+			assert.Equal(t, tt.status, recorder.Code)
+			var report health.Report
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+			require.Len(t, report.Checks, len(tt.want))
+			for name, status := range tt.want {
+				assert.Equal(t, status, report.Checks[name].Status, name)
+				if status == health.StatusError {
+					assert.NotEmpty(t, report.Checks[name].Error, name)
+				}
+			}
+			if tt.status == http.StatusOK {
+				assert.Equal(t, health.StatusOK, report.Status)
+			} else {
+				assert.Equal(t, health.StatusUnavailable, report.Status)
+			}
+		})
+	}
+}
+
+func TestRegistry_RunTimeoutReportsDeadline(t *testing.T) {
+	registry := health.NewRegistry(20*time.Millisecond, health.NewCheck("slow", blocked))
+
+	report := registry.Run(context.Background())
+
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+}
+
+func TestStaleness(t *testing.T) {
+	tests := []struct {
+		name    string
+		last    time.Time
+		healthy bool
+	}{
+		{name: "poll reciente", last: time.Now().Add(-time.Second), healthy: true},
+		{name: "poll viejo", last: time.Now().Add(-3 * time.Minute), healthy: false},
+		{name: "sin polls", last: time.Time{}, healthy: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := health.Staleness("worker_poll", func() time.Time { return tt.last }, 2*time.Minute)
+
+			err := check.Check(context.Background())
+			assert.Equal(t, "worker_poll", check.Name())
+			assert.Equal(t, tt.healthy, err == nil)
+		})
+	}
+}
+
+func TestLivenessHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	health.LivenessHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+)
+
+var catalog = map[string]map[string]string{
+	Spanish: {
+		apperrors.CodeInvalidRequestBody:           "No se pudo deserializar el request",
+		apperrors.CodeInvalidCursor:                "El cursor de paginación no es válido",
+		apperrors.CodeInvalidLimit:                 "El parámetro limit debe ser un entero positivo",
+		apperrors.CodeInvalidQueryParam:            "El parámetro {param} no es válido",
+		apperrors.CodeTweetNotFound:                "El tweet {tweet_id} no existe",
+		apperrors.CodeTweetContentEmpty:            "El contenido del tweet no puede estar vacío",
+		apperrors.CodeTweetContentTooLong:          "El contenido del tweet excede el máximo de {max} caracteres",
+		apperrors.CodeReplyNotFound:                "El tweet {tweet_id} al que se responde no existe",
+		apperrors.CodeHashtagInvalid:               "El hashtag {tag} no es válido",
+		apperrors.CodeFollowIDInvalid:              "Formato de ID de follow inválido: {follow_id}",
+		apperrors.CodeFollowNotFound:               "El follow {follow_id} no existe",
+		apperrors.CodeSelfFollow:                   "Un usuario no puede seguirse a sí mismo",
+		apperrors.CodeFollowAlreadyExists:          "El usuario ya sigue a este usuario",
+		apperrors.CodeTimelineEmpty:                "El timeline está vacío, se solicitó su reconstrucción",
+		apperrors.CodeTrendWindowInvalid:           "La ventana {window} no es válida, valores permitidos: {allowed}",
+		apperrors.CodeNotificationUserRequired:     "El parámetro user_id es obligatorio",
+		apperrors.CodeRealtimeTokenInvalid:         "El token de la conexión no es válido o expiró",
+		apperrors.CodeRealtimeMessageInvalid:       "El mensaje no es válido",
+		apperrors.CodeRealtimeTopicInvalid:         "El tópico {topic} no existe, valores permitidos: {allowed}",
+		apperrors.CodeRealtimeConversationRequired: "Para suscribirse a una conversación hay que indicar el id del tweet",
+		apperrors.CodeRealtimeTooManySubscriptions: "Se alcanzó el máximo de {max} suscripciones por conexión",
+
+		string(apperrors.ErrorTypeNotFound):      "Recurso no encontrado",
+		string(apperrors.ErrorTypeInvalidInput):  "Los datos enviados no son válidos",
+		string(apperrors.ErrorTypeUnauthorized):  "No autorizado",
+		string(apperrors.ErrorTypeForbidden):     "Acceso denegado",
+		string(apperrors.ErrorTypeAlreadyExists): "El recurso ya existe",
+		string(apperrors.ErrorTypeUnavailable):   "Servicio no disponible, intente nuevamente más tarde",
+		string(apperrors.ErrorTypeInternal):      "Error interno del servidor",
+		string(apperrors.ErrorTypeInternalError): "Error interno del servidor",
+	},
+	English: {
+		apperrors.CodeInvalidRequestBody:           "The request body could not be parsed",
+		apperrors.CodeInvalidCursor:                "The pagination cursor is not valid",
+		apperrors.CodeInvalidLimit:                 "The limit parameter must be a positive integer",
+		apperrors.CodeInvalidQueryParam:            "The {param} parameter is invalid",
+		apperrors.CodeTweetNotFound:                "Tweet {tweet_id} does not exist",
+		apperrors.CodeTweetContentEmpty:            "Tweet content cannot be empty",
+		apperrors.CodeTweetContentTooLong:          "Tweet content exceeds the maximum of {max} characters",
+		apperrors.CodeReplyNotFound:                "The tweet {tweet_id} being replied to does not exist",
+		apperrors.CodeHashtagInvalid:               "Hashtag {tag} is not valid",
+		apperrors.CodeFollowIDInvalid:              "Invalid follow ID format: {follow_id}",
+		apperrors.CodeFollowNotFound:               "Follow {follow_id} does not exist",
+		apperrors.CodeSelfFollow:                   "Users cannot follow themselves",
+		apperrors.CodeFollowAlreadyExists:          "You already follow this user",
+		apperrors.CodeTimelineEmpty:                "The timeline is empty and is being rebuilt",
+		apperrors.CodeTrendWindowInvalid:           "Window {window} is not valid, allowed values: {allowed}",
+		apperrors.CodeNotificationUserRequired:     "The user_id parameter is required",
+		apperrors.CodeRealtimeTokenInvalid:         "The connection token is invalid or expired",
+		apperrors.CodeRealtimeMessageInvalid:       "The message is invalid",
+		apperrors.CodeRealtimeTopicInvalid:         "Topic {topic} does not exist, allowed values: {allowed}",
+		apperrors.CodeRealtimeConversationRequired: "Subscribing to a conversation requires the tweet id",
+		apperrors.CodeRealtimeTooManySubscriptions: "Reached the maximum of {max} subscriptions per connection",
+
+		string(apperrors.ErrorTypeNotFound):      "Resource not found",
+		string(apperrors.ErrorTypeInvalidInput):  "The submitted data is not valid",
+		string(apperrors.ErrorTypeUnauthorized):  "Unauthorized",
+		string(apperrors.ErrorTypeForbidden):     "Access denied",
+		string(apperrors.ErrorTypeAlreadyExists): "The resource already exists",
+		string(apperrors.ErrorTypeUnavailable):   "Service unavailable, please try again later",
+		string(apperrors.ErrorTypeInternal):      "Internal server error",
+		string(apperrors.ErrorTypeInternalError): "Internal server error",
+	},
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+const (
+	Spanish = "es"
+	English = "en"
+
+	DefaultLanguage = Spanish
+)
+
+// El primer tag es el que se usa cuando Accept-Language no coincide con
+// ningún idioma soportado.
+var matcher = language.NewMatcher([]language.Tag{
+	language.Spanish,
+	language.English,
+})
+
+// FromAcceptLanguage devuelve el idioma soportado que mejor coincide con el
+// header Accept-Language, o DefaultLanguage si no hay coincidencia.
+func FromAcceptLanguage(header string) string {
+	if header == "" {
+		return DefaultLanguage
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
+	}
+	if index == 1 {
+		return English
+	}
+	return Spanish
+}
+
+// DefaultCode es el mensaje que se devuelve para un código que no está en el
+// catálogo.
+const DefaultCode = string(apperrors.ErrorTypeInternalError)
+
+// Message devuelve el texto del catálogo para el código en el idioma pedido,
+// reemplazando los placeholders {clave} con los valores de params. Para un
+// código desconocido devuelve el mensaje de DefaultCode y false, así quien
+// tenga un texto propio puede preferirlo.
+func Message(lang, code string, params map[string]any) (string, bool) {
+	messages, ok := catalog[lang]
+	if !ok {
+		messages = catalog[DefaultLanguage]
+	}
+
+	message, ok := messages[code]
+	if !ok {
+		return messages[DefaultCode], false
+	}
+
+	for key, value := range params {
+		message = strings.ReplaceAll(message, "{"+key+"}", fmt.Sprint(value))
+	}
+	return message, true
+}
@@ -0,0 +1,89 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/i18n"
+)
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "en-US,en;q=0.9", want: i18n.English},
+		{header: "es-AR,es;q=0.9,en;q=0.8", want: i18n.Spanish},
+		{header: "fr", want: i18n.Spanish},
+		{header: "", want: i18n.Spanish},
+		{header: ";;;q=abc", want: i18n.Spanish},
+		{header: "en;q=nope", want: i18n.Spanish},
+		{header: "es;q=0.1,en;q=0.8", want: i18n.English},
+		{header: "fr-FR,en;q=0.5", want: i18n.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, i18n.FromAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   string
+		code   string
+		params map[string]any
+		want   string
+		found  bool
+	}{
+		{
+			name:   "reemplaza placeholders",
+			lang:   i18n.Spanish,
+			code:   apperrors.CodeTweetContentTooLong,
+			params: map[string]any{"max": 280},
+			want:   "El contenido del tweet excede el máximo de 280 caracteres",
+			found:  true,
+		},
+		{
+			name:   "en inglés",
+			lang:   i18n.English,
+			code:   apperrors.CodeTweetNotFound,
+			params: map[string]any{"tweet_id": "twt-1"},
+			want:   "Tweet twt-1 does not exist",
+			found:  true,
+		},
+		{
+			name:  "idioma desconocido usa el por defecto",
+			lang:  "fr",
+			code:  apperrors.CodeSelfFollow,
+			want:  "Un usuario no puede seguirse a sí mismo",
+			found: true,
+		},
+		{
+			name:  "código desconocido",
+			lang:  i18n.Spanish,
+			code:  "UNKNOWN_CODE",
+			want:  "Error interno del servidor",
+			found: false,
+		},
+		{
+			name:  "código desconocido en inglés",
+			lang:  i18n.English,
+			code:  "UNKNOWN_CODE",
+			want:  "Internal server error",
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, found := i18n.Message(tt.lang, tt.code, tt.params)
+			assert.Equal(t, tt.want, message)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package localcache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"go.uber.org/zap"
+)
+
+// InvalidationChannel es el canal de Redis por el que las instancias avisan
+// que un timeline o un tweet cambió y hay que descartarlo de los cachés en
+// memoria de todos los procesos.
+const InvalidationChannel = "cache:invalidate"
+
+const (
+	KindTimeline = "timeline"
+	KindTweet    = "tweet"
+)
+
+const resubscribeDelay = time.Second
+
+// Invalidation identifica la entrada a descartar: Key es el ID del usuario
+// para KindTimeline y el del tweet para KindTweet.
+type Invalidation struct {
+	Kind string `json:"kind"`
+	Key  string `json:"key"`
+}
+
+// Deleter es el caché que se limpia al recibir una invalidación.
+type Deleter interface {
+	Delete(keys ...string)
+}
+
+// PubSub es el canal compartido por las instancias para repartir las
+// invalidaciones.
+type PubSub interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	PSubscribe(ctx context.Context, patterns ...string) (<-chan redisadapter.Message, error)
+}
+
+type Logger interface {
+	Warn(msg string, fields ...zap.Field)
+}
+
+// Invalidator reparte las invalidaciones entre los cachés en memoria que se le
+// registran. Cada proceso arma el suyo y lo comparte entre los repositorios.
+type Invalidator struct {
+	pubsub PubSub
+
+	mu     sync.RWMutex
+	caches map[string][]Deleter
+}
+
+func NewInvalidator(pubsub PubSub) *Invalidator {
+	return &Invalidator{
+		pubsub: pubsub,
+		caches: make(map[string][]Deleter),
+	}
+}
+
+// Register asocia un caché con un tipo de invalidación.
+func (i *Invalidator) Register(kind string, cache Deleter) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.caches[kind] = append(i.caches[kind], cache)
+}
+
+// Invalidate descarta las claves de los cachés registrados.
+func (i *Invalidator) Invalidate(kind string, keys ...string) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	for _, cache := range i.caches[kind] {
+		cache.Delete(keys...)
+	}
+}
+
+// Publish descarta la clave en este proceso y avisa al resto por Redis.
+func (i *Invalidator) Publish(ctx context.Context, kind, key string) error {
+	i.Invalidate(kind, key)
+
+	payload, err := json.Marshal(Invalidation{Kind: kind, Key: key})
+	if err != nil {
+		return err
+	}
+	return i.pubsub.Publish(ctx, InvalidationChannel, payload)
+}
+
+// Run aplica las invalidaciones publicadas por otras instancias hasta que se
+// cancele ctx, volviendo a suscribirse si la suscripción se corta. Mientras no
+// hay suscripción las entradas sólo vencen por TTL.
+func (i *Invalidator) Run(ctx context.Context, logger Logger) {
+	for ctx.Err() == nil {
+		messages, err := i.pubsub.PSubscribe(ctx, InvalidationChannel)
+		if err != nil {
+			logger.Warn("Error suscribiendo a invalidaciones de caché, reintentando", zap.Error(err))
+		} else {
+			for msg := range messages {
+				var inv Invalidation
+				if err := json.Unmarshal(msg.Payload, &inv); err != nil {
+					logger.Warn("Invalidación de caché inválida", zap.Error(err))
+					continue
+				}
+				i.Invalidate(inv.Kind, inv.Key)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
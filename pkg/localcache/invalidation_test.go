@@ -0,0 +1,147 @@
+package localcache_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	redisadapter "github.com/juanmalvarez3/twit/internal/adapters/redis"
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Warn(string, ...zap.Field) {}
+
+// fakePubSub entrega cada Publish a las suscripciones abiertas, como el
+// pub/sub de Redis: lo publicado sin suscriptores se pierde.
+type fakePubSub struct {
+	mu   sync.Mutex
+	subs []chan redisadapter.Message
+}
+
+func (f *fakePubSub) Publish(_ context.Context, channel string, payload []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, sub := range f.subs {
+		sub <- redisadapter.Message{Channel: channel, Payload: payload}
+	}
+	return nil
+}
+
+func (f *fakePubSub) PSubscribe(ctx context.Context, _ ...string) (<-chan redisadapter.Message, error) {
+	sub := make(chan redisadapter.Message, 16)
+	f.mu.Lock()
+	f.subs = append(f.subs, sub)
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, s := range f.subs {
+			if s == sub {
+				f.subs = append(f.subs[:i], f.subs[i+1:]...)
+				break
+			}
+		}
+		close(sub)
+	}()
+	return sub, nil
+}
+
+func TestRun_AppliesInvalidationsFromOtherInstances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pubsub := &fakePubSub{}
+	invalidator := localcache.NewInvalidator(pubsub)
+	timelines := localcache.New[string](10, time.Minute)
+	invalidator.Register(localcache.KindTimeline, timelines)
+	timelines.Set("user-1", "timeline")
+	timelines.Set("user-2", "timeline")
+
+	done := make(chan struct{})
+	go func() {
+		invalidator.Run(ctx, nopLogger{})
+		close(done)
+	}()
+
+	payload, err := json.Marshal(localcache.Invalidation{Kind: localcache.KindTimeline, Key: "user-1"})
+	require.NoError(t, err)
+
+	// Publish no espera a que haya suscriptores: se repite hasta que Run esté
+	// escuchando.
+	assert.Eventually(t, func() bool {
+		if err := pubsub.Publish(ctx, localcache.InvalidationChannel, payload); err != nil {
+			return false
+		}
+		_, ok := timelines.Get("user-1")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	_, ok := timelines.Get("user-2")
+	assert.True(t, ok, "sólo se descarta la clave invalidada")
+
+	// Un mensaje malformado o de otro tipo no afecta al caché.
+	require.NoError(t, pubsub.Publish(ctx, localcache.InvalidationChannel, []byte("{")))
+	require.NoError(t, pubsub.Publish(ctx, localcache.InvalidationChannel,
+		[]byte(`{"kind":"tweet","key":"user-2"}`)))
+	time.Sleep(20 * time.Millisecond)
+	_, ok = timelines.Get("user-2")
+	assert.True(t, ok)
+
+	cancel()
+	<-done
+}
+
+func TestPublish_InvalidatesLocallyAndNotifies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pubsub := &fakePubSub{}
+	messages, err := pubsub.PSubscribe(ctx, localcache.InvalidationChannel)
+	require.NoError(t, err)
+
+	invalidator := localcache.NewInvalidator(pubsub)
+	tweets := localcache.New[string](10, time.Minute)
+	invalidator.Register(localcache.KindTweet, tweets)
+	tweets.Set("twt-1", "tweet")
+
+	require.NoError(t, invalidator.Publish(ctx, localcache.KindTweet, "twt-1"))
+
+	_, ok := tweets.Get("twt-1")
+	assert.False(t, ok)
+	select {
+	case msg := <-messages:
+		assert.JSONEq(t, `{"kind":"tweet","key":"twt-1"}`, string(msg.Payload))
+	case <-time.After(time.Second):
+		t.Fatal("no se publicó la invalidación")
+	}
+}
+
+// Cada Invalidator sólo limpia los cachés que se le registraron, así dos
+// aplicaciones armadas en el mismo proceso no comparten cachés.
+func TestInvalidator_KeepsCachesSeparate(t *testing.T) {
+	first := localcache.NewInvalidator(&fakePubSub{})
+	second := localcache.NewInvalidator(&fakePubSub{})
+	firstCache := localcache.New[string](10, time.Minute)
+	secondCache := localcache.New[string](10, time.Minute)
+	first.Register(localcache.KindTimeline, firstCache)
+	second.Register(localcache.KindTimeline, secondCache)
+	firstCache.Set("user-1", "timeline")
+	secondCache.Set("user-1", "timeline")
+
+	first.Invalidate(localcache.KindTimeline, "user-1")
+
+	_, ok := firstCache.Get("user-1")
+	assert.False(t, ok)
+	_, ok = secondCache.Get("user-1")
+	assert.True(t, ok)
+}
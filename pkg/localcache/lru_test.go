@@ -0,0 +1,72 @@
+package localcache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/juanmalvarez3/twit/pkg/localcache"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := localcache.New[string](2, time.Minute)
+
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	cache.Set("c", "3")
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok, "b es la menos usada y se descarta al llenarse")
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", value)
+	value, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, "3", value)
+}
+
+func TestLRU_SetRefreshesExistingKey(t *testing.T) {
+	cache := localcache.New[string](2, time.Minute)
+
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	cache.Set("a", "updated")
+	cache.Set("c", "3")
+
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "updated", value)
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+}
+
+func TestLRU_EntriesExpire(t *testing.T) {
+	cache := localcache.New[string](10, 30*time.Millisecond)
+	cache.Set("a", "1")
+
+	_, ok := cache.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(40 * time.Millisecond)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Zero(t, cache.Len(), "la entrada vencida se descarta al leerla")
+}
+
+func TestLRU_Delete(t *testing.T) {
+	cache := localcache.New[string](10, time.Minute)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+
+	cache.Delete("a", "missing")
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	_, ok = cache.Get("b")
+	assert.True(t, ok)
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// AppendAWSMiddlewares registra un contador de errores por servicio y
+// operación en todas las llamadas del SDK de AWS.
+func AppendAWSMiddlewares(apiOptions *[]func(*middleware.Stack) error) {
+	*apiOptions = append(*apiOptions, func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("TwitDependencyErrors",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+				out, metadata, err := next.HandleInitialize(ctx, in)
+				if err != nil {
+					DependencyError(
+						strings.ToLower(awsmiddleware.GetServiceID(ctx)),
+						awsmiddleware.GetOperationName(ctx),
+					)
+				}
+				return out, metadata, err
+			}), middleware.After)
+	})
+}
@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "twit"
+
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+
+	CacheTierL1 = "l1"
+	CacheTierL2 = "l2"
+
+	CacheTimeline   = "timeline"
+	CacheTweet      = "tweet"
+	CacheUserTweets = "user_tweets"
+
+	StageReceive = "receive"
+	StageHandle  = "handle"
+	StageDelete  = "delete"
+
+	ResultSuccess = "success"
+	ResultError   = "error"
+
+	DependencyDynamoDB = "dynamodb"
+	DependencyRedis    = "redis"
+)
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latencia de las peticiones HTTP por ruta.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TimelineCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "timeline",
+		Name:      "cache_requests_total",
+		Help:      "Lecturas de timeline resueltas desde caché (hit) o base de datos (miss).",
+	}, []string{"result"})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Lecturas por caché y nivel (l1 en memoria del proceso, l2 Redis) resueltas con hit o miss.",
+	}, []string{"cache", "tier", "result"})
+
+	FanoutSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "timeline",
+		Name:      "fanout_size",
+		Help:      "Cantidad de seguidores a los que se distribuye cada tweet.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	})
+
+	QueueMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "messages_total",
+		Help:      "Mensajes recibidos, procesados y eliminados por cola.",
+	}, []string{"queue", "stage", "result"})
+
+	QueueDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "queue",
+		Name:      "operation_duration_seconds",
+		Help:      "Latencia de recepción, procesamiento y eliminación de mensajes por cola.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"queue", "stage"})
+
+	WorkerRestarts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "restarts_total",
+		Help:      "Reinicios de workers tras un pánico o un error.",
+	}, []string{"worker"})
+
+	DependencyErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "dependency",
+		Name:      "errors_total",
+		Help:      "Errores devueltos por dependencias externas.",
+	}, []string{"dependency", "operation"})
+)
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveCache registra una lectura del caché de timelines en Redis.
+func ObserveCache(hit bool) {
+	ObserveCacheTier(CacheTimeline, CacheTierL2, hit)
+	if hit {
+		TimelineCacheRequests.WithLabelValues(CacheHit).Inc()
+		return
+	}
+	TimelineCacheRequests.WithLabelValues(CacheMiss).Inc()
+}
+
+func ObserveCacheTier(cache, tier string, hit bool) {
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+	CacheRequests.WithLabelValues(cache, tier, result).Inc()
+}
+
+// ObserveQueue registra una operación sobre count mensajes. Una operación
+// fallida cuenta al menos como un error aunque no haya devuelto mensajes,
+// para que los Receive fallidos se vean en twit_queue_messages_total.
+func ObserveQueue(queue, stage string, start time.Time, count int, err error) {
+	QueueDuration.WithLabelValues(queue, stage).Observe(time.Since(start).Seconds())
+
+	result := ResultSuccess
+	if err != nil {
+		result = ResultError
+		count = max(count, 1)
+	}
+	QueueMessages.WithLabelValues(queue, stage, result).Add(float64(count))
+}
+
+func DependencyError(dependency, operation string) {
+	DependencyErrors.WithLabelValues(dependency, operation).Inc()
+}
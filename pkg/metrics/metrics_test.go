@@ -0,0 +1,37 @@
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/juanmalvarez3/twit/pkg/metrics"
+)
+
+func TestObserveQueue(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int
+		err    error
+		result string
+		want   float64
+	}{
+		{name: "éxito cuenta los mensajes", count: 3, result: metrics.ResultSuccess, want: 3},
+		{name: "Receive vacío no cuenta", count: 0, result: metrics.ResultSuccess, want: 0},
+		{name: "error sin mensajes cuenta uno", count: 0, err: errors.New("boom"), result: metrics.ResultError, want: 1},
+		{name: "error de un lote cuenta el lote", count: 4, err: errors.New("boom"), result: metrics.ResultError, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := metrics.QueueMessages.WithLabelValues("observe-"+tt.name, metrics.StageReceive, tt.result)
+
+			metrics.ObserveQueue("observe-"+tt.name, metrics.StageReceive, time.Now(), tt.count, tt.err)
+
+			assert.Equal(t, tt.want, testutil.ToFloat64(counter))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apperrors "github.com/juanmalvarez3/twit/pkg/errors"
+	"github.com/juanmalvarez3/twit/pkg/i18n"
+	"github.com/juanmalvarez3/twit/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type ErrorResponse struct {
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details"`
+	RequestID string         `json:"request_id"`
+}
+
+// ErrorHandler transforma el último error registrado con c.Error en una
+// respuesta JSON con el status del AppError. Los errores que no son AppError
+// se responden como 500 sin exponer la causa. El mensaje se traduce según
+// Accept-Language a partir del código del error.
+func ErrorHandler(log logger.LoggerInterface) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		response := ErrorResponse{
+			Code:      string(apperrors.ErrorTypeInternalError),
+			Message:   "Error interno del servidor",
+			Details:   map[string]any{},
+			RequestID: GetRequestID(c),
+		}
+		status := http.StatusInternalServerError
+
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			status = appErr.StatusCode
+			response.Code = appErr.ErrorCode()
+			response.Message = appErr.Message
+			if appErr.Details != nil {
+				response.Details = appErr.Details
+			}
+		}
+
+		lang := i18n.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+		if message, ok := i18n.Message(lang, response.Code, response.Details); ok {
+			response.Message = message
+		}
+		c.Header("Content-Language", lang)
+
+		fields := []zap.Field{
+			zap.String("request_id", response.RequestID),
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", status),
+			zap.String("code", response.Code),
+			zap.Error(err),
+		}
+		if status >= http.StatusInternalServerError {
+			log.Error("Error procesando request", fields...)
+		} else {
+			log.Warn("Request rechazado", fields...)
+		}
+
+		c.JSON(status, response)
+	}
+}
@@ -0,0 +1,171 @@
+package sqldb
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Las migraciones de cada dialecto viven en migrations/<driver> y se llaman
+// <versión>_<nombre>.sql. Los dos dialectos tienen que tener las mismas
+// versiones.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migrationLockID es la clave del advisory lock de PostgreSQL con el que dos
+// procesos que arrancan a la vez no aplican la misma migración.
+const migrationLockID = 7_340_201_901
+
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Migrations devuelve las migraciones del driver ordenadas por versión.
+func Migrations(driver string) ([]Migration, error) {
+	dir := path.Join("migrations", driver)
+	files, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("sin migraciones para el driver %q: %w", driver, err)
+	}
+
+	migrations := make([]Migration, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), ".sql")
+		if file.IsDir() || name == file.Name() {
+			continue
+		}
+		prefix, label, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("nombre de migración inválido: %s", file.Name())
+		}
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: label, SQL: string(content)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrate aplica, cada una en su transacción, las migraciones que todavía no
+// figuran en schema_migrations. Devuelve las versiones aplicadas.
+func Migrate(ctx context.Context, db *DB) ([]int, error) {
+	migrations, err := Migrations(db.driver)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.InTx(ctx, func(tx *Tx) error {
+		if err := tx.lock(ctx); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TEXT NOT NULL
+		)`)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creando schema_migrations: %w", err)
+	}
+
+	var applied []int
+	for _, migration := range migrations {
+		var done bool
+		err := db.InTx(ctx, func(tx *Tx) error {
+			if err := tx.lock(ctx); err != nil {
+				return err
+			}
+			var count int
+			if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM schema_migrations WHERE version = ?",
+				migration.Version).Scan(&count); err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			if _, err := tx.ExecContext(ctx, migration.SQL); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now().UTC().Format(time.RFC3339))
+			done = err == nil
+			return err
+		})
+		if err != nil {
+			return applied, fmt.Errorf("error aplicando la migración %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			applied = append(applied, migration.Version)
+		}
+	}
+	return applied, nil
+}
+
+// Pending devuelve las versiones que todavía no se aplicaron, sin modificar
+// la base.
+func Pending(ctx context.Context, db *DB) ([]int, error) {
+	migrations, err := Migrations(db.driver)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'"
+	if db.driver == DriverPostgres {
+		exists = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'schema_migrations'"
+	}
+	var count int
+	if err := db.QueryRowContext(ctx, exists).Scan(&count); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]bool)
+	if count > 0 {
+		rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var version int
+			if err := rows.Scan(&version); err != nil {
+				return nil, err
+			}
+			applied[version] = true
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var pending []int
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration.Version)
+		}
+	}
+	return pending, nil
+}
+
+// lock toma el advisory lock de migraciones hasta el fin de la transacción.
+// SQLite no lo necesita: sus escrituras ya son de a una.
+func (tx *Tx) lock(ctx context.Context) error {
+	if tx.db.driver != DriverPostgres {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(?)", migrationLockID)
+	return err
+}
@@ -0,0 +1,64 @@
+-- Las claves de orden se comparan byte a byte (COLLATE "C"), como las claves
+-- de DynamoDB: la paginación por keyset depende de que el orden de la base y
+-- el de los cursores coincidan.
+
+CREATE TABLE tweets (
+    id               TEXT COLLATE "C" PRIMARY KEY,
+    user_id          TEXT NOT NULL,
+    content          TEXT NOT NULL,
+    created_at       TEXT COLLATE "C" NOT NULL,
+    hashtags         TEXT NOT NULL DEFAULT '[]',
+    mentions         TEXT NOT NULL DEFAULT '[]',
+    reply_to_id      TEXT NOT NULL DEFAULT '',
+    reply_to_user_id TEXT NOT NULL DEFAULT '',
+    retweet_of_id    TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX tweets_user_created_at ON tweets (user_id, created_at DESC, id DESC);
+
+CREATE TABLE tweet_hashtags (
+    tag        TEXT NOT NULL,
+    created_at TEXT COLLATE "C" NOT NULL,
+    tweet_id   TEXT COLLATE "C" NOT NULL,
+    PRIMARY KEY (tag, created_at, tweet_id)
+);
+
+CREATE INDEX tweet_hashtags_tweet ON tweet_hashtags (tweet_id);
+
+CREATE TABLE follows (
+    follower_id TEXT COLLATE "C" NOT NULL,
+    followed_id TEXT COLLATE "C" NOT NULL,
+    id          TEXT NOT NULL,
+    created_at  TEXT NOT NULL,
+    PRIMARY KEY (follower_id, followed_id)
+);
+
+CREATE INDEX follows_followed ON follows (followed_id, follower_id);
+
+CREATE TABLE timeline_entries (
+    user_id    TEXT NOT NULL,
+    sort_key   TEXT COLLATE "C" NOT NULL,
+    tweet_id   TEXT NOT NULL,
+    author_id  TEXT NOT NULL,
+    content    TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL,
+    expires_at BIGINT,
+    PRIMARY KEY (user_id, sort_key)
+);
+
+CREATE TABLE timeline_pending_trims (
+    user_id TEXT PRIMARY KEY
+);
+
+CREATE TABLE notifications (
+    recipient_id TEXT NOT NULL,
+    id           TEXT COLLATE "C" NOT NULL,
+    type         TEXT NOT NULL,
+    actor_id     TEXT NOT NULL,
+    tweet_id     TEXT NOT NULL DEFAULT '',
+    created_at   TEXT NOT NULL,
+    is_read      BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (recipient_id, id)
+);
+
+CREATE INDEX notifications_unread ON notifications (recipient_id) WHERE NOT is_read;
@@ -0,0 +1,142 @@
+// Package sqldb abre la base relacional del backend de almacenamiento SQL y
+// aplica sus migraciones. Las consultas se escriben con ? como marcador y DB
+// las reescribe a $1, $2... cuando el driver es PostgreSQL.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// Driver de PostgreSQL. SQLite necesita cgo, así que sólo lo importan las
+	// pruebas.
+	_ "github.com/lib/pq"
+)
+
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
+// DB es un *sql.DB que conoce el dialecto de su driver.
+type DB struct {
+	*sql.DB
+	driver string
+}
+
+// Open abre la base y verifica la conexión. SQLite admite un solo escritor,
+// así que con ese driver el pool queda en una conexión.
+func Open(ctx context.Context, driver, dsn string, maxOpenConns int) (*DB, error) {
+	if driver != DriverPostgres && driver != DriverSQLite {
+		return nil, fmt.Errorf("driver SQL no soportado: %q", driver)
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if driver == DriverSQLite {
+		maxOpenConns = 1
+	}
+	if maxOpenConns > 0 {
+		conn.SetMaxOpenConns(maxOpenConns)
+		conn.SetMaxIdleConns(maxOpenConns)
+	}
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &DB{DB: conn, driver: driver}, nil
+}
+
+func (db *DB) Driver() string {
+	return db.driver
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, db.rebind(query), args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, db.rebind(query), args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.DB.QueryRowContext(ctx, db.rebind(query), args...)
+}
+
+// InTx corre fn dentro de una transacción: la confirma si fn no devuelve
+// error y la descarta si no.
+func (db *DB) InTx(ctx context.Context, fn func(tx *Tx) error) error {
+	sqlTx, err := db.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(&Tx{Tx: sqlTx, db: db}); err != nil {
+		sqlTx.Rollback()
+		return err
+	}
+	return sqlTx.Commit()
+}
+
+// Tx es una transacción con la misma reescritura de marcadores que DB.
+type Tx struct {
+	*sql.Tx
+	db *DB
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.db.rebind(query), args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.db.rebind(query), args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.db.rebind(query), args...)
+}
+
+func (db *DB) rebind(query string) string {
+	if db.driver != DriverPostgres {
+		return query
+	}
+	return Rebind(query)
+}
+
+// Rebind reemplaza cada ? fuera de un literal entre comillas simples por
+// $1, $2..., los marcadores de PostgreSQL.
+func Rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Placeholders devuelve n marcadores separados por coma, para las listas de
+// un IN.
+func Placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("?, ", n-1) + "?"
+}
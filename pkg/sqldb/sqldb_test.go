@@ -0,0 +1,66 @@
+package sqldb_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/juanmalvarez3/twit/pkg/sqldb"
+)
+
+func TestRebind(t *testing.T) {
+	assert.Equal(t, "SELECT 1", sqldb.Rebind("SELECT 1"))
+	assert.Equal(t, "SELECT * FROM t WHERE a = $1 AND b IN ($2, $3)",
+		sqldb.Rebind("SELECT * FROM t WHERE a = ? AND b IN ("+sqldb.Placeholders(2)+")"))
+	assert.Equal(t, "SELECT '?' FROM t WHERE a = $1", sqldb.Rebind("SELECT '?' FROM t WHERE a = ?"))
+}
+
+func TestMigrations_SameVersionsInEveryDialect(t *testing.T) {
+	postgres, err := sqldb.Migrations(sqldb.DriverPostgres)
+	require.NoError(t, err)
+	sqlite, err := sqldb.Migrations(sqldb.DriverSQLite)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, postgres)
+	require.Len(t, sqlite, len(postgres))
+	for i := range postgres {
+		assert.Equal(t, postgres[i].Version, sqlite[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+	}
+}
+
+func TestMigrate_IsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqldb.Open(ctx, sqldb.DriverSQLite, filepath.Join(t.TempDir(), "twit.db"), 0)
+	require.NoError(t, err)
+	defer db.Close()
+
+	pending, err := sqldb.Pending(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, pending)
+
+	applied, err := sqldb.Migrate(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, applied)
+
+	pending, err = sqldb.Pending(ctx, db)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	applied, err = sqldb.Migrate(ctx, db)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	_, err = db.ExecContext(ctx, "INSERT INTO follows (follower_id, followed_id, id, created_at) VALUES (?, ?, ?, ?)",
+		"u1", "u2", "flw-u1-u2", "2026-10-19T10:00:00Z")
+	assert.NoError(t, err)
+}
+
+func TestOpen_RejectsUnknownDriver(t *testing.T) {
+	_, err := sqldb.Open(context.Background(), "mysql", "", 0)
+	assert.Error(t, err)
+}
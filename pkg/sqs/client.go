@@ -0,0 +1,17 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/juanmalvarez3/twit/pkg/awsconfig"
+	pkgConfig "github.com/juanmalvarez3/twit/pkg/config"
+)
+
+func New(ctx context.Context, cfg *pkgConfig.Config) (*sqs.Client, error) {
+	awsCfg, err := awsconfig.Load(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sqs.NewFromConfig(awsCfg), nil
+}
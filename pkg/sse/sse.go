@@ -0,0 +1,49 @@
+package sse
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Headers que necesita una respuesta text/event-stream. X-Accel-Buffering
+// evita que nginx acumule los eventos antes de enviarlos.
+var Headers = map[string]string{
+	"Content-Type":      "text/event-stream",
+	"Cache-Control":     "no-cache",
+	"Connection":        "keep-alive",
+	"X-Accel-Buffering": "no",
+}
+
+// WriteEvent escribe un evento con su id, para que el navegador lo devuelva
+// en Last-Event-ID al reconectar.
+func WriteEvent(w io.Writer, id, event string, data []byte) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteComment escribe una línea de comentario. Los clientes la ignoran, pero
+// mantiene viva la conexión frente a proxies que cortan las inactivas.
+func WriteComment(w io.Writer, comment string) error {
+	_, err := fmt.Fprintf(w, ": %s\n\n", comment)
+	return err
+}
+
+// WriteRetry indica al cliente cuánto esperar antes de reconectar.
+func WriteRetry(w io.Writer, retry time.Duration) error {
+	_, err := fmt.Fprintf(w, "retry: %d\n\n", retry.Milliseconds())
+	return err
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Inject agrega el contexto de traza actual (traceparent/tracestate/baggage)
+// al mapa de atributos de un mensaje.
+func Inject(ctx context.Context, attributes map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(attributes))
+}
+
+// Extract devuelve un contexto hijo del recibido con la traza remota
+// contenida en los atributos del mensaje, si existe.
+func Extract(ctx context.Context, attributes map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(attributes))
+}
@@ -0,0 +1,63 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/juanmalvarez3/twit/pkg/config"
+	"github.com/juanmalvarez3/twit/pkg/tracing"
+)
+
+// setup registra el propagador W3C como al arrancar con el tracing
+// deshabilitado y devuelve un tracer que sí registra spans.
+func setup(t *testing.T) trace.Tracer {
+	t.Helper()
+	shutdown, err := tracing.New(context.Background(), config.TracingConfig{}, "test")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = shutdown(context.Background()) })
+
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider.Tracer("test")
+}
+
+func TestInjectExtract_RoundTrip(t *testing.T) {
+	tracer := setup(t)
+	ctx, span := tracer.Start(context.Background(), "publish")
+	defer span.End()
+
+	attributes := map[string]string{"event_type": "created"}
+	tracing.Inject(ctx, attributes)
+	assert.Contains(t, attributes, "traceparent")
+	assert.Equal(t, "created", attributes["event_type"])
+
+	remote := trace.SpanContextFromContext(tracing.Extract(context.Background(), attributes))
+	assert.True(t, remote.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+
+	// El span del consumidor continúa la traza del publicador.
+	_, consumer := tracer.Start(tracing.Extract(context.Background(), attributes), "consume")
+	defer consumer.End()
+	assert.Equal(t, span.SpanContext().TraceID(), consumer.SpanContext().TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), consumer.(sdktrace.ReadOnlySpan).Parent().SpanID())
+}
+
+func TestExtract_WithoutTraceStartsRootSpan(t *testing.T) {
+	tracer := setup(t)
+
+	for _, attributes := range []map[string]string{nil, {}, {"event_type": "created"}, {"traceparent": "invalid"}} {
+		ctx := tracing.Extract(context.Background(), attributes)
+		assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+
+		_, span := tracer.Start(ctx, "consume")
+		assert.True(t, span.SpanContext().IsValid())
+		assert.False(t, span.(sdktrace.ReadOnlySpan).Parent().IsValid(), "sin traza recibida el span es raíz")
+		span.End()
+	}
+}
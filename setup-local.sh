@@ -14,9 +14,6 @@ if ! command -v docker-compose &> /dev/null; then
     exit 1
 fi
 
-# Asegurar que el script de inicialización tenga permisos de ejecución
-chmod +x ./localstack/init-aws.sh
-
 # Crear archivo .env desde el ejemplo si no existe
 if [ ! -f .env ]; then
     echo "Creando archivo .env desde .env.example..."
@@ -32,6 +29,11 @@ docker-compose down
 echo "Iniciando servicios locales (Redis y LocalStack)..."
 docker-compose up -d
 
+# El servicio migrate crea los recursos de AWS con twitctl migrate; si falla,
+# su salida indica qué no coincide con lo declarado
+echo "Esperando la migración de recursos AWS..."
+docker-compose logs migrate
+
 echo "Esperando a que los servicios estén listos..."
 sleep 10
 